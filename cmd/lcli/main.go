@@ -2,9 +2,19 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
 
+	"github.com/Softorize/lcli/internal/auth"
 	"github.com/Softorize/lcli/internal/client"
 	"github.com/Softorize/lcli/internal/command"
 	"github.com/Softorize/lcli/internal/config"
@@ -13,51 +23,294 @@ import (
 )
 
 func main() {
-	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(1)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		// After the first interrupt, restore the default handling so a
+		// second Ctrl-C exits immediately.
+		<-ctx.Done()
+		stop()
+	}()
+
+	if isTerminal(os.Stdin) {
+		config.PassphrasePrompt = promptPassphrase
+	}
+
+	deps := &command.Deps{
+		Ctx:    ctx,
+		Output: output.NewPrinter(os.Stdout, output.FormatTable),
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		Init:   initDeps,
+	}
+
+	err := command.Run(os.Args[1:], deps)
+	stop()
+	if closeErr := deps.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if command.JSONOutput(deps) {
+			_ = command.WriteJSONError(os.Stderr, err)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s %v\n", errorPrefix(deps), err)
+		}
+		os.Exit(command.ExitCode(err))
 	}
 }
 
-func run() error {
+// initDeps loads the configuration and services once command.Run has parsed
+// the global flags, which may select a profile or override settings.
+func initDeps(deps *command.Deps) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
+	deps.Cfg = cfg
+	if cfg.SecretErr != nil {
+		// Only commands that log in need the client secret.
+		fmt.Fprintf(deps.Stderr, "warning: %v\n", cfg.SecretErr)
+	}
 
-	deps := &command.Deps{
-		Cfg:    cfg,
-		Output: output.NewPrinter(os.Stdout, output.FormatTable),
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
+	if deps.Global.Verbose {
+		fmt.Fprintf(deps.Stderr, "Profile: %s, API version: %s\n", config.ActiveProfile(), cfg.APIVersion)
 	}
 
-	if err := initServices(cfg, deps); err != nil {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return err
+	}
+	tracer, err := newTracer(deps)
+	if err != nil {
+		return err
+	}
+	cache := client.NewCache(config.CacheDir())
+	deps.Cache = cache
+	if deps.Global.NoCache {
+		cache = cache.WithoutReads()
+	}
+
+	// OAuth requests and media uploads bypass the API client, and with it
+	// the cache, but still go over the configured network and --trace.
+	httpClient := &http.Client{Transport: transport}
+	if tracer != nil {
+		httpClient.Transport = tracer.Transport(transport)
+	}
+	deps.HTTP = httpClient
+
+	limiter, err := newRateLimiter(cfg, deps.Stderr)
+	if err != nil {
+		return err
+	}
+	deps.RateLimits = limiter
+
+	if err := initServices(cfg, deps, transport, tracer, limiter, cache); err != nil {
 		// Non-fatal: services will be nil and commands that need
 		// auth will return an appropriate error.
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		fmt.Fprintf(deps.Stderr, "warning: %v\n", err)
+	}
+	return nil
+}
+
+// errorPrefix returns the "error:" label, in red when stderr is a terminal
+// and color is not disabled.
+func errorPrefix(deps *command.Deps) string {
+	if deps.Global.NoColor {
+		return "error:"
+	}
+	if !isTerminal(os.Stderr) {
+		return "error:"
+	}
+	return "\x1b[31merror:\x1b[0m"
+}
+
+// isTerminal reports whether f is a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// promptPassphrase asks for the encrypted credential store passphrase on the
+// controlling terminal, with echo turned off where stty is available.
+func promptPassphrase() (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", err
+	}
+	defer tty.Close()
+
+	fmt.Fprintf(tty, "Passphrase for the encrypted credential store (or set LCLI_KEY): ")
+	if stty(tty, "-echo") == nil {
+		defer func() {
+			stty(tty, "echo")
+			fmt.Fprintln(tty)
+		}()
 	}
+	line, err := bufio.NewReader(tty).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
 
-	return command.Run(os.Args[1:], deps)
+// stty applies a terminal setting to tty.
+func stty(tty *os.File, setting string) error {
+	cmd := exec.Command("stty", setting)
+	cmd.Stdin = tty
+	return cmd.Run()
+}
+
+// newTracer returns the HTTP tracer selected by --verbose, --trace,
+// LCLI_DEBUG and --trace-file, or nil if tracing is off.
+func newTracer(deps *command.Deps) (*client.Tracer, error) {
+	g := deps.Global
+
+	var summary, ndjson io.Writer
+	if g.Verbose || g.Trace {
+		summary = deps.Stderr
+	}
+	if g.TraceFile != "" {
+		f, err := os.OpenFile(g.TraceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("open trace file: %w", err)
+		}
+		deps.Closers = append(deps.Closers, f)
+		ndjson = f
+	}
+	if summary == nil && ndjson == nil {
+		return nil, nil
+	}
+	return client.NewTracer(summary, g.Trace, ndjson), nil
+}
+
+// newTransport returns the transport all HTTP requests go through: the
+// network as configured by the proxy, CA bundle and client certificate
+// settings, wrapped by a cassette if one is selected.
+func newTransport(cfg *config.Config) (http.RoundTripper, error) {
+	network, err := client.NewTransport(client.TransportConfig{
+		ProxyURL:   cfg.ProxyURL,
+		CABundle:   cfg.CABundle,
+		ClientCert: cfg.ClientCert,
+		ClientKey:  cfg.ClientKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("configure HTTP transport: %w", err)
+	}
+	cassette, err := cassetteTransport(network)
+	if err != nil || cassette == nil {
+		return network, err
+	}
+	return cassette, nil
+}
+
+// cassetteTransport returns the recorder selected by LCLI_RECORD, which
+// sends requests on through network, or the replayer selected by
+// LCLI_REPLAY, or nil if neither is set.
+func cassetteTransport(network http.RoundTripper) (http.RoundTripper, error) {
+	record, replay := os.Getenv("LCLI_RECORD"), os.Getenv("LCLI_REPLAY")
+	switch {
+	case record != "" && replay != "":
+		return nil, errors.New("LCLI_RECORD and LCLI_REPLAY cannot be used together")
+	case record != "":
+		rec, err := client.NewRecorder(record, network)
+		if err != nil {
+			return nil, fmt.Errorf("LCLI_RECORD: %w", err)
+		}
+		return rec, nil
+	case replay != "":
+		rep, err := client.NewReplayer(replay)
+		if err != nil {
+			return nil, fmt.Errorf("LCLI_REPLAY: %w", err)
+		}
+		return rep, nil
+	default:
+		return nil, nil
+	}
 }
 
-func initServices(cfg *config.Config, deps *command.Deps) error {
+// newRateLimiter returns the limiter enforcing rate_limits, whose state is
+// shared with other lcli processes using the same profile. Without limits it
+// only records the quota LinkedIn reports. Failures to update the state are
+// warned about on stderr.
+func newRateLimiter(cfg *config.Config, stderr io.Writer) (*client.RateLimiter, error) {
+	limits, err := config.ParseRateLimits(cfg.RateLimits)
+	if err != nil {
+		return nil, fmt.Errorf("rate_limits: %w", err)
+	}
+	return client.NewRateLimiter(limits, config.RateLimitStatePath(), stderr), nil
+}
+
+func initServices(cfg *config.Config, deps *command.Deps, transport http.RoundTripper, tracer *client.Tracer, limiter *client.RateLimiter, cache *client.Cache) error {
 	token, err := config.LoadToken()
 	if err != nil {
 		return fmt.Errorf("load token: %w", err)
 	}
 
-	if token == nil || !token.Valid() {
+	if token == nil {
+		return nil
+	}
+
+	authenticator := auth.NewAuthenticator(cfg, deps.HTTP)
+	if token.NeedsRefresh() {
+		fresh, err := authenticator.Renew(deps.Ctx, token)
+		if err != nil && !token.Valid() {
+			return fmt.Errorf("refresh token: %w", err)
+		}
+		if err == nil {
+			token = fresh
+		}
+	}
+
+	if !token.Valid() {
 		return nil
 	}
 
-	cli := client.New(token.AccessToken, cfg.APIVersion)
+	retry := client.DefaultRetryPolicy()
+	retry.MaxRetries = cfg.MaxRetries
+	retry.RetryPOST = cfg.RetryPOST
 
-	deps.Profile = linkedin.NewProfileService(cli, token.AccessToken)
+	refresher := func(ctx context.Context) (string, error) {
+		fresh, err := authenticator.Renew(ctx, token)
+		if err != nil {
+			return "", err
+		}
+		token = fresh
+		return fresh.AccessToken, nil
+	}
+
+	opts := []client.Option{
+		client.WithRetryPolicy(retry),
+		client.WithTokenRefresher(refresher),
+		client.WithTransport(transport),
+	}
+	if limiter != nil {
+		opts = append(opts, client.WithRateLimiter(limiter))
+	}
+	if tracer != nil {
+		opts = append(opts, client.WithTracer(tracer))
+	}
+	if cache != nil {
+		opts = append(opts, client.WithCache(cache))
+	}
+	if cfg.APIBaseURL != "" {
+		opts = append(opts, client.WithBaseURL(cfg.APIBaseURL))
+	}
+	cli := client.New(token.AccessToken, cfg.APIVersion, opts...)
+
+	deps.Scopes = token.Scopes
+	deps.AppToken = token.IsApp()
+	// An app token acts as no member, so member-only services stay nil.
+	if !token.IsApp() {
+		profile := linkedin.NewProfileService(cli)
+		if cfg.UserinfoURL != "" {
+			profile.UseUserinfoURL(cfg.UserinfoURL)
+		}
+		deps.Profile = profile
+	}
 	deps.Posts = linkedin.NewPostService(cli)
 	deps.Comments = linkedin.NewCommentService(cli)
 	deps.Reactions = linkedin.NewReactionService(cli)
-	deps.Media = linkedin.NewMediaService(cli)
+	deps.Media = linkedin.NewMediaService(cli, deps.HTTP)
 	deps.Orgs = linkedin.NewOrgService(cli)
 	deps.Analytics = linkedin.NewAnalyticsService(cli)
 
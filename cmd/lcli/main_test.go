@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/command"
+	"github.com/Softorize/lcli/internal/linkedintest"
+	"github.com/Softorize/lcli/internal/output"
+)
+
+// run executes lcli with args the way main does, against the fake server
+// the environment points at.
+func run(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	deps := &command.Deps{
+		Ctx:    context.Background(),
+		Output: output.NewPrinter(&stdout, output.FormatTable),
+		Stdin:  strings.NewReader(""),
+		Stdout: &stdout,
+		Stderr: &stderr,
+		Init:   initDeps,
+	}
+	err := command.Run(args, deps)
+	if closeErr := deps.Close(); err == nil {
+		err = closeErr
+	}
+	return stdout.String(), stderr.String(), err
+}
+
+func TestEndToEndAgainstFakeServer(t *testing.T) {
+	srv := linkedintest.NewServer()
+	defer srv.Close()
+
+	t.Setenv("LCLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("LCLI_API_BASE_URL", srv.APIBaseURL())
+	t.Setenv("LCLI_OAUTH_BASE_URL", srv.OAuthBaseURL())
+	t.Setenv("LCLI_USERINFO_URL", srv.UserinfoURL())
+	t.Setenv("LCLI_ACCESS_TOKEN", linkedintest.Token)
+
+	out, _, err := run(t, "--output", "json", "profile", "me")
+	if err != nil {
+		t.Fatalf("profile me: %v", err)
+	}
+	if !strings.Contains(out, `"id": "`+linkedintest.MemberID+`"`) {
+		t.Errorf("profile me = %s", out)
+	}
+
+	_, stderr, err := run(t, "post", "create", "--text", "Hello from the fake")
+	if err != nil {
+		t.Fatalf("post create: %v", err)
+	}
+	if !strings.Contains(stderr, "Post created: urn:li:share:") {
+		t.Errorf("post create stderr = %q", stderr)
+	}
+
+	out, _, err = run(t, "--output", "json", "post", "list", "--author", "urn:li:person:"+linkedintest.MemberID)
+	if err != nil {
+		t.Fatalf("post list: %v", err)
+	}
+	var list struct {
+		Elements []struct {
+			ID   string `json:"id"`
+			Text string `json:"text"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("decode post list %q: %v", out, err)
+	}
+	if len(list.Elements) != 1 || list.Elements[0].Text != "Hello from the fake" {
+		t.Fatalf("post list = %+v", list)
+	}
+
+	if _, _, err := run(t, "post", "edit", "--confirm", "--text", "Hello, edited", list.Elements[0].ID); err != nil {
+		t.Fatalf("post edit: %v", err)
+	}
+	out, _, err = run(t, "--output", "json", "post", "get", list.Elements[0].ID)
+	if err != nil || !strings.Contains(out, `"text": "Hello, edited"`) {
+		t.Errorf("post get after edit = %s, %v", out, err)
+	}
+
+	_, stderr, err = run(t, "post", "reshare", "--text", "Worth a read", list.Elements[0].ID)
+	if err != nil {
+		t.Fatalf("post reshare: %v", err)
+	}
+	reshare := strings.TrimSpace(strings.TrimPrefix(stderr, "Post reshared: "))
+	out, _, err = run(t, "post", "get", reshare)
+	if err != nil || !strings.Contains(out, "Reshare of") || !strings.Contains(out, list.Elements[0].ID) {
+		t.Errorf("post get reshare = %s, %v", out, err)
+	}
+	if _, _, err := run(t, "post", "delete", "--confirm", reshare); err != nil {
+		t.Fatalf("post delete reshare: %v", err)
+	}
+
+	if _, _, err := run(t, "post", "delete", "--confirm", list.Elements[0].ID); err != nil {
+		t.Fatalf("post delete: %v", err)
+	}
+	if n := srv.PostCount(); n != 0 {
+		t.Errorf("PostCount after delete = %d, want 0", n)
+	}
+
+	_, _, err = run(t, "post", "get", list.Elements[0].ID)
+	if code := command.ExitCode(err); code != command.ExitNotFound {
+		t.Errorf("post get after delete: %v, exit code %d, want %d", err, code, command.ExitNotFound)
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	srv := linkedintest.NewServer()
+	defer srv.Close()
+
+	t.Setenv("LCLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("LCLI_API_BASE_URL", srv.APIBaseURL())
+	t.Setenv("LCLI_USERINFO_URL", srv.UserinfoURL())
+	t.Setenv("LCLI_ACCESS_TOKEN", linkedintest.Token)
+
+	if _, _, err := run(t, "profile", "me"); err != nil {
+		t.Fatalf("profile me: %v", err)
+	}
+
+	out, _, err := run(t, "--output", "json", "cache", "stats")
+	if err != nil {
+		t.Fatalf("cache stats: %v", err)
+	}
+	var stats struct {
+		Entries   int `json:"entries"`
+		Resources []struct {
+			Resource string `json:"resource"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal([]byte(out), &stats); err != nil {
+		t.Fatalf("decode cache stats %q: %v", out, err)
+	}
+	if stats.Entries != 1 || stats.Resources[0].Resource != "userinfo" {
+		t.Errorf("cache stats = %s", out)
+	}
+
+	_, stderr, err := run(t, "cache", "clear")
+	if err != nil || !strings.Contains(stderr, "Removed 1 cached responses") {
+		t.Errorf("cache clear: %v, %q", err, stderr)
+	}
+
+	if _, _, err := run(t, "--no-cache", "profile", "me"); err != nil {
+		t.Fatalf("profile me --no-cache: %v", err)
+	}
+	if out, _, _ := run(t, "--output", "json", "cache", "stats"); !strings.Contains(out, `"entries": 0`) {
+		t.Errorf("--no-cache stored a response: %s", out)
+	}
+}
+
+func TestNoCacheWritesInvalidateCache(t *testing.T) {
+	srv := linkedintest.NewServer()
+	defer srv.Close()
+
+	t.Setenv("LCLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("LCLI_API_BASE_URL", srv.APIBaseURL())
+	t.Setenv("LCLI_USERINFO_URL", srv.UserinfoURL())
+	t.Setenv("LCLI_ACCESS_TOKEN", linkedintest.Token)
+
+	_, stderr, err := run(t, "post", "create", "--text", "Soon gone")
+	if err != nil {
+		t.Fatalf("post create: %v", err)
+	}
+	urn := strings.TrimSpace(strings.TrimPrefix(stderr, "Post created: "))
+	if _, _, err := run(t, "post", "get", urn); err != nil {
+		t.Fatalf("post get: %v", err)
+	}
+
+	// The delete skips cached reads but must still drop the cached post.
+	if _, _, err := run(t, "--no-cache", "post", "delete", "--confirm", urn); err != nil {
+		t.Fatalf("post delete --no-cache: %v", err)
+	}
+	_, _, err = run(t, "post", "get", urn)
+	if code := command.ExitCode(err); code != command.ExitNotFound {
+		t.Errorf("post get after delete: %v, exit code %d, want %d", err, code, command.ExitNotFound)
+	}
+}
+
+func TestRateLimitStatusRecordsQuotaWithoutLimits(t *testing.T) {
+	srv := linkedintest.NewServer()
+	defer srv.Close()
+	srv.SetRateLimit(100, time.Hour)
+
+	t.Setenv("LCLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("LCLI_API_BASE_URL", srv.APIBaseURL())
+	t.Setenv("LCLI_USERINFO_URL", srv.UserinfoURL())
+	t.Setenv("LCLI_ACCESS_TOKEN", linkedintest.Token)
+
+	if _, _, err := run(t, "post", "list", "--author", "urn:li:person:"+linkedintest.MemberID); err != nil {
+		t.Fatalf("post list: %v", err)
+	}
+	out, _, err := run(t, "--output", "json", "ratelimit", "status")
+	if err != nil {
+		t.Fatalf("ratelimit status: %v", err)
+	}
+	if !strings.Contains(out, `"family": "posts"`) || !strings.Contains(out, `"limit": 100`) {
+		t.Errorf("ratelimit status without rate_limits = %s, want the observed posts quota", out)
+	}
+}
+
+func TestTraceFileIsClosed(t *testing.T) {
+	srv := linkedintest.NewServer()
+	defer srv.Close()
+
+	t.Setenv("LCLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("LCLI_API_BASE_URL", srv.APIBaseURL())
+	t.Setenv("LCLI_USERINFO_URL", srv.UserinfoURL())
+	t.Setenv("LCLI_ACCESS_TOKEN", linkedintest.Token)
+
+	trace := filepath.Join(t.TempDir(), "trace.ndjson")
+	deps := &command.Deps{
+		Ctx:    context.Background(),
+		Output: output.NewPrinter(io.Discard, output.FormatTable),
+		Stdin:  strings.NewReader(""),
+		Stdout: io.Discard,
+		Stderr: io.Discard,
+		Init:   initDeps,
+	}
+	if err := command.Run([]string{"--trace-file", trace, "profile", "me"}, deps); err != nil {
+		t.Fatalf("profile me: %v", err)
+	}
+	if len(deps.Closers) != 1 {
+		t.Fatalf("Closers = %v, want the trace file", deps.Closers)
+	}
+	f := deps.Closers[0].(*os.File)
+	if err := deps.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := f.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("write after Close: err = %v, want os.ErrClosed", err)
+	}
+
+	data, err := os.ReadFile(trace)
+	if err != nil || !strings.Contains(string(data), "/userinfo") {
+		t.Errorf("trace file = %q, %v", data, err)
+	}
+}
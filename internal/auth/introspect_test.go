@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Softorize/lcli/internal/config"
+)
+
+func TestIntrospect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != introspectPath {
+			t.Errorf("path = %s, want %s", r.URL.Path, introspectPath)
+		}
+		_ = r.ParseForm()
+		if got := r.FormValue("token"); got != "tok" {
+			t.Errorf("token = %q, want tok", got)
+		}
+		if got := r.FormValue("client_id"); got != "cid" {
+			t.Errorf("client_id = %q, want cid", got)
+		}
+		w.Write([]byte(`{"active":true,"status":"active","client_id":"cid","auth_type":"3L",
+			"scope":"openid,profile","created_at":1700000000,"expires_at":1705184000}`))
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", ClientSecret: "cs", OAuthBaseURL: srv.URL + "/"}, nil)
+	info, err := a.Introspect(context.Background(), "tok")
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+	if !info.Active || info.ClientID != "cid" || info.AuthType != "3L" {
+		t.Errorf("info = %+v", info)
+	}
+	if strings.Join(info.Scopes, " ") != "openid profile" {
+		t.Errorf("Scopes = %v", info.Scopes)
+	}
+	if info.ExpiresAt.Unix() != 1705184000 {
+		t.Errorf("ExpiresAt = %v", info.ExpiresAt)
+	}
+	if !info.AuthorizedAt.IsZero() {
+		t.Errorf("AuthorizedAt = %v, want zero", info.AuthorizedAt)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	var revoked string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != revokePath {
+			t.Errorf("path = %s, want %s", r.URL.Path, revokePath)
+		}
+		_ = r.ParseForm()
+		revoked = r.FormValue("token")
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", ClientSecret: "cs", OAuthBaseURL: srv.URL}, nil)
+	if err := a.Revoke(context.Background(), "tok"); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if revoked != "tok" {
+		t.Errorf("revoked token = %q, want tok", revoked)
+	}
+}
+
+func TestRevokeErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", OAuthBaseURL: srv.URL}, nil)
+	err := a.Revoke(context.Background(), "tok")
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("err = %v, want 401 error", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,21 +18,50 @@ import (
 )
 
 const (
-	authEndpoint  = "https://www.linkedin.com/oauth/v2/authorization"
-	tokenEndpoint = "https://www.linkedin.com/oauth/v2/accessToken"
+	// defaultOAuthBase is LinkedIn's OAuth 2.0 endpoint base. Config.OAuthBaseURL
+	// overrides it, e.g. to test against a local stub server, and
+	// Config.AuthURL and Config.TokenURL override single endpoints.
+	defaultOAuthBase = "https://www.linkedin.com/oauth/v2"
+
+	authPath       = "/authorization"
+	tokenPath      = "/accessToken"
+	introspectPath = "/introspectToken"
+	revokePath     = "/revoke"
 )
 
-// defaultScopes are the OAuth scopes requested during authorization.
+// defaultScopes are the OAuth scopes always requested during authorization.
+// Config.Scopes adds to them.
 var defaultScopes = []string{"openid", "profile", "email", "w_member_social"}
 
 // Authenticator handles LinkedIn OAuth 2.0 flows.
 type Authenticator struct {
-	cfg *config.Config
+	cfg      *config.Config
+	http     *http.Client
+	baseURL  string
+	verifier string
 }
 
 // NewAuthenticator creates an Authenticator using the provided configuration.
-func NewAuthenticator(cfg *config.Config) *Authenticator {
-	return &Authenticator{cfg: cfg}
+// Requests are sent with hc, or http.DefaultClient if hc is nil.
+func NewAuthenticator(cfg *config.Config, hc *http.Client) *Authenticator {
+	base := defaultOAuthBase
+	if cfg.OAuthBaseURL != "" {
+		base = strings.TrimRight(cfg.OAuthBaseURL, "/")
+	}
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &Authenticator{
+		cfg:     cfg,
+		http:    hc,
+		baseURL: base,
+	}
+}
+
+// UsePKCE makes AuthorizationURL send the S256 challenge for verifier and
+// Exchange send the verifier itself. See NewVerifier.
+func (a *Authenticator) UsePKCE(verifier string) {
+	a.verifier = verifier
 }
 
 // AuthorizationURL builds the LinkedIn authorization URL with the given state
@@ -42,48 +72,143 @@ func (a *Authenticator) AuthorizationURL(state string) string {
 		"client_id":     {a.cfg.ClientID},
 		"redirect_uri":  {a.cfg.RedirectURI},
 		"state":         {state},
-		"scope":         {strings.Join(defaultScopes, " ")},
+		"scope":         {strings.Join(a.Scopes(), " ")},
+	}
+	if a.verifier != "" {
+		params.Set("code_challenge", Challenge(a.verifier))
+		params.Set("code_challenge_method", pkceMethod)
+	}
+	return a.endpoint(authPath) + "?" + params.Encode()
+}
+
+// Scopes returns the scopes requested by AuthorizationURL: the defaults
+// followed by any extra scopes from the configuration, without duplicates.
+func (a *Authenticator) Scopes() []string {
+	scopes := slices.Clone(defaultScopes)
+	for _, s := range a.cfg.Scopes {
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
 	}
-	return authEndpoint + "?" + params.Encode()
+	return scopes
+}
+
+// ParseScopes splits a scope list such as the scope field of a token
+// response. LinkedIn separates scopes with commas; the OAuth spec uses spaces.
+func ParseScopes(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
 }
 
 // tokenResponse is the JSON body returned by the token endpoint.
 type tokenResponse struct {
-	AccessToken  string `json:"access_token"`
-	ExpiresIn    int64  `json:"expires_in"`
-	RefreshToken string `json:"refresh_token"`
-	Scope        string `json:"scope"`
+	AccessToken           string `json:"access_token"`
+	ExpiresIn             int64  `json:"expires_in"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresIn int64  `json:"refresh_token_expires_in"`
+	Scope                 string `json:"scope"`
 }
 
-// Exchange trades an authorization code for an access token.
+// Exchange trades an authorization code for an access token. With PKCE the
+// code verifier is sent, and the client secret only if one is configured.
 func (a *Authenticator) Exchange(ctx context.Context, code string) (*config.Token, error) {
-	data := url.Values{
-		"grant_type":    {"authorization_code"},
-		"code":          {code},
-		"redirect_uri":  {a.cfg.RedirectURI},
-		"client_id":     {a.cfg.ClientID},
-		"client_secret": {a.cfg.ClientSecret},
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {a.cfg.RedirectURI},
+	}
+	if a.verifier != "" {
+		form.Set("code_verifier", a.verifier)
 	}
+	return a.requestToken(ctx, a.withClient(form))
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(data.Encode()))
+// Refresh trades a refresh token for a new access token. LinkedIn may rotate
+// the refresh token; if the response omits one, the original is kept.
+func (a *Authenticator) Refresh(ctx context.Context, refreshToken string) (*config.Token, error) {
+	tok, err := a.requestToken(ctx, a.withClient(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	}))
 	if err != nil {
-		return nil, fmt.Errorf("build token request: %w", err)
+		return nil, fmt.Errorf("refresh: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := http.DefaultClient.Do(req)
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = refreshToken
+	}
+	return tok, nil
+}
+
+// ClientCredentials obtains an application token with the two-legged
+// client-credentials grant. No member is involved, so it works without a
+// browser but only for endpoints that accept app tokens.
+func (a *Authenticator) ClientCredentials(ctx context.Context) (*config.Token, error) {
+	tok, err := a.requestToken(ctx, url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {a.cfg.ClientID},
+		"client_secret": {a.cfg.ClientSecret},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("token request: %w", err)
+		return nil, fmt.Errorf("client credentials: %w", err)
 	}
-	defer resp.Body.Close()
+	tok.Type = config.TokenApp
+	return tok, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// Renew refreshes tok and persists the result with config.SaveToken. The
+// refresh token expiry is carried over when LinkedIn does not report a new one.
+// App tokens are re-issued with ClientCredentials instead.
+func (a *Authenticator) Renew(ctx context.Context, tok *config.Token) (*config.Token, error) {
+	if tok.IsApp() {
+		fresh, err := a.ClientCredentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := config.SaveToken(fresh); err != nil {
+			return nil, err
+		}
+		return fresh, nil
+	}
+
+	if !tok.CanRefresh() {
+		return nil, fmt.Errorf("no usable refresh token — run 'lcli auth login' again")
+	}
+
+	fresh, err := a.Refresh(ctx, tok.RefreshToken)
 	if err != nil {
-		return nil, fmt.Errorf("read token response: %w", err)
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, body)
+	if fresh.RefreshExpiresAt.IsZero() && fresh.RefreshToken == tok.RefreshToken {
+		fresh.RefreshExpiresAt = tok.RefreshExpiresAt
+	}
+	if len(fresh.Scopes) == 0 {
+		fresh.Scopes = tok.Scopes
+	}
+
+	if err := config.SaveToken(fresh); err != nil {
+		return nil, err
+	}
+	return fresh, nil
+}
+
+// withClient adds the client credentials to form. Native clients using PKCE
+// have no secret, so an empty one is omitted.
+func (a *Authenticator) withClient(form url.Values) url.Values {
+	form.Set("client_id", a.cfg.ClientID)
+	if a.cfg.ClientSecret != "" {
+		form.Set("client_secret", a.cfg.ClientSecret)
+	}
+	return form
+}
+
+// requestToken posts form to the token endpoint and decodes the result.
+func (a *Authenticator) requestToken(ctx context.Context, form url.Values) (*config.Token, error) {
+	body, err := a.postForm(ctx, tokenPath, "token", form)
+	if err != nil {
+		return nil, err
 	}
 
 	var tr tokenResponse
@@ -91,15 +216,58 @@ func (a *Authenticator) Exchange(ctx context.Context, code string) (*config.Toke
 		return nil, fmt.Errorf("parse token response: %w", err)
 	}
 
+	now := time.Now()
 	tok := &config.Token{
 		AccessToken:  tr.AccessToken,
 		RefreshToken: tr.RefreshToken,
-		ExpiresAt:    time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second),
-		Scopes:       strings.Fields(tr.Scope),
+		ExpiresAt:    now.Add(time.Duration(tr.ExpiresIn) * time.Second),
+		Scopes:       ParseScopes(tr.Scope),
+		Type:         config.TokenMember,
+	}
+	if tr.RefreshTokenExpiresIn > 0 {
+		tok.RefreshExpiresAt = now.Add(time.Duration(tr.RefreshTokenExpiresIn) * time.Second)
 	}
 	return tok, nil
 }
 
+// endpoint returns the URL of the OAuth endpoint at path below the OAuth
+// base, unless the configuration overrides that endpoint.
+func (a *Authenticator) endpoint(path string) string {
+	switch {
+	case path == authPath && a.cfg.AuthURL != "":
+		return a.cfg.AuthURL
+	case path == tokenPath && a.cfg.TokenURL != "":
+		return a.cfg.TokenURL
+	}
+	return a.baseURL + path
+}
+
+// postForm posts form to the endpoint at path and returns the body of a 200
+// response. name labels errors.
+func (a *Authenticator) postForm(ctx context.Context, path, name string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint(path), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("build %s request: %w", name, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read %s response: %w", name, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s endpoint returned %d: %s", name, resp.StatusCode, body)
+	}
+	return body, nil
+}
+
 // RandomState generates a cryptographically random hex string for use as an
 // OAuth state parameter.
 func RandomState() (string, error) {
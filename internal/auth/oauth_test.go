@@ -1,12 +1,14 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Softorize/lcli/internal/config"
 )
@@ -16,7 +18,7 @@ func TestAuthorizationURL(t *testing.T) {
 		ClientID:    "my-client-id",
 		RedirectURI: "http://localhost:8484/callback",
 	}
-	a := NewAuthenticator(cfg)
+	a := NewAuthenticator(cfg, nil)
 	rawURL := a.AuthorizationURL("teststate")
 
 	parsed, err := url.Parse(rawURL)
@@ -41,8 +43,8 @@ func TestAuthorizationURL(t *testing.T) {
 		})
 	}
 
-	if !strings.HasPrefix(rawURL, authEndpoint) {
-		t.Errorf("URL should start with %s", authEndpoint)
+	if want := defaultOAuthBase + authPath; !strings.HasPrefix(rawURL, want) {
+		t.Errorf("URL should start with %s", want)
 	}
 }
 
@@ -93,9 +95,18 @@ func TestExchangeFormParams(t *testing.T) {
 		json.NewEncoder(w).Encode(resp)
 	}))
 	defer srv.Close()
-	// Note: We cannot redirect Exchange to the test server since tokenEndpoint
-	// is a const. The handler above validates the expected form parameters.
-	_ = srv
+
+	a := NewAuthenticator(&config.Config{
+		ClientID: "cid", ClientSecret: "cs", RedirectURI: "http://localhost/cb",
+		OAuthBaseURL: srv.URL,
+	}, nil)
+	tok, err := a.Exchange(context.Background(), "authcode")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if tok.AccessToken != "tok" || tok.RefreshToken != "ref" {
+		t.Errorf("token = %+v", tok)
+	}
 }
 
 func TestExchangeErrorStatus(t *testing.T) {
@@ -109,10 +120,214 @@ func TestExchangeErrorStatus(t *testing.T) {
 	}
 	for _, tt := range configs {
 		t.Run(tt.name, func(t *testing.T) {
-			a := NewAuthenticator(&tt.cfg)
+			a := NewAuthenticator(&tt.cfg, nil)
 			if a == nil {
 				t.Fatal("authenticator is nil")
 			}
 		})
 	}
 }
+
+func TestEndpointOverrides(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "tok", "expires_in": 3600})
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{
+		ClientID:     "cid",
+		OAuthBaseURL: srv.URL + "/base/",
+		AuthURL:      "https://sso.example.test/authorize",
+		TokenURL:     srv.URL + "/mirror/token",
+	}, nil)
+
+	if got := a.AuthorizationURL("s"); !strings.HasPrefix(got, "https://sso.example.test/authorize?") {
+		t.Errorf("AuthorizationURL = %q", got)
+	}
+	if _, err := a.Refresh(context.Background(), "ref"); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if err := a.Revoke(context.Background(), "tok"); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if want := []string{"/mirror/token", "/base/revoke"}; strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+}
+
+func TestRefreshFormParams(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		checks := map[string]string{
+			"grant_type": "refresh_token", "refresh_token": "ref-old",
+			"client_id": "cid", "client_secret": "cs",
+		}
+		for k, want := range checks {
+			if got := r.FormValue(k); got != want {
+				t.Errorf("form %s = %q, want %q", k, got, want)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "new-tok", "expires_in": 3600,
+			"refresh_token_expires_in": 7200,
+		})
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", ClientSecret: "cs"}, nil)
+	a.baseURL = srv.URL
+
+	tok, err := a.Refresh(context.Background(), "ref-old")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if tok.AccessToken != "new-tok" {
+		t.Errorf("AccessToken = %q, want new-tok", tok.AccessToken)
+	}
+	if tok.RefreshToken != "ref-old" {
+		t.Errorf("RefreshToken = %q, want original token kept", tok.RefreshToken)
+	}
+	if tok.RefreshExpiresAt.IsZero() {
+		t.Error("RefreshExpiresAt not set")
+	}
+}
+
+func TestRefreshErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", ClientSecret: "cs"}, nil)
+	a.baseURL = srv.URL
+
+	_, err := a.Refresh(context.Background(), "ref")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("error = %q, want token endpoint body", err)
+	}
+}
+
+func TestRenewPersistsRotatedToken(t *testing.T) {
+	isolateHome(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "new-tok", "expires_in": 3600, "refresh_token": "ref-new",
+		})
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", ClientSecret: "cs"}, nil)
+	a.baseURL = srv.URL
+
+	old := &config.Token{
+		AccessToken:  "old-tok",
+		RefreshToken: "ref-old",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		Scopes:       []string{"openid"},
+	}
+	if _, err := a.Renew(context.Background(), old); err != nil {
+		t.Fatalf("Renew: %v", err)
+	}
+
+	saved, err := config.LoadToken()
+	if err != nil {
+		t.Fatalf("LoadToken: %v", err)
+	}
+	if saved.AccessToken != "new-tok" || saved.RefreshToken != "ref-new" {
+		t.Errorf("saved token = %+v, want rotated tokens", saved)
+	}
+	if len(saved.Scopes) != 1 || saved.Scopes[0] != "openid" {
+		t.Errorf("Scopes = %v, want carried over", saved.Scopes)
+	}
+}
+
+func TestRenewWithoutRefreshToken(t *testing.T) {
+	a := NewAuthenticator(&config.Config{}, nil)
+	_, err := a.Renew(context.Background(), &config.Token{AccessToken: "tok"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestScopesIncludesConfigured(t *testing.T) {
+	a := NewAuthenticator(&config.Config{Scopes: []string{"rw_organization_admin", "openid"}}, nil)
+	got := strings.Join(a.Scopes(), " ")
+	if want := "openid profile email w_member_social rw_organization_admin"; got != want {
+		t.Errorf("Scopes() = %q, want %q", got, want)
+	}
+}
+
+func TestParseScopes(t *testing.T) {
+	got := ParseScopes("email,openid, profile w_member_social")
+	if strings.Join(got, "|") != "email|openid|profile|w_member_social" {
+		t.Errorf("ParseScopes = %q", got)
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		checks := map[string]string{"grant_type": "client_credentials", "client_id": "cid", "client_secret": "cs"}
+		for k, want := range checks {
+			if got := r.FormValue(k); got != want {
+				t.Errorf("form %s = %q, want %q", k, got, want)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "app-tok", "expires_in": 1800})
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", ClientSecret: "cs", OAuthBaseURL: srv.URL}, nil)
+	tok, err := a.ClientCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("ClientCredentials: %v", err)
+	}
+	if tok.AccessToken != "app-tok" || !tok.IsApp() {
+		t.Errorf("token = %+v, want app token", tok)
+	}
+}
+
+func TestRenewReissuesAppToken(t *testing.T) {
+	isolateHome(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if got := r.FormValue("grant_type"); got != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", got)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "app-new", "expires_in": 1800})
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", ClientSecret: "cs", OAuthBaseURL: srv.URL}, nil)
+	old := &config.Token{AccessToken: "app-old", Type: config.TokenApp, ExpiresAt: time.Now().Add(-time.Minute)}
+
+	fresh, err := a.Renew(context.Background(), old)
+	if err != nil {
+		t.Fatalf("Renew: %v", err)
+	}
+	if fresh.AccessToken != "app-new" || !fresh.IsApp() {
+		t.Errorf("fresh = %+v", fresh)
+	}
+	saved, err := config.LoadToken()
+	if err != nil || saved == nil || saved.AccessToken != "app-new" {
+		t.Errorf("saved token = %+v, %v", saved, err)
+	}
+}
+
+// isolateHome points the configuration at a temporary home directory and
+// clears the environment overrides that would redirect it.
+func isolateHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	for _, env := range []string{"LCLI_CONFIG_DIR", "XDG_CONFIG_HOME", "LCLI_PROFILE", "LCLI_ACCESS_TOKEN"} {
+		t.Setenv(env, "")
+	}
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Softorize/lcli/internal/config"
+)
+
+func TestChallengeS256(t *testing.T) {
+	// base64url(sha256(verifier)) without padding.
+	got := Challenge("lcli-test-verifier")
+	if want := "YUyYVujdqQJQlhm7NuSXPi8g65-Mbd-DCMixI-EnXB0"; got != want {
+		t.Errorf("Challenge = %q, want %q", got, want)
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	v, err := NewVerifier()
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	if len(v) != 43 {
+		t.Errorf("length = %d, want 43", len(v))
+	}
+}
+
+func TestAuthorizationURLWithPKCE(t *testing.T) {
+	a := NewAuthenticator(&config.Config{ClientID: "cid", RedirectURI: "http://localhost/cb"}, nil)
+	a.UsePKCE("verifier")
+
+	parsed, err := url.Parse(a.AuthorizationURL("st"))
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+	q := parsed.Query()
+	if got := q.Get("code_challenge"); got != Challenge("verifier") {
+		t.Errorf("code_challenge = %q", got)
+	}
+	if got := q.Get("code_challenge_method"); got != "S256" {
+		t.Errorf("code_challenge_method = %q, want S256", got)
+	}
+}
+
+func TestExchangeWithPKCEOmitsSecret(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if got := r.FormValue("code_verifier"); got != "verifier" {
+			t.Errorf("code_verifier = %q, want verifier", got)
+		}
+		if _, ok := r.Form["client_secret"]; ok {
+			t.Error("client_secret should be omitted for a native client")
+		}
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "tok", "expires_in": 3600})
+	}))
+	defer srv.Close()
+
+	a := NewAuthenticator(&config.Config{ClientID: "cid", PKCE: true}, nil)
+	a.baseURL = srv.URL
+	a.UsePKCE("verifier")
+
+	tok, err := a.Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if tok.AccessToken != "tok" {
+		t.Errorf("AccessToken = %q, want tok", tok.AccessToken)
+	}
+}
+
+func TestParseRedirect(t *testing.T) {
+	tests := []struct {
+		name, input string
+		want        string
+		wantErr     error
+	}{
+		{"full URL", "http://localhost:8484/callback?code=abc&state=st", "abc", nil},
+		{"query only", "code=abc&state=st\n", "abc", nil},
+		{"bare code", "  abc-123_x \n", "", ErrBareCode},
+		{"wrong state", "http://localhost:8484/callback?code=abc&state=evil", "", ErrStateMismatch},
+		{"missing state", "http://localhost:8484/callback?code=abc", "", ErrStateMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRedirect(tt.input, "st")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("code = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseRedirect("http://x/cb?error=access_denied&error_description=nope", "st"); err == nil {
+		t.Error("expected OAuth error to be reported")
+	}
+	if _, err := ParseRedirect("  ", "st"); err == nil {
+		t.Error("expected error for empty input")
+	}
+}
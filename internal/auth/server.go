@@ -3,9 +3,12 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 const successHTML = `<!DOCTYPE html>
@@ -68,24 +71,73 @@ func (s *CallbackServer) Start(ctx context.Context) (code string, state string,
 
 // handleCallback processes the OAuth redirect request.
 func (s *CallbackServer) handleCallback(w http.ResponseWriter, r *http.Request) {
-	q := r.URL.Query()
+	res := parseCallback(r.URL.Query())
+	if res.Err != nil {
+		s.result <- res
+		http.Error(w, "Authentication failed.", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, successHTML)
 
+	s.result <- res
+}
+
+// parseCallback extracts the code and state from the redirect query.
+func parseCallback(q url.Values) callbackResult {
 	if errMsg := q.Get("error"); errMsg != "" {
 		desc := q.Get("error_description")
-		s.result <- callbackResult{Err: fmt.Errorf("oauth error: %s — %s", errMsg, desc)}
-		http.Error(w, "Authentication failed.", http.StatusBadRequest)
-		return
+		return callbackResult{Err: fmt.Errorf("oauth error: %s — %s", errMsg, desc)}
 	}
 
 	code := q.Get("code")
 	if code == "" {
-		s.result <- callbackResult{Err: fmt.Errorf("missing code parameter")}
-		http.Error(w, "Missing code.", http.StatusBadRequest)
-		return
+		return callbackResult{Err: fmt.Errorf("missing code parameter")}
+	}
+	return callbackResult{Code: code, State: q.Get("state")}
+}
+
+// ErrStateMismatch is returned when the state in a redirect does not match
+// the one sent in the authorization URL.
+var ErrStateMismatch = errors.New("state mismatch — possible CSRF attack")
+
+// ErrBareCode is returned when a bare code is pasted instead of the redirect
+// URL, whose state can then not be verified.
+var ErrBareCode = errors.New("paste the full redirect URL: the state cannot be checked against a bare code")
+
+// ParseRedirect extracts the authorization code from input pasted by the
+// user during a manual login. input is the full redirect URL or its query
+// string, whose state must equal state. A bare code yields ErrBareCode, as
+// its state cannot be checked; callers may use it only after asking the user.
+func ParseRedirect(input, state string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("no redirect URL given")
 	}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	fmt.Fprint(w, successHTML)
+	if !strings.ContainsAny(input, "?=&") {
+		return "", ErrBareCode
+	}
+
+	query := input
+	if i := strings.IndexByte(input, '?'); i >= 0 {
+		query = input[i+1:]
+	}
+	if i := strings.IndexByte(query, '#'); i >= 0 {
+		query = query[:i]
+	}
+	q, err := url.ParseQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("parse redirect URL: %w", err)
+	}
 
-	s.result <- callbackResult{Code: code, State: q.Get("state")}
+	res := parseCallback(q)
+	if res.Err != nil {
+		return "", res.Err
+	}
+	if res.State != state {
+		return "", ErrStateMismatch
+	}
+	return res.Code, nil
 }
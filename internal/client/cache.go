@@ -0,0 +1,381 @@
+// cache.go keeps GET responses on disk so repeated reads, such as the
+// profile lookup behind every post create, are answered without a round trip.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+const (
+	// maxCachedBody is the largest response body the cache stores.
+	maxCachedBody = 1 << 20
+
+	// CacheStatusHeader is set on responses served from the cache: "hit"
+	// when the entry was fresh, "revalidated" after a 304 Not Modified.
+	CacheStatusHeader = "X-Lcli-Cache"
+)
+
+// cacheTTLs is how long a response from each resource is used without
+// asking the API again. Resources without a TTL are stored only when the
+// response carries an ETag or Last-Modified, and revalidated on every use.
+var cacheTTLs = map[string]time.Duration{
+	"userinfo":                               24 * time.Hour,
+	"people":                                 24 * time.Hour,
+	"organizations":                          24 * time.Hour,
+	"posts":                                  5 * time.Minute,
+	"socialActions":                          time.Minute,
+	"reactions":                              time.Minute,
+	"organizationalEntityShareStatistics":    15 * time.Minute,
+	"organizationalEntityFollowerStatistics": 15 * time.Minute,
+	"organizationPageStatistics":             15 * time.Minute,
+	"networkSizes":                           15 * time.Minute,
+}
+
+// Cache stores GET responses in a directory, one file per request. Entries
+// are keyed by URL, API version and credentials, so tokens never see each
+// other's responses. A successful write to a resource, such as a POST to
+// /posts, drops the cached responses of that resource.
+type Cache struct {
+	dir     string
+	now     func() time.Time
+	noReads bool
+}
+
+// NewCache returns a cache stored in dir, which is created on first use.
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir, now: time.Now}
+}
+
+// WithoutReads returns a copy of c that sends GET requests to the API
+// without reading or storing entries, for --no-cache. Writes still drop
+// the cached responses of their resource, so later reads never see them.
+func (c *Cache) WithoutReads() *Cache {
+	cp := *c
+	cp.noReads = true
+	return &cp
+}
+
+// WithCache answers GET requests from c where possible and stores their
+// responses in it. Fresh cache hits do not count against the rate limiter.
+func WithCache(c *Cache) Option {
+	return func(cl *Client) { cl.cache = c }
+}
+
+// cacheEntry is the file stored for one response.
+type cacheEntry struct {
+	URL      string      `json:"url"`
+	Resource string      `json:"resource"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	Stored   time.Time   `json:"stored"`
+	Expires  time.Time   `json:"expires"`
+}
+
+// Transport returns a RoundTripper that serves GET requests from the cache
+// and sends everything else, including revalidations, through next.
+func (c *Cache) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return c.roundTrip(req, next)
+	})
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func (c *Cache) roundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := next.RoundTrip(req)
+		if err == nil && resp.StatusCode < 300 {
+			c.invalidate(resource(req.URL))
+		}
+		return resp, err
+	}
+	if c.noReads || !cacheable(req) {
+		return next.RoundTrip(req)
+	}
+
+	key := c.key(req)
+	entry := c.load(key)
+	if entry != nil && c.now().Before(entry.Expires) {
+		return entry.response(req, "hit"), nil
+	}
+
+	out := req
+	if entry != nil {
+		out = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			out.Header.Set("If-None-Match", etag)
+		}
+		if modified := entry.Header.Get("Last-Modified"); modified != "" {
+			out.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		drainBody(resp)
+		entry.Stored = c.now()
+		entry.Expires = entry.Stored.Add(cacheTTLs[entry.Resource])
+		c.save(key, entry)
+		return entry.response(req, "revalidated"), nil
+	}
+	name := resource(req.URL)
+	if resp.StatusCode != http.StatusOK || !storable(resp, name) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if len(body) > maxCachedBody {
+		return resp, nil
+	}
+
+	header := resp.Header.Clone()
+	// Rate limit headers describe the quota when the response was fetched,
+	// not when it is served again.
+	for k := range header {
+		if strings.HasPrefix(k, "X-Ratelimit-") || k == "Retry-After" {
+			delete(header, k)
+		}
+	}
+	now := c.now()
+	c.save(key, &cacheEntry{
+		URL:      req.URL.String(),
+		Resource: name,
+		Header:   header,
+		Body:     body,
+		Stored:   now,
+		Expires:  now.Add(cacheTTLs[name]),
+	})
+	return resp, nil
+}
+
+// fresh reports whether req would be answered from the cache without
+// contacting the API.
+func (c *Cache) fresh(req *http.Request) bool {
+	if c.noReads || req.Method != http.MethodGet || !cacheable(req) {
+		return false
+	}
+	entry := c.load(c.key(req))
+	return entry != nil && c.now().Before(entry.Expires)
+}
+
+// cacheable reports whether the cache may answer req. Requests that are
+// already conditional, or ask not to be served from a cache, pass through.
+func cacheable(req *http.Request) bool {
+	return req.Header.Get("If-None-Match") == "" &&
+		req.Header.Get("If-Modified-Since") == "" &&
+		!strings.Contains(req.Header.Get("Cache-Control"), "no-cache")
+}
+
+// storable reports whether resp may be stored: it must not forbid it, and
+// it must either have a TTL or be revalidatable.
+func storable(resp *http.Response, name string) bool {
+	if strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return false
+	}
+	if cacheTTLs[name] > 0 {
+		return true
+	}
+	return resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+// response rebuilds the stored response for req.
+func (e *cacheEntry) response(req *http.Request, status string) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set(CacheStatusHeader, status)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// resource names the API resource a URL belongs to: the first path segment
+// after /rest/ or /v2/, such as "posts" or "userinfo". Only letters and
+// digits are kept, since it becomes part of a file name.
+func resource(u *url.URL) string {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	name := segments[0]
+	for i, s := range segments[:len(segments)-1] {
+		if s == "rest" || s == "v2" {
+			name = segments[i+1]
+			break
+		}
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+// key returns the file name for req: its resource, so writes can drop the
+// resource's entries, and a hash of everything that selects the response.
+func (c *Cache) key(req *http.Request) string {
+	h := sha256.New()
+	for _, s := range []string{req.URL.String(), req.Header.Get("LinkedIn-Version"), req.Header.Get("Authorization")} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return resource(req.URL) + "-" + hex.EncodeToString(h.Sum(nil)[:16]) + ".json"
+}
+
+// load reads an entry, returning nil if it is missing or unreadable.
+func (c *Cache) load(key string) *cacheEntry {
+	data, err := os.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return nil
+	}
+	return &entry
+}
+
+// save writes an entry atomically. Failures are ignored: the cache only
+// saves round trips and must never fail a call.
+func (c *Cache) save(key string, entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return
+	}
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(c.dir, key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// invalidate drops the cached responses of a resource.
+func (c *Cache) invalidate(name string) {
+	files, _ := filepath.Glob(filepath.Join(c.dir, name+"-*.json"))
+	for _, f := range files {
+		os.Remove(f)
+	}
+}
+
+// Clear removes every cached response and returns how many there were.
+func (c *Cache) Clear(ctx context.Context) (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+	for i, f := range files {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		if err := os.Remove(f); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return i, fmt.Errorf("clear cache: %w", err)
+		}
+	}
+	return len(files), nil
+}
+
+// Stats summarizes the cached responses per resource.
+func (c *Cache) Stats(ctx context.Context) (*model.CacheStats, error) {
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+	stats := &model.CacheStats{Dir: c.dir}
+	byResource := make(map[string]*model.CacheResourceStats)
+	now := c.now()
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fi, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		name, _, _ := strings.Cut(filepath.Base(f), "-")
+		rs, ok := byResource[name]
+		if !ok {
+			rs = &model.CacheResourceStats{Resource: name, TTL: cacheTTLs[name].String()}
+			byResource[name] = rs
+		}
+		rs.Entries++
+		rs.Bytes += fi.Size()
+		if entry := c.load(filepath.Base(f)); entry != nil && now.Before(entry.Expires) {
+			rs.Fresh++
+		}
+	}
+
+	names := make([]string, 0, len(byResource))
+	for name := range byResource {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		rs := byResource[name]
+		stats.Entries += rs.Entries
+		stats.Fresh += rs.Fresh
+		stats.Bytes += rs.Bytes
+		stats.Resources = append(stats.Resources, *rs)
+	}
+	return stats, nil
+}
+
+// files lists the entry files in the cache directory.
+func (c *Cache) files() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*-*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("read cache: %w", err)
+	}
+	return files, nil
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// cacheServer serves a body with an ETag and counts requests by method,
+// answering If-None-Match with 304.
+type cacheServer struct {
+	*httptest.Server
+	gets, notModified, writes int
+}
+
+func newCacheServer(t *testing.T) *cacheServer {
+	s := &cacheServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			s.writes++
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		s.gets++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			s.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// fetch GETs path through c and returns the body and cache status.
+func fetch(t *testing.T, c *Client, path string) (string, string) {
+	t.Helper()
+	resp, err := c.Get(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body), resp.Header.Get(CacheStatusHeader)
+}
+
+func TestCacheServesFreshEntries(t *testing.T) {
+	srv := newCacheServer(t)
+	cache := NewCache(t.TempDir())
+	c := New("tok", "202601", WithBaseURL(srv.URL+"/rest"), WithCache(cache))
+
+	body, status := fetch(t, c, "/organizations/1")
+	if body != `{"path":"/rest/organizations/1"}` || status != "" {
+		t.Fatalf("first GET = %q, %q", body, status)
+	}
+	body, status = fetch(t, c, "/organizations/1")
+	if body != `{"path":"/rest/organizations/1"}` || status != "hit" {
+		t.Fatalf("second GET = %q, %q", body, status)
+	}
+	if srv.gets != 1 {
+		t.Errorf("server saw %d GETs, want 1", srv.gets)
+	}
+
+	// Another token does not share entries.
+	other := New("other", "202601", WithBaseURL(srv.URL+"/rest"), WithCache(cache))
+	if _, status := fetch(t, other, "/organizations/1"); status != "" {
+		t.Errorf("other token cache status = %q", status)
+	}
+}
+
+func TestCacheRevalidatesStaleEntries(t *testing.T) {
+	srv := newCacheServer(t)
+	cache := NewCache(t.TempDir())
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	c := New("tok", "202601", WithBaseURL(srv.URL+"/rest"), WithCache(cache))
+
+	fetch(t, c, "/posts/urn%3Ali%3Ashare%3A1")
+	now = now.Add(cacheTTLs["posts"] + time.Second)
+	body, status := fetch(t, c, "/posts/urn%3Ali%3Ashare%3A1")
+	if status != "revalidated" || !strings.Contains(body, "/rest/posts/") {
+		t.Fatalf("stale GET = %q, %q", body, status)
+	}
+	if srv.gets != 2 || srv.notModified != 1 {
+		t.Errorf("gets = %d, notModified = %d", srv.gets, srv.notModified)
+	}
+
+	// The revalidation made the entry fresh again.
+	if _, status := fetch(t, c, "/posts/urn%3Ali%3Ashare%3A1"); status != "hit" {
+		t.Errorf("after revalidation status = %q", status)
+	}
+}
+
+func TestCacheWritesInvalidateResource(t *testing.T) {
+	srv := newCacheServer(t)
+	cache := NewCache(t.TempDir())
+	c := New("tok", "202601", WithBaseURL(srv.URL+"/rest"), WithCache(cache))
+
+	fetch(t, c, "/posts?q=author")
+	fetch(t, c, "/organizations/1")
+	resp, err := c.Post(context.Background(), "/posts", map[string]string{"commentary": "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if _, status := fetch(t, c, "/posts?q=author"); status != "" {
+		t.Errorf("posts after write: status = %q, want a miss", status)
+	}
+	if _, status := fetch(t, c, "/organizations/1"); status != "hit" {
+		t.Errorf("organizations after write: status = %q, want hit", status)
+	}
+}
+
+func TestCacheWithoutReads(t *testing.T) {
+	srv := newCacheServer(t)
+	cache := NewCache(t.TempDir())
+	c := New("tok", "202601", WithBaseURL(srv.URL+"/rest"), WithCache(cache))
+	noCache := New("tok", "202601", WithBaseURL(srv.URL+"/rest"), WithCache(cache.WithoutReads()))
+
+	fetch(t, c, "/posts?q=author")
+	if _, status := fetch(t, noCache, "/posts?q=author"); status != "" {
+		t.Errorf("GET without reads: status = %q, want a miss", status)
+	}
+	if srv.gets != 2 {
+		t.Errorf("server saw %d GETs, want 2", srv.gets)
+	}
+
+	// A write without reads still drops the cached responses.
+	resp, err := noCache.Post(context.Background(), "/posts", map[string]string{"commentary": "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if _, status := fetch(t, c, "/posts?q=author"); status != "" {
+		t.Errorf("posts after write without reads: status = %q, want a miss", status)
+	}
+}
+
+func TestCacheStatsAndClear(t *testing.T) {
+	srv := newCacheServer(t)
+	cache := NewCache(t.TempDir())
+	c := New("tok", "202601", WithBaseURL(srv.URL+"/rest"), WithCache(cache))
+	ctx := context.Background()
+
+	fetch(t, c, "/organizations/1")
+	fetch(t, c, "/organizations/2")
+	fetch(t, c, "/posts?q=author")
+
+	stats, err := cache.Stats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 3 || stats.Fresh != 3 || len(stats.Resources) != 2 || stats.Resources[0].Resource != "organizations" {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	n, err := cache.Clear(ctx)
+	if err != nil || n != 3 {
+		t.Fatalf("Clear = %d, %v", n, err)
+	}
+	if stats, _ := cache.Stats(ctx); stats.Entries != 0 {
+		t.Errorf("entries after clear = %d", stats.Entries)
+	}
+}
+
+func TestCacheHitsSkipRateLimiter(t *testing.T) {
+	srv := newCacheServer(t)
+	clock := &fakeClock{now: time.Now()}
+	limiter := clock.install(NewRateLimiter(map[string]Limit{"other": {Requests: 1, Per: time.Hour}}, "", nil))
+	c := New("tok", "202601", WithBaseURL(srv.URL+"/rest"), WithCache(NewCache(t.TempDir())), WithRateLimiter(limiter))
+
+	fetch(t, c, "/organizations/1")
+	if _, status := fetch(t, c, "/organizations/1"); status != "hit" {
+		t.Fatalf("status = %q", status)
+	}
+	if len(clock.sleeps) != 0 {
+		t.Errorf("cache hit waited for the limiter: %v", clock.sleeps)
+	}
+}
+
+func TestCacheResource(t *testing.T) {
+	tests := map[string]string{
+		"https://api.linkedin.com/rest/posts/urn%3Ali%3Ashare%3A1": "posts",
+		"https://api.linkedin.com/v2/userinfo":                     "userinfo",
+		"http://127.0.0.1:8080/rest/people/(id:abc)":               "people",
+		"https://example.test/organizations":                       "organizations",
+	}
+	for raw, want := range tests {
+		req := httptest.NewRequest(http.MethodGet, raw, nil)
+		if got := resource(req.URL); got != want {
+			t.Errorf("resource(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+// cassette.go implements record and replay transports. A recorder saves each
+// HTTP exchange as a redacted JSON fixture; a replayer serves the fixtures
+// back without touching the network.
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Fixture is one recorded HTTP exchange, stored as a JSON file.
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+// FixtureRequest identifies a recorded request. Replay matches on the
+// method, path, query and body; the host and headers are informational.
+type FixtureRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Path    string            `json:"path"`
+	Query   string            `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// FixtureResponse is a recorded response. Textual bodies are kept as text,
+// anything else as base64.
+type FixtureResponse struct {
+	Status     int               `json:"status"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	BodyBase64 string            `json:"body_base64,omitempty"`
+}
+
+// key returns the string replay matches requests on.
+func (r FixtureRequest) key() string {
+	return r.Method + " " + r.Path + "?" + r.Query + "\n" + r.Body
+}
+
+// fixtureHeaders are the request headers kept in a fixture.
+var fixtureHeaders = []string{"Content-Type", "Linkedin-Version", "X-Restli-Method", "X-Restli-Protocol-Version"}
+
+// newFixtureRequest describes req for matching and recording. It reads the
+// body through GetBody, so req can still be sent. Streamed bodies, such as
+// media uploads, cannot be re-read and are matched without their body.
+func newFixtureRequest(req *http.Request) (FixtureRequest, error) {
+	fr := FixtureRequest{
+		Method: req.Method,
+		URL:    redactURL(req.URL),
+		Path:   req.URL.EscapedPath(),
+		Query:  redactQuery(req.URL.RawQuery),
+	}
+
+	headers := redactHeaders(req.Header)
+	for k, v := range headers {
+		if slices.Contains(fixtureHeaders, http.CanonicalHeaderKey(k)) {
+			if fr.Headers == nil {
+				fr.Headers = make(map[string]string)
+			}
+			fr.Headers[k] = v
+		}
+	}
+
+	if req.GetBody == nil || req.Body == nil || req.Body == http.NoBody {
+		return fr, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return fr, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fr, err
+	}
+	contentType := req.Header.Get("Content-Type")
+	if isTextual(contentType) && len(data) <= maxTraceBody {
+		fr.Body = redactBody(contentType, data)
+	} else {
+		fr.Body = fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+	}
+	return fr, nil
+}
+
+// Recorder is an http.RoundTripper that sends requests with another
+// transport and saves each exchange as a fixture file in a directory.
+type Recorder struct {
+	next http.RoundTripper
+	dir  string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecorder returns a Recorder that writes fixtures to dir, creating it if
+// needed. Numbering continues after any fixtures already there, so several
+// runs can record one session. A nil next means http.DefaultTransport.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cassette directory: %w", err)
+	}
+	names, err := fixtureFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{next: next, dir: dir, seq: len(names)}, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	fr, err := newFixtureRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	fixture := Fixture{
+		Request: fr,
+		Response: FixtureResponse{
+			Status:  resp.StatusCode,
+			Headers: redactHeaders(resp.Header),
+		},
+	}
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case len(data) == 0:
+	case isTextual(contentType):
+		fixture.Response.Body = redactBody(contentType, data)
+	default:
+		fixture.Response.BodyBase64 = base64.StdEncoding.EncodeToString(data)
+	}
+
+	if err := r.save(&fixture); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	return resp, nil
+}
+
+// slugChars matches runs of characters not used in fixture file names.
+var slugChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// save writes fixture to the next numbered file.
+func (r *Recorder) save(fixture *Fixture) error {
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	path, err := url.PathUnescape(fixture.Request.Path)
+	if err != nil {
+		path = fixture.Request.Path
+	}
+	slug := strings.Trim(slugChars.ReplaceAllString(path, "-"), "-")
+	if len(slug) > 60 {
+		slug = slug[:60]
+	}
+	name := fmt.Sprintf("%04d-%s-%s.json", r.seq, fixture.Request.Method, slug)
+	return os.WriteFile(filepath.Join(r.dir, name), append(data, '\n'), 0o600)
+}
+
+// Replayer is an http.RoundTripper that answers requests from fixtures
+// saved by a Recorder, without any network access. Fixtures that match the
+// same request are served in recording order; the last one is then repeated.
+type Replayer struct {
+	mu       sync.Mutex
+	fixtures map[string][]*Fixture
+	served   map[string]int
+}
+
+// NewReplayer loads the fixtures in dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	names, err := fixtureFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no fixtures in %s", dir)
+	}
+
+	r := &Replayer{fixtures: make(map[string][]*Fixture), served: make(map[string]int)}
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		var f Fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parse fixture %s: %w", name, err)
+		}
+		key := f.Request.key()
+		r.fixtures[key] = append(r.fixtures[key], &f)
+	}
+	return r, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	fr, err := newFixtureRequest(req)
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+
+	key := fr.key()
+	r.mu.Lock()
+	candidates := r.fixtures[key]
+	n := r.served[key]
+	r.served[key]++
+	r.mu.Unlock()
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("replay: no recorded response for %s %s", req.Method, fr.URL)
+	}
+	f := candidates[min(n, len(candidates)-1)]
+
+	body := []byte(f.Response.Body)
+	if f.Response.BodyBase64 != "" {
+		body, err = base64.StdEncoding.DecodeString(f.Response.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("replay: decode body: %w", err)
+		}
+	}
+
+	header := make(http.Header, len(f.Response.Headers))
+	for k, v := range f.Response.Headers {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Response.Status, http.StatusText(f.Response.Status)),
+		StatusCode:    f.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// fixtureFiles returns the names of the fixture files in dir, in order.
+func fixtureFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette directory: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
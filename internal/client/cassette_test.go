@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	gets := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Li-Uuid", "uuid-1")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"urn:li:share:1","access_token":"leaked?"}`)
+		default:
+			gets++
+			if gets == 1 {
+				_, _ = io.WriteString(w, `{"elements":[]}`)
+			} else {
+				_, _ = io.WriteString(w, `{"elements":[{"id":"urn:li:share:1"}]}`)
+			}
+		}
+	}))
+
+	dir := t.TempDir()
+	rec, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	c := New("real-token", "202601", WithTransport(rec))
+	c.baseURL = srv.URL + "/rest"
+
+	ctx := context.Background()
+	calls := []struct{ method, path string }{
+		{http.MethodGet, "/posts?q=author&author=urn%3Ali%3Aperson%3A1"},
+		{http.MethodPost, "/posts"},
+		{http.MethodGet, "/posts?q=author&author=urn%3Ali%3Aperson%3A1"},
+	}
+	var recorded []string
+	for _, call := range calls {
+		var body any
+		if call.method == http.MethodPost {
+			body = map[string]string{"commentary": "hello"}
+		}
+		resp, err := c.Do(ctx, call.method, call.path, body)
+		if err != nil {
+			t.Fatalf("record %s %s: %v", call.method, call.path, err)
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		recorded = append(recorded, string(data))
+	}
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Fatalf("recorded %d fixtures, want 3", len(files))
+	}
+	for _, f := range files {
+		data, _ := os.ReadFile(f)
+		if strings.Contains(string(data), "real-token") || strings.Contains(string(data), "leaked?") {
+			t.Errorf("fixture %s contains a secret:\n%s", f, data)
+		}
+	}
+
+	rep, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer: %v", err)
+	}
+	c = New("other-token", "202601", WithTransport(rep))
+	c.baseURL = "https://api.example.invalid/rest"
+
+	for i, call := range calls {
+		var body any
+		if call.method == http.MethodPost {
+			body = map[string]string{"commentary": "hello"}
+		}
+		resp, err := c.Do(ctx, call.method, call.path, body)
+		if err != nil {
+			t.Fatalf("replay %s %s: %v", call.method, call.path, err)
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if i != 1 && string(data) != recorded[i] {
+			t.Errorf("replay %d body = %s, want %s", i, data, recorded[i])
+		}
+		if resp.Header.Get("X-Li-Uuid") != "uuid-1" {
+			t.Errorf("replay %d lost headers: %v", i, resp.Header)
+		}
+	}
+
+	_, err = c.Do(ctx, http.MethodPost, "/posts", map[string]string{"commentary": "different"})
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("unmatched body: err = %v", err)
+	}
+}
+
+func TestRecorderContinuesNumbering(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for range 2 {
+		rec, err := NewRecorder(dir, nil)
+		if err != nil {
+			t.Fatalf("NewRecorder: %v", err)
+		}
+		c := New("tok", "202601", WithTransport(rec))
+		c.baseURL = srv.URL
+		resp, err := c.Do(context.Background(), http.MethodGet, "/me", nil)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	for _, name := range []string{"0001-GET-me.json", "0002-GET-me.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("missing %s: %v", name, err)
+		}
+	}
+}
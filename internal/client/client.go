@@ -8,54 +8,217 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Softorize/lcli/internal/restli"
 )
 
-const baseURL = "https://api.linkedin.com/rest"
+const defaultBaseURL = "https://api.linkedin.com/rest"
+
+// responseHeaderTimeout bounds how long the API may take to start answering
+// a request. Request bodies, such as large uploads, are not limited; the
+// caller's context bounds the whole call.
+const responseHeaderTimeout = 60 * time.Second
 
 // Client is an authenticated HTTP client for the LinkedIn REST API.
 type Client struct {
-	http        *http.Client
+	http       *http.Client
+	baseURL    string
+	apiVersion string
+	retry      RetryPolicy
+	sleep      func(ctx context.Context, d time.Duration) error
+	refresh    TokenRefresher
+	tracer     *Tracer
+	limiter    *RateLimiter
+	cache      *Cache
+
+	mu          sync.Mutex
 	accessToken string
-	apiVersion  string
+}
+
+// TokenRefresher obtains a new access token after the current one has been
+// rejected. It returns the new token or an error if renewal is not possible.
+type TokenRefresher func(ctx context.Context) (string, error)
+
+// Option configures optional Client behaviour.
+type Option func(*Client)
+
+// WithRetryPolicy sets the policy used to retry transient failures.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// WithTokenRefresher makes the client renew its access token and replay the
+// request once when the API responds with 401 Unauthorized.
+func WithTokenRefresher(fn TokenRefresher) Option {
+	return func(c *Client) { c.refresh = fn }
+}
+
+// WithTransport sends requests through rt instead of the default transport,
+// for example one from NewTransport, a Recorder or a Replayer.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) { c.http.Transport = rt }
+}
+
+// WithBaseURL sends requests to baseURL instead of LinkedIn's REST API, for
+// example a staging mirror or a linkedintest fake server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimRight(baseURL, "/") }
 }
 
 // New creates a Client with the given access token and API version.
-func New(accessToken, apiVersion string) *Client {
-	return &Client{
-		http:        &http.Client{},
+// Transient failures are retried according to DefaultRetryPolicy unless
+// overridden with WithRetryPolicy.
+func New(accessToken, apiVersion string, opts ...Option) *Client {
+	c := &Client{
+		http:        newHTTPClient(),
+		baseURL:     defaultBaseURL,
 		accessToken: accessToken,
 		apiVersion:  apiVersion,
+		retry:       DefaultRetryPolicy(),
+		sleep:       sleepContext,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	if c.tracer != nil {
+		c.http.Transport = c.tracer.Transport(c.http.Transport)
+	}
+	if c.cache != nil {
+		// Outside the tracer, so only requests that reach the network,
+		// including revalidations, are traced.
+		c.http.Transport = c.cache.Transport(c.http.Transport)
+	}
+	return c
+}
+
+// newHTTPClient returns an http.Client whose transport gives up on a server
+// that accepts a request but never responds.
+func newHTTPClient() *http.Client {
+	t, _ := NewTransport(TransportConfig{})
+	return &http.Client{Transport: t}
+}
+
+// restliMethoder is implemented by request bodies whose Rest.li method is
+// not implied by the HTTP method, such as restli.Patch.
+type restliMethoder interface {
+	RestliMethod() string
 }
 
 // Do executes an authenticated request against the LinkedIn API.
-// If body is non-nil it is JSON-marshalled and sent as the request body.
+// path is relative to the base URL unless it is an absolute URL, as for the
+// OpenID Connect userinfo endpoint, which lives outside the REST API.
+// If body is non-nil it is JSON-marshalled and sent as the request body,
+// and if it has a RestliMethod the method is sent in X-RestLi-Method.
+// Transient failures are retried with the body replayed on each attempt, and
+// a 401 triggers a single token refresh when a TokenRefresher is configured.
 func (c *Client) Do(ctx context.Context, method, path string, body any) (*http.Response, error) {
-	var bodyReader io.Reader
+	var data []byte
 	if body != nil {
-		data, err := json.Marshal(body)
+		var err error
+		data, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshal request body: %w", err)
 		}
+	}
+	var restliMethod string
+	if m, ok := body.(restliMethoder); ok {
+		restliMethod = m.RestliMethod()
+	}
+
+	resp, err := c.do(ctx, method, restliMethod, path, data)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.refresh == nil {
+		return resp, err
+	}
+
+	token, refreshErr := c.refresh(ctx)
+	if refreshErr != nil {
+		// Surface the original 401 so callers report it as usual.
+		return resp, nil
+	}
+	drainBody(resp)
+	c.setAccessToken(token)
+
+	return c.do(ctx, method, restliMethod, path, data)
+}
+
+// do sends the request, retrying transient failures per the retry policy.
+func (c *Client) do(ctx context.Context, method, restliMethod, path string, data []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, method, restliMethod, path, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.limiter != nil && c.limiter.limited() && (c.cache == nil || !c.cache.fresh(req)) {
+			if err := c.limiter.Wait(ctx, path); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.http.Do(req)
+		if c.limiter != nil && err == nil {
+			// The state file only caches the quota; failing to update it
+			// must not fail the call.
+			_ = c.limiter.Observe(ctx, path, resp)
+		}
+		wait, retry := c.retry.next(method, attempt, resp, err)
+		if !retry {
+			if err != nil {
+				return nil, fmt.Errorf("execute request: %w", err)
+			}
+			return resp, nil
+		}
+
+		drainBody(resp)
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("execute request: %w", err)
+		}
+	}
+}
+
+// newRequest builds a request with the standard LinkedIn headers, and
+// X-RestLi-Method if restliMethod is set. The body is exposed through
+// GetBody so the request can be replayed.
+func (c *Client) newRequest(ctx context.Context, method, restliMethod, path string, data []byte) (*http.Request, error) {
+	var bodyReader io.Reader
+	if data != nil {
 		bodyReader = bytes.NewReader(data)
 	}
 
-	url := baseURL + path
+	url := c.baseURL + path
+	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
+		url = path
+	}
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("build request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Authorization", "Bearer "+c.token())
 	req.Header.Set("LinkedIn-Version", c.apiVersion)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Restli-Protocol-Version", "2.0.0")
-
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+	if restliMethod != "" {
+		req.Header.Set(restli.MethodHeader, restliMethod)
 	}
-	return resp, nil
+	return req, nil
+}
+
+// token returns the current access token.
+func (c *Client) token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accessToken
+}
+
+// setAccessToken replaces the access token used for subsequent requests.
+func (c *Client) setAccessToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = token
 }
 
 // Get performs an authenticated GET request.
@@ -73,16 +236,6 @@ func (c *Client) Delete(ctx context.Context, path string) (*http.Response, error
 	return c.Do(ctx, http.MethodDelete, path, nil)
 }
 
-// APIError is returned when the LinkedIn API responds with a non-2xx status.
-type APIError struct {
-	StatusCode int
-	Body       string
-}
-
-func (e *APIError) Error() string {
-	return fmt.Sprintf("linkedin api %d: %s", e.StatusCode, e.Body)
-}
-
 // DecodeResponse reads the response body and JSON-unmarshals it into v.
 // If the response status is not 2xx, an *APIError is returned.
 func DecodeResponse(resp *http.Response, v any) error {
@@ -94,7 +247,7 @@ func DecodeResponse(resp *http.Response, v any) error {
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return &APIError{StatusCode: resp.StatusCode, Body: string(data)}
+		return NewAPIError(resp, data)
 	}
 
 	if v != nil {
@@ -3,11 +3,14 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 func TestNew(t *testing.T) {
@@ -42,7 +45,7 @@ func TestDoSetsHeaders(t *testing.T) {
 
 	c := New("mytoken", "202501")
 	c.http = srv.Client()
-	origBase := baseURL
+	origBase := defaultBaseURL
 	// We override via the server URL by making the client hit the test server
 	resp, err := c.doRaw(context.Background(), http.MethodGet, srv.URL, nil)
 	_ = origBase
@@ -116,6 +119,35 @@ func TestDoWithBody(t *testing.T) {
 	resp.Body.Close()
 }
 
+func TestDoSendsRestliMethod(t *testing.T) {
+	var methods []string
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Header.Get("X-RestLi-Method"))
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New("tok", "202601", WithBaseURL(srv.URL))
+	patch := restli.Patch{Set: map[string]any{"commentary": "Fixed"}}
+	for _, b := range []any{patch, map[string]string{"commentary": "New"}} {
+		resp, err := c.Do(context.Background(), http.MethodPost, "/posts/x", b)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(methods) != 2 || methods[0] != "PARTIAL_UPDATE" || methods[1] != "" {
+		t.Errorf("X-RestLi-Method = %q", methods)
+	}
+	if !strings.Contains(body, "commentary") {
+		t.Errorf("body = %s", body)
+	}
+}
+
 func TestDecodeResponse200(t *testing.T) {
 	type result struct {
 		ID string `json:"id"`
@@ -164,3 +196,90 @@ func TestDecodeResponseNilTarget(t *testing.T) {
 		t.Fatalf("DecodeResponse with nil target: %v", err)
 	}
 }
+
+func TestDoRefreshesTokenOnUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	refreshes := 0
+	c := New("stale", "202501", WithTokenRefresher(func(context.Context) (string, error) {
+		refreshes++
+		return "fresh", nil
+	}))
+	c.http = srv.Client()
+	c.baseURL = srv.URL
+
+	resp, err := c.Get(context.Background(), "/posts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	if refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", refreshes)
+	}
+	if c.token() != "fresh" {
+		t.Errorf("token = %q, want fresh", c.token())
+	}
+}
+
+func TestDoReturnsUnauthorizedWhenRefreshFails(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := New("stale", "202501", WithTokenRefresher(func(context.Context) (string, error) {
+		return "", errors.New("no refresh token")
+	}))
+	c.http = srv.Client()
+	c.baseURL = srv.URL
+
+	resp, err := c.Get(context.Background(), "/posts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestDoHonoursCancel(t *testing.T) {
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := New("tok", "202501")
+	c.http = srv.Client()
+	c.baseURL = srv.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := c.Do(ctx, http.MethodGet, "/me", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
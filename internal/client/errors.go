@@ -0,0 +1,82 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// APIError is returned when the LinkedIn API responds with a non-2xx status.
+// It is the same type as model.APIError, so errors.Is matches the model
+// sentinels whichever package produced it.
+type APIError = model.APIError
+
+// requestIDHeaders are the response headers LinkedIn uses to identify a
+// request, in order of preference.
+var requestIDHeaders = []string{"X-Li-Uuid", "X-Li-Request-Id", "X-Restli-Request-Id"}
+
+// NewAPIError builds the error for a failed response whose body has already
+// been read. The Rest.li error envelope is parsed when body holds one;
+// otherwise the body becomes the message. OAuth-style error bodies, as
+// returned by the userinfo endpoint, are understood too.
+func NewAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{Body: string(body)}
+
+	var oauth struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if json.Unmarshal(body, e) != nil {
+		e.Message = strings.TrimSpace(string(body))
+	} else if e.Message == "" && json.Unmarshal(body, &oauth) == nil {
+		e.Message = oauth.ErrorDescription
+		if e.Code == "" {
+			e.Code = oauth.Error
+		}
+	}
+	// The status line is authoritative; the envelope's copy may be absent.
+	e.StatusCode = resp.StatusCode
+	e.Body = string(body)
+
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			e.RequestID = id
+			break
+		}
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			e.Path = resp.Request.URL.Path
+		}
+	}
+
+	e.RateLimit = ParseRateLimit(resp)
+	if wait := RetryAfter(resp); wait > 0 || resp.StatusCode == http.StatusTooManyRequests {
+		if e.RateLimit == nil {
+			e.RateLimit = &RateLimit{}
+		}
+		e.RateLimit.RetryAfter = wait
+	}
+	return e
+}
+
+// CheckResponse returns nil for a 2xx response. Otherwise it reads and
+// closes the body and returns the *APIError describing the failure.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		e := NewAPIError(resp, nil)
+		e.Message = "failed to read error response"
+		return e
+	}
+	return NewAPIError(resp, body)
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+func TestNewAPIErrorParsesEnvelopeAndHeaders(t *testing.T) {
+	resp := makeResp(429, map[string]string{
+		"X-Li-Uuid":             "req-42",
+		"X-RateLimit-Limit":     "100",
+		"X-RateLimit-Remaining": "0",
+		"Retry-After":           "30",
+	})
+	resp.Request = &http.Request{Method: http.MethodPost, URL: &url.URL{Path: "/rest/posts"}}
+	body := `{"status":429,"serviceErrorCode":101,"code":"TOO_MANY_REQUESTS","message":"Resource level throttle limit reached"}`
+
+	e := NewAPIError(resp, []byte(body))
+	if e.StatusCode != 429 || e.ServiceErrorCode != 101 || e.Code != "TOO_MANY_REQUESTS" {
+		t.Errorf("envelope = %+v", e)
+	}
+	if e.RequestID != "req-42" || e.Method != http.MethodPost || e.Path != "/rest/posts" {
+		t.Errorf("request = %q %q %q", e.RequestID, e.Method, e.Path)
+	}
+	if e.RateLimit == nil || e.RateLimit.Limit != 100 || e.RateLimit.RetryAfter != 30*time.Second {
+		t.Errorf("RateLimit = %+v", e.RateLimit)
+	}
+	if !errors.Is(e, model.ErrRateLimited) {
+		t.Error("errors.Is(e, model.ErrRateLimited) = false")
+	}
+	if e.Body != body {
+		t.Errorf("Body = %q", e.Body)
+	}
+}
+
+func TestNewAPIErrorOAuthBody(t *testing.T) {
+	e := NewAPIError(makeResp(401, nil), []byte(`{"error":"invalid_token","error_description":"The token expired"}`))
+	if e.Code != "invalid_token" || e.Message != "The token expired" {
+		t.Errorf("APIError = %+v", e)
+	}
+	if e.RateLimit != nil {
+		t.Errorf("RateLimit = %+v, want nil", e.RateLimit)
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	ok := &http.Response{StatusCode: 201, Body: io.NopCloser(strings.NewReader(""))}
+	if err := CheckResponse(ok); err != nil {
+		t.Errorf("CheckResponse(201) = %v", err)
+	}
+
+	bad := &http.Response{StatusCode: 404, Body: io.NopCloser(strings.NewReader(`{"status":404,"message":"Not Found"}`))}
+	err := CheckResponse(bad)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "Not Found" || !errors.Is(err, model.ErrNotFound) {
+		t.Errorf("CheckResponse(404) = %v", err)
+	}
+}
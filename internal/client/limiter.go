@@ -0,0 +1,398 @@
+// limiter.go throttles API calls with per-family token buckets whose state,
+// together with the quota LinkedIn reports, is shared between processes.
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+const (
+	// quotaWaitLimit is the longest the limiter waits for an exhausted
+	// quota to reset. Longer resets, such as LinkedIn's daily throttles,
+	// fail the call with model.ErrRateLimited instead.
+	quotaWaitLimit = time.Minute
+
+	// lockTimeout bounds how long the limiter waits for another process
+	// to release the state file lock; staleLock is the age after which a
+	// lock left behind by a crashed process is broken. The timeout is the
+	// longer of the two, so a stale lock is broken before the wait gives up.
+	lockTimeout = 10 * time.Second
+	staleLock   = 5 * time.Second
+	lockPoll    = 10 * time.Millisecond
+)
+
+// Limit allows Requests calls per Per. Up to Requests calls may be made at
+// once; the budget then refills evenly over Per.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// String formats the limit as, for example, "100/24h0m0s".
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Per)
+}
+
+// rate returns the refill rate in tokens per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// Family returns the endpoint family of an API path, one of
+// model.RateLimitFamilies. Limits and quotas are kept per family.
+func Family(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	first, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	switch first {
+	case "posts", "ugcPosts", "shares":
+		return "posts"
+	case "socialActions":
+		return "socialActions"
+	case "reactions":
+		return "reactions"
+	case "organizationalEntityShareStatistics", "organizationalEntityFollowerStatistics",
+		"organizationPageStatistics", "networkSizes":
+		return "statistics"
+	default:
+		return "other"
+	}
+}
+
+// QuotaState is the shared rate limit state of one endpoint family. Tokens
+// and Refilled are the client-side bucket; Limit, Remaining and Reset are
+// the X-RateLimit-* values last observed at Observed.
+type QuotaState struct {
+	Tokens    float64   `json:"tokens"`
+	Refilled  time.Time `json:"refilled,omitzero"`
+	Limit     int       `json:"limit,omitempty"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset,omitzero"`
+	Observed  time.Time `json:"observed,omitzero"`
+}
+
+// RateState maps endpoint families to their shared state.
+type RateState map[string]*QuotaState
+
+// RateLimiter delays API calls that would exceed the configured limits and
+// refuses calls whose quota LinkedIn reports as exhausted. With a state
+// path, every process using the same file shares one budget.
+type RateLimiter struct {
+	limits map[string]Limit
+	path   string
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+	warn   io.Writer
+
+	mu    sync.Mutex
+	state RateState // used when path is empty
+}
+
+// NewRateLimiter returns a limiter enforcing limits, keyed by family. A
+// non-empty statePath names the file shared with other processes; it is
+// created on first use. Failures to lock or update it are reported to warn,
+// which may be nil to discard them.
+func NewRateLimiter(limits map[string]Limit, statePath string, warn io.Writer) *RateLimiter {
+	if warn == nil {
+		warn = io.Discard
+	}
+	return &RateLimiter{
+		limits: limits,
+		path:   statePath,
+		now:    time.Now,
+		sleep:  sleepContext,
+		warn:   warn,
+		state:  make(RateState),
+	}
+}
+
+// limited reports whether any limits are configured.
+func (l *RateLimiter) limited() bool {
+	return len(l.limits) > 0
+}
+
+// WithRateLimiter makes the client record the rate limit headers of every
+// response in l and, if l has limits, wait for it before every request.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *Client) { c.limiter = l }
+}
+
+// Wait blocks until a call to path is within budget, or returns an error
+// wrapping model.ErrRateLimited if the family's quota is exhausted for
+// longer than quotaWaitLimit. If the state file cannot be locked or updated
+// the call is let through unthrottled and a warning is written instead.
+func (l *RateLimiter) Wait(ctx context.Context, path string) error {
+	family := Family(path)
+	for {
+		var wait time.Duration
+		var exhausted error
+		err := l.update(ctx, func(state RateState) error {
+			q := state[family]
+			now := l.now()
+
+			if q != nil && !q.Observed.IsZero() && q.Remaining <= 0 && q.Reset.After(now) {
+				wait = q.Reset.Sub(now)
+				if wait > quotaWaitLimit {
+					exhausted = fmt.Errorf("%s quota exhausted until %s: %w",
+						family, q.Reset.Local().Format(time.DateTime), model.ErrRateLimited)
+				}
+				return nil
+			}
+
+			limit, ok := l.limits[family]
+			if !ok || limit.Requests <= 0 || limit.Per <= 0 {
+				return nil
+			}
+			q = state.get(family)
+			q.refill(limit, now)
+			if q.Tokens >= 1 {
+				q.Tokens--
+				return nil
+			}
+			wait = time.Duration((1 - q.Tokens) / limit.rate() * float64(time.Second))
+			return nil
+		})
+		if exhausted != nil {
+			return exhausted
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Fprintf(l.warn, "warning: %v; not rate limiting this call\n", err)
+			return nil
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if err := l.sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Observe records the X-RateLimit-* headers of resp. Headers without
+// X-RateLimit-Remaining are ignored, since they say nothing of the quota
+// left. A 429 without them marks the family exhausted until the Retry-After
+// hint has passed.
+func (l *RateLimiter) Observe(ctx context.Context, path string, resp *http.Response) error {
+	rl := ParseRateLimit(resp)
+	if rl != nil && !rl.HasRemaining {
+		rl = nil
+	}
+	retry := RetryAfter(resp)
+	if rl == nil && (resp.StatusCode != http.StatusTooManyRequests || retry <= 0) {
+		return nil
+	}
+
+	return l.update(ctx, func(state RateState) error {
+		q := state.get(Family(path))
+		now := l.now()
+		q.Observed = now
+		if rl != nil {
+			q.Limit, q.Remaining, q.Reset = rl.Limit, rl.Remaining, rl.Reset
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			q.Remaining = 0
+			if retry > 0 {
+				q.Reset = now.Add(retry)
+			}
+		}
+		return nil
+	})
+}
+
+// Quotas reports the state of every family that has a limit or an observed
+// quota, with client-side buckets refilled to the current time.
+func (l *RateLimiter) Quotas(ctx context.Context) ([]model.Quota, error) {
+	var quotas []model.Quota
+	err := l.update(ctx, func(state RateState) error {
+		now := l.now()
+		for _, family := range model.RateLimitFamilies {
+			limit, limited := l.limits[family]
+			if _, seen := state[family]; !limited && !seen {
+				continue
+			}
+			q := state.get(family)
+
+			quota := model.Quota{Family: family}
+			if limited {
+				q.refill(limit, now)
+				available := int(q.Tokens)
+				quota.Budget = limit.String()
+				quota.Available = &available
+			}
+			if !q.Observed.IsZero() {
+				remaining := q.Remaining
+				quota.Limit = q.Limit
+				quota.Remaining = &remaining
+				quota.Reset = q.Reset
+				quota.Observed = q.Observed
+			}
+			quotas = append(quotas, quota)
+		}
+		return nil
+	})
+	return quotas, err
+}
+
+// get returns the state of family, creating it if needed.
+func (s RateState) get(family string) *QuotaState {
+	q, ok := s[family]
+	if !ok {
+		q = &QuotaState{}
+		s[family] = q
+	}
+	return q
+}
+
+// refill adds the tokens earned since the last refill. A bucket that has
+// never been used starts full.
+func (q *QuotaState) refill(limit Limit, now time.Time) {
+	if q.Refilled.IsZero() {
+		q.Tokens = float64(limit.Requests)
+	} else if elapsed := now.Sub(q.Refilled); elapsed > 0 {
+		q.Tokens += elapsed.Seconds() * limit.rate()
+	}
+	q.Tokens = min(q.Tokens, float64(limit.Requests))
+	q.Refilled = now
+}
+
+// update applies fn to the state under the limiter's lock and saves it. With
+// a state file the lock is held across processes and the file is re-read, so
+// fn always sees the latest state.
+func (l *RateLimiter) update(ctx context.Context, fn func(RateState) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.path == "" {
+		return fn(l.state)
+	}
+
+	unlock, err := lockFile(ctx, l.path+".lock")
+	if err != nil {
+		return fmt.Errorf("rate limit state: %w", err)
+	}
+	defer unlock()
+
+	state, err := ReadRateState(l.path)
+	if err != nil {
+		return err
+	}
+	if err := fn(state); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("rate limit state: %w", err)
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("rate limit state: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("rate limit state: %w", err)
+	}
+	return nil
+}
+
+// ReadRateState reads a shared state file. A missing or corrupt file is
+// treated as empty, since the state is only a cache of the quota.
+func ReadRateState(path string) (RateState, error) {
+	state := make(RateState)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rate limit state: %w", err)
+	}
+	if json.Unmarshal(data, &state) != nil {
+		return make(RateState), nil
+	}
+	for family, q := range state {
+		if q == nil {
+			delete(state, family)
+		}
+	}
+	return state, nil
+}
+
+// lockFile acquires an exclusive lock by creating path with O_EXCL, which
+// works the same on every platform. The file holds a token unique to this
+// holder, so breaking a lock older than staleLock and releasing one only
+// ever remove the lock they looked at, never one another process has just
+// taken. It gives up after lockTimeout. The returned function releases the
+// lock.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	token := lockToken()
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, err = f.WriteString(token)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return func() { removeLock(path, token) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		// The token is read before the age is checked, so a lock replaced
+		// in between is seen as fresh rather than broken.
+		held, readErr := os.ReadFile(path)
+		if fi, statErr := os.Stat(path); readErr == nil && statErr == nil && time.Since(fi.ModTime()) > staleLock {
+			removeLock(path, string(held))
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is held by another process", path)
+		}
+		if err := sleepContext(ctx, lockPoll); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// lockToken returns a token identifying one lock holder.
+func lockToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%d-%x", os.Getpid(), b)
+}
+
+// removeLock removes the lock at path if it still holds token. The lock is
+// first renamed out of the way, which only one process can do, and put back
+// if it turns out to be another holder's.
+func removeLock(path, token string) {
+	claimed := path + "." + lockToken()
+	if os.Rename(path, claimed) != nil {
+		return
+	}
+	if held, err := os.ReadFile(claimed); err == nil && string(held) != token {
+		// A link, unlike a rename, does not replace a lock created since.
+		os.Link(claimed, path)
+	}
+	os.Remove(claimed)
+}
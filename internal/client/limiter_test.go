@@ -0,0 +1,296 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// fakeClock makes a limiter's sleeps advance its clock instead of blocking,
+// and records them.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) install(l *RateLimiter) *RateLimiter {
+	l.now = func() time.Time { return c.now }
+	l.sleep = func(ctx context.Context, d time.Duration) error {
+		c.sleeps = append(c.sleeps, d)
+		c.now = c.now.Add(d)
+		return ctx.Err()
+	}
+	return l
+}
+
+func TestFamily(t *testing.T) {
+	tests := map[string]string{
+		"/posts":                                                      "posts",
+		"/posts/urn%3Ali%3Ashare%3A1":                                 "posts",
+		"/posts?q=author&author=urn":                                  "posts",
+		"/socialActions/urn%3Ali%3Ashare%3A1/comments":                "socialActions",
+		"/reactions/(actor:a,entity:b)":                               "reactions",
+		"/organizationalEntityShareStatistics?q=organizationalEntity": "statistics",
+		"/networkSizes/urn%3Ali%3Aorganization%3A1":                   "statistics",
+		"/images?action=initializeUpload":                             "other",
+	}
+	for path, want := range tests {
+		if got := Family(path); got != want {
+			t.Errorf("Family(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	l := clock.install(NewRateLimiter(map[string]Limit{"posts": {Requests: 2, Per: time.Minute}}, "", nil))
+	ctx := context.Background()
+
+	for range 2 {
+		if err := l.Wait(ctx, "/posts"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(clock.sleeps) != 0 {
+		t.Fatalf("burst slept %v", clock.sleeps)
+	}
+
+	if err := l.Wait(ctx, "/posts"); err != nil {
+		t.Fatal(err)
+	}
+	if len(clock.sleeps) != 1 || clock.sleeps[0] != 30*time.Second {
+		t.Errorf("sleeps = %v, want [30s]", clock.sleeps)
+	}
+
+	// Other families are not limited.
+	if err := l.Wait(ctx, "/reactions"); err != nil || len(clock.sleeps) != 1 {
+		t.Errorf("reactions: err = %v, sleeps = %v", err, clock.sleeps)
+	}
+}
+
+func TestRateLimiterSharesStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	limits := map[string]Limit{"reactions": {Requests: 1, Per: time.Hour}}
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	a := clock.install(NewRateLimiter(limits, path, nil))
+	b := clock.install(NewRateLimiter(limits, path, nil))
+	ctx := context.Background()
+
+	if err := a.Wait(ctx, "/reactions"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Wait(ctx, "/reactions"); err != nil {
+		t.Fatal(err)
+	}
+	if len(clock.sleeps) != 1 || clock.sleeps[0] != time.Hour {
+		t.Errorf("second process sleeps = %v, want [1h]", clock.sleeps)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestRateLimiterUnreadableStateWarns(t *testing.T) {
+	// A directory in place of the state file cannot be read.
+	path := t.TempDir()
+	var warn bytes.Buffer
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	l := clock.install(NewRateLimiter(map[string]Limit{"posts": {Requests: 1, Per: time.Hour}}, path, &warn))
+
+	for range 2 {
+		if err := l.Wait(context.Background(), "/posts"); err != nil {
+			t.Fatalf("Wait = %v, want the call let through", err)
+		}
+	}
+	if len(clock.sleeps) != 0 {
+		t.Errorf("sleeps = %v, want none", clock.sleeps)
+	}
+	if !strings.Contains(warn.String(), "warning: rate limit state:") {
+		t.Errorf("warnings = %q", warn.String())
+	}
+}
+
+func TestRateLimiterObservedQuota(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	l := clock.install(NewRateLimiter(nil, path, nil))
+	ctx := context.Background()
+
+	resp := makeResp(http.StatusTooManyRequests, map[string]string{"Retry-After": "3600"})
+	if err := l.Observe(ctx, "/posts", resp); err != nil {
+		t.Fatal(err)
+	}
+
+	other := clock.install(NewRateLimiter(nil, path, nil))
+	if err := other.Wait(ctx, "/posts"); !errors.Is(err, model.ErrRateLimited) {
+		t.Errorf("Wait = %v, want model.ErrRateLimited", err)
+	}
+	if err := other.Wait(ctx, "/socialActions/x/comments"); err != nil {
+		t.Errorf("other family: %v", err)
+	}
+
+	// A short reset is waited out instead.
+	resp = makeResp(http.StatusOK, map[string]string{
+		"X-RateLimit-Limit":     "100",
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     "1792152010", // 10s after the clock
+	})
+	clock.now = time.Unix(1792152000, 0)
+	if err := l.Observe(ctx, "/posts", resp); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Wait(ctx, "/posts"); err != nil {
+		t.Fatal(err)
+	}
+	if len(clock.sleeps) != 1 || clock.sleeps[0] != 10*time.Second {
+		t.Errorf("sleeps = %v, want [10s]", clock.sleeps)
+	}
+
+	quotas, err := other.Quotas(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotas) != 1 || quotas[0].Family != "posts" || quotas[0].Limit != 100 || *quotas[0].Remaining != 0 {
+		t.Errorf("Quotas = %+v", quotas)
+	}
+}
+
+func TestRateLimiterIgnoresQuotaWithoutRemaining(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1792152000, 0)}
+	l := clock.install(NewRateLimiter(nil, "", nil))
+	ctx := context.Background()
+
+	resp := makeResp(http.StatusOK, map[string]string{"X-RateLimit-Reset": "1792155600"})
+	if err := l.Observe(ctx, "/posts", resp); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Wait(ctx, "/posts"); err != nil || len(clock.sleeps) != 0 {
+		t.Errorf("Wait = %v, sleeps = %v, want the call let through", err, clock.sleeps)
+	}
+}
+
+func TestRateLimiterQuotas(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	l := clock.install(NewRateLimiter(map[string]Limit{"statistics": {Requests: 5, Per: time.Minute}}, "", nil))
+	if err := l.Wait(context.Background(), "/networkSizes/x"); err != nil {
+		t.Fatal(err)
+	}
+
+	quotas, err := l.Quotas(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotas) != 1 {
+		t.Fatalf("Quotas = %+v", quotas)
+	}
+	q := quotas[0]
+	if q.Family != "statistics" || q.Budget != "5/1m0s" || *q.Available != 4 || q.Remaining != nil {
+		t.Errorf("quota = %+v", q)
+	}
+}
+
+func TestLockFileBreaksStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.lock")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err := lockFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("lockFile: %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("lock not released: %v", err)
+	}
+}
+
+func TestLockFileReleaseKeepsOtherHoldersLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.lock")
+	ctx := context.Background()
+
+	unlockA, err := lockFile(ctx, path)
+	if err != nil {
+		t.Fatalf("lockFile A: %v", err)
+	}
+	// A stalls past staleLock, so B breaks its lock and takes it.
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	unlockB, err := lockFile(ctx, path)
+	if err != nil {
+		t.Fatalf("lockFile B: %v", err)
+	}
+
+	unlockA()
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("A's release removed B's lock: %v", err)
+	}
+	unlockB()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("B's lock not released: %v", err)
+	}
+	if files, _ := filepath.Glob(path + ".*"); len(files) != 0 {
+		t.Errorf("leftover files: %v", files)
+	}
+}
+
+func TestClientRecordsRateLimitHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "500")
+		w.Header().Set("X-RateLimit-Remaining", "499")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	c := New("tok", "202601", WithBaseURL(srv.URL), WithRateLimiter(NewRateLimiter(nil, path, nil)))
+	resp, err := c.Get(context.Background(), "/posts?q=author")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	state, err := ReadRateState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := state["posts"]; q == nil || q.Limit != 500 || q.Remaining != 499 {
+		t.Errorf("state = %+v", q)
+	}
+}
+
+func TestClientWithoutLimitsDoesNotThrottle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	l := NewRateLimiter(nil, path, nil)
+	resp := makeResp(http.StatusTooManyRequests, map[string]string{"Retry-After": "3600"})
+	if err := l.Observe(context.Background(), "/posts", resp); err != nil {
+		t.Fatal(err)
+	}
+
+	c := New("tok", "202601", WithBaseURL(srv.URL), WithRateLimiter(l))
+	resp, err := c.Get(context.Background(), "/posts?q=author")
+	if err != nil {
+		t.Fatalf("Get with an exhausted quota and no limits: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -6,15 +6,13 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+
+	"github.com/Softorize/lcli/internal/model"
 )
 
 // RateLimit holds the rate limit information parsed from LinkedIn API response
 // headers.
-type RateLimit struct {
-	Limit     int
-	Remaining int
-	Reset     time.Time
-}
+type RateLimit = model.RateLimit
 
 // ParseRateLimit extracts rate limit information from the response headers.
 // Returns nil if the headers are not present.
@@ -33,7 +31,7 @@ func ParseRateLimit(resp *http.Response) *RateLimit {
 		rl.Limit = v
 	}
 	if v, err := strconv.Atoi(remainStr); err == nil {
-		rl.Remaining = v
+		rl.Remaining, rl.HasRemaining = v, true
 	}
 	if v, err := strconv.ParseInt(resetStr, 10, 64); err == nil {
 		rl.Reset = time.Unix(v, 0)
@@ -61,3 +59,23 @@ func CheckRateLimit(resp *http.Response) error {
 
 	return fmt.Errorf("rate limited (429): retry later")
 }
+
+// RetryAfter returns how long the server asked the client to wait before
+// retrying. The Retry-After header is honoured in both its delta-seconds and
+// HTTP-date forms, falling back to X-RateLimit-Reset. It returns zero when no
+// hint is present or the indicated time has already passed.
+func RetryAfter(resp *http.Response) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(max(secs, 0)) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(time.Until(t), 0)
+		}
+	}
+
+	if rl := ParseRateLimit(resp); rl != nil && !rl.Reset.IsZero() {
+		return max(time.Until(rl.Reset), 0)
+	}
+	return 0
+}
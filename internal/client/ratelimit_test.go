@@ -99,3 +99,32 @@ func TestCheckRateLimit429NoReset(t *testing.T) {
 		t.Errorf("error %q should contain 'retry later'", err.Error())
 	}
 }
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"none", map[string]string{}, 0, 0},
+		{"seconds", map[string]string{"Retry-After": "12"}, 12 * time.Second, 12 * time.Second},
+		{"http date", map[string]string{
+			"Retry-After": time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat),
+		}, 28 * time.Second, 30 * time.Second},
+		{"rate limit reset", map[string]string{
+			"X-RateLimit-Reset": strconv.FormatInt(time.Now().Add(20*time.Second).Unix(), 10),
+		}, 18 * time.Second, 20 * time.Second},
+		{"past reset", map[string]string{
+			"X-RateLimit-Reset": strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10),
+		}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RetryAfter(makeResp(429, tt.headers))
+			if got < tt.min || got > tt.max {
+				t.Errorf("RetryAfter = %v, want within [%v, %v]", got, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// retry.go implements retrying of transient LinkedIn API failures.
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries transient failures such as
+// 429 throttling, 502/503/504 gateway errors and connection resets.
+type RetryPolicy struct {
+	// MaxRetries is the number of attempts made after the first one.
+	// Zero disables retries.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry. It doubles on
+	// every subsequent attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps a single wait. If the server asks the client to wait
+	// longer than this, the response is returned instead of retried.
+	MaxDelay time.Duration
+	// RetryPOST allows non-idempotent POST requests to be retried. It is
+	// off by default because a retried POST may create duplicates.
+	RetryPOST bool
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// next reports whether the attempt that produced resp or err should be
+// retried, and how long to wait before doing so.
+func (p RetryPolicy) next(method string, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxRetries || !p.allows(method) {
+		return 0, false
+	}
+
+	if err != nil {
+		if !isConnReset(err) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	if hint := RetryAfter(resp); hint > 0 {
+		if hint > p.MaxDelay {
+			return 0, false
+		}
+		return hint, true
+	}
+	return p.backoff(attempt), true
+}
+
+// allows reports whether requests with the given method may be retried.
+func (p RetryPolicy) allows(method string) bool {
+	if method == http.MethodPost {
+		return p.RetryPOST
+	}
+	return true
+}
+
+// backoff returns a jittered exponential delay for the given attempt,
+// drawn uniformly from [BaseDelay/2, BaseDelay*2^attempt] and capped at
+// MaxDelay.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << attempt
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	floor := p.BaseDelay / 2
+	if d <= floor {
+		return d
+	}
+	return floor + rand.N(d-floor)
+}
+
+// isConnReset reports whether err indicates the connection was dropped
+// before a complete response was received.
+func isConnReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// sleepContext waits for d or until ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// drainBody reads and closes the response body to allow connection reuse.
+func drainBody(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,255 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client pointed at srv that records backoff waits
+// instead of sleeping.
+func newTestClient(srv *httptest.Server, p RetryPolicy) (*Client, *[]time.Duration) {
+	var waits []time.Duration
+	c := New("tok", "202501", WithRetryPolicy(p))
+	c.http = srv.Client()
+	c.baseURL = srv.URL
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return c, &waits
+}
+
+func TestDoRetriesTransientStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"429", http.StatusTooManyRequests},
+		{"502", http.StatusBadGateway},
+		{"503", http.StatusServiceUnavailable},
+		{"504", http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			c, waits := newTestClient(srv, DefaultRetryPolicy())
+			resp, err := c.Get(context.Background(), "/posts")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("status = %d, want 200", resp.StatusCode)
+			}
+			if calls.Load() != 2 {
+				t.Errorf("calls = %d, want 2", calls.Load())
+			}
+			if len(*waits) != 1 {
+				t.Errorf("waits = %v, want 1 entry", *waits)
+			}
+		})
+	}
+}
+
+func TestDoDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c, _ := newTestClient(srv, DefaultRetryPolicy())
+	resp, err := c.Get(context.Background(), "/posts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestDoGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	p := DefaultRetryPolicy()
+	p.MaxRetries = 2
+	c, _ := newTestClient(srv, p)
+	resp, err := c.Get(context.Background(), "/posts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", resp.StatusCode)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+}
+
+func TestDoPOSTRetryRequiresOptIn(t *testing.T) {
+	tests := []struct {
+		name      string
+		retryPOST bool
+		wantCalls int32
+	}{
+		{"default", false, 1},
+		{"opted in", true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != `{"text":"hi"}` {
+					t.Errorf("body = %q, want replayed payload", body)
+				}
+				if calls.Add(1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer srv.Close()
+
+			p := DefaultRetryPolicy()
+			p.RetryPOST = tt.retryPOST
+			c, _ := newTestClient(srv, p)
+			resp, err := c.Post(context.Background(), "/posts", map[string]string{"text": "hi"})
+			if err != nil {
+				t.Fatalf("Post: %v", err)
+			}
+			resp.Body.Close()
+
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDoHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, waits := newTestClient(srv, DefaultRetryPolicy())
+	resp, err := c.Get(context.Background(), "/posts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Errorf("waits = %v, want [7s]", *waits)
+	}
+}
+
+func TestDoReturnsWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c, _ := newTestClient(srv, DefaultRetryPolicy())
+	resp, err := c.Get(context.Background(), "/posts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429", resp.StatusCode)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestDoRetriesConnectionReset(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("hijack: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, _ := newTestClient(srv, DefaultRetryPolicy())
+	resp, err := c.Get(context.Background(), "/posts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2", calls.Load())
+	}
+}
+
+func TestDoStopsWhenContextCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, _ := newTestClient(srv, DefaultRetryPolicy())
+	c.sleep = sleepContext
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Get(ctx, "/posts"); err == nil {
+		t.Fatal("expected error for cancelled context")
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt := range 8 {
+		d := p.backoff(attempt)
+		if d < p.BaseDelay/2 || d > p.MaxDelay {
+			t.Errorf("backoff(%d) = %v, want within [%v, %v]", attempt, d, p.BaseDelay/2, p.MaxDelay)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+// trace.go records HTTP exchanges for --trace, --verbose and --trace-file,
+// redacting tokens and secrets; the redaction helpers are shared with the
+// cassette recorder.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTraceBody caps the bytes of a request or response body kept in a trace.
+const maxTraceBody = 64 << 10
+
+// redacted replaces secret values in traces.
+const redacted = "REDACTED"
+
+// secretHeaders are never written to a trace in full.
+var secretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// secretParams are query, form and JSON fields whose values are redacted.
+var secretParams = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+	"code_verifier": true,
+	"token":         true,
+	"password":      true,
+}
+
+// secretFormParams are redacted in queries and form bodies only. "code" is
+// the OAuth authorization code there, but an error code in JSON responses.
+var secretFormParams = map[string]bool{
+	"code": true,
+}
+
+// TraceRecord describes one HTTP exchange. Tracers write them as NDJSON.
+type TraceRecord struct {
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	Status          int               `json:"status,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	DurationMS      int64             `json:"duration_ms"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// Tracer records HTTP exchanges for debugging, with tokens and secrets
+// redacted. It writes a readable summary to one writer and NDJSON records
+// to another; either may be nil.
+type Tracer struct {
+	w        io.Writer
+	detailed bool
+	ndjson   io.Writer
+
+	mu sync.Mutex
+}
+
+// NewTracer returns a Tracer. Summaries go to w: the method, URL, API
+// version headers, request body, status, rate-limit and trace-ID headers and
+// timing. With detailed set they also include every header and the response
+// body. NDJSON records always include everything.
+func NewTracer(w io.Writer, detailed bool, ndjson io.Writer) *Tracer {
+	return &Tracer{w: w, detailed: detailed, ndjson: ndjson}
+}
+
+// WithTracer records every request the client sends with t.
+func WithTracer(t *Tracer) Option {
+	return func(c *Client) { c.tracer = t }
+}
+
+// Transport wraps next so that every exchange is recorded. A nil next means
+// http.DefaultTransport.
+func (t *Tracer) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &tracingTransport{next: next, tracer: t}
+}
+
+// tracingTransport is the http.RoundTripper returned by Tracer.Transport.
+type tracingTransport struct {
+	next   http.RoundTripper
+	tracer *Tracer
+}
+
+func (tt *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := &TraceRecord{
+		Time:           time.Now(),
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+		RequestBody:    requestBody(req),
+	}
+
+	resp, err := tt.next.RoundTrip(req)
+	rec.DurationMS = time.Since(rec.Time).Milliseconds()
+	if err != nil {
+		rec.Error = err.Error()
+	} else {
+		rec.Status = resp.StatusCode
+		rec.ResponseHeaders = redactHeaders(resp.Header)
+		rec.ResponseBody = responseBody(resp)
+	}
+
+	tt.tracer.record(rec)
+	return resp, err
+}
+
+// record writes rec to the tracer's outputs.
+func (t *Tracer) record(rec *TraceRecord) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.w != nil {
+		t.writeSummary(rec)
+	}
+	if t.ndjson != nil {
+		if data, err := json.Marshal(rec); err == nil {
+			_, _ = t.ndjson.Write(append(data, '\n'))
+		}
+	}
+}
+
+// summaryRequestHeaders are shown for every request; the rest only when
+// the tracer is detailed.
+var summaryRequestHeaders = []string{"Linkedin-Version", "X-Restli-Protocol-Version"}
+
+// writeSummary prints rec in a readable form.
+func (t *Tracer) writeSummary(rec *TraceRecord) {
+	fmt.Fprintf(t.w, "> %s %s\n", rec.Method, rec.URL)
+	for _, k := range slices.Sorted(maps.Keys(rec.RequestHeaders)) {
+		if t.detailed || slices.Contains(summaryRequestHeaders, http.CanonicalHeaderKey(k)) {
+			fmt.Fprintf(t.w, "> %s: %s\n", k, rec.RequestHeaders[k])
+		}
+	}
+	if rec.RequestBody != "" {
+		fmt.Fprintf(t.w, "> %s\n", rec.RequestBody)
+	}
+
+	if rec.Error != "" {
+		fmt.Fprintf(t.w, "< error after %dms: %s\n", rec.DurationMS, rec.Error)
+		return
+	}
+	fmt.Fprintf(t.w, "< %d %s (%dms)\n", rec.Status, http.StatusText(rec.Status), rec.DurationMS)
+	for _, k := range slices.Sorted(maps.Keys(rec.ResponseHeaders)) {
+		if t.detailed || isDiagnosticHeader(k) {
+			fmt.Fprintf(t.w, "< %s: %s\n", k, rec.ResponseHeaders[k])
+		}
+	}
+	if t.detailed && rec.ResponseBody != "" {
+		fmt.Fprintf(t.w, "< %s\n", rec.ResponseBody)
+	}
+}
+
+// isDiagnosticHeader reports whether a response header carries rate-limit
+// state or a LinkedIn trace ID such as x-li-uuid.
+func isDiagnosticHeader(k string) bool {
+	k = http.CanonicalHeaderKey(k)
+	return strings.HasPrefix(k, "X-Ratelimit-") || strings.HasPrefix(k, "X-Li-") ||
+		k == "Retry-After" || k == "X-Restli-Id"
+}
+
+// redactHeaders flattens h, hiding credentials. The Authorization scheme is
+// kept so a trace still shows that a bearer token was sent.
+func redactHeaders(h http.Header) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		value := strings.Join(v, ", ")
+		if ck := http.CanonicalHeaderKey(k); secretHeaders[ck] {
+			scheme, _, ok := strings.Cut(value, " ")
+			if ok && strings.HasSuffix(ck, "Authorization") {
+				value = scheme + " " + redacted
+			} else {
+				value = redacted
+			}
+		}
+		out[k] = value
+	}
+	return out
+}
+
+// redactURL returns u with secret query parameters hidden.
+func redactURL(u *url.URL) string {
+	c := *u
+	c.RawQuery = redactQuery(u.RawQuery)
+	return c.String()
+}
+
+// redactQuery hides secret parameters in a raw query. A query without
+// secrets is returned unchanged, keeping Rest.li encoding intact.
+func redactQuery(raw string) string {
+	q, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw
+	}
+	changed := false
+	for k := range q {
+		if secretParams[k] || secretFormParams[k] {
+			q.Set(k, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return raw
+	}
+	return q.Encode()
+}
+
+// requestBody returns the redacted request body without consuming it. Only
+// textual bodies are captured, so media uploads are not copied.
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return ""
+	}
+	if !isTextual(req.Header.Get("Content-Type")) {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(io.LimitReader(body, maxTraceBody+1))
+	return redactBody(req.Header.Get("Content-Type"), data)
+}
+
+// responseBody returns the redacted response body and replaces resp.Body so
+// the caller can still read all of it.
+func responseBody(resp *http.Response) string {
+	if resp.Body == nil || !isTextual(resp.Header.Get("Content-Type")) {
+		return ""
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxTraceBody+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+	return redactBody(resp.Header.Get("Content-Type"), data)
+}
+
+// isTextual reports whether a body of the given content type is worth
+// tracing.
+func isTextual(contentType string) bool {
+	mt, _, _ := mime.ParseMediaType(contentType)
+	return mt == "application/json" || mt == "application/x-www-form-urlencoded" ||
+		strings.HasPrefix(mt, "text/")
+}
+
+// redactBody hides secret fields in a JSON or form body and truncates it.
+func redactBody(contentType string, data []byte) string {
+	truncated := len(data) > maxTraceBody
+	if truncated {
+		data = data[:maxTraceBody]
+	}
+
+	mt, _, _ := mime.ParseMediaType(contentType)
+	if mt == "application/json" && truncated {
+		// A cut-off document cannot be parsed, so it cannot be redacted.
+		return fmt.Sprintf("(JSON body over %d bytes omitted)", maxTraceBody)
+	}
+
+	s := string(data)
+	switch {
+	case mt == "application/x-www-form-urlencoded":
+		if form, err := url.ParseQuery(s); err == nil {
+			for k := range form {
+				if secretParams[k] || secretFormParams[k] {
+					form.Set(k, redacted)
+				}
+			}
+			s = form.Encode()
+		}
+	case mt == "application/json":
+		var v any
+		if json.Unmarshal(data, &v) == nil {
+			if out, err := json.Marshal(redactJSON(v)); err == nil {
+				s = string(out)
+			}
+		}
+	}
+
+	if truncated {
+		s += "...(truncated)"
+	}
+	return s
+}
+
+// redactJSON replaces the values of secret fields anywhere in v.
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if secretParams[k] {
+				v[k] = redacted
+			} else {
+				v[k] = redactJSON(child)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactJSON(child)
+		}
+	}
+	return v
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTracerRecordsExchange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Li-Uuid", "abc-123")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-Other", "noise")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"bad field","status":400}`))
+	}))
+	defer srv.Close()
+
+	var summary, ndjson bytes.Buffer
+	c := New("secret-token", "202601", WithTracer(NewTracer(&summary, false, &ndjson)))
+	c.baseURL = srv.URL
+
+	resp, err := c.Do(context.Background(), http.MethodPost, "/posts", map[string]string{"commentary": "hi"})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "bad field") {
+		t.Errorf("caller lost the response body: %q", body)
+	}
+
+	out := summary.String()
+	for _, want := range []string{
+		"> POST " + srv.URL + "/posts",
+		"> Linkedin-Version: 202601",
+		"> X-Restli-Protocol-Version: 2.0.0",
+		`{"commentary":"hi"}`,
+		"< 400 Bad Request",
+		"< X-Li-Uuid: abc-123",
+		"< X-Ratelimit-Remaining: 42",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"secret-token", "X-Other", "bad field"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("summary should not contain %q:\n%s", unwanted, out)
+		}
+	}
+
+	var rec TraceRecord
+	if err := json.Unmarshal(ndjson.Bytes(), &rec); err != nil {
+		t.Fatalf("NDJSON record: %v\n%s", err, ndjson.String())
+	}
+	if rec.Status != 400 || rec.Method != "POST" || !strings.Contains(rec.ResponseBody, "bad field") {
+		t.Errorf("record = %+v", rec)
+	}
+	if got := rec.RequestHeaders["Authorization"]; got != "Bearer REDACTED" {
+		t.Errorf("Authorization = %q, want redacted", got)
+	}
+}
+
+func TestTracerDetailedIncludesResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"urn:li:share:1"}`))
+	}))
+	defer srv.Close()
+
+	var summary bytes.Buffer
+	c := New("tok", "202601", WithTracer(NewTracer(&summary, true, nil)))
+	c.baseURL = srv.URL
+
+	resp, err := c.Do(context.Background(), http.MethodGet, "/posts/1", nil)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	out := summary.String()
+	if !strings.Contains(out, `< {"id":"urn:li:share:1"}`) || !strings.Contains(out, "> Authorization: Bearer REDACTED") {
+		t.Errorf("detailed trace incomplete:\n%s", out)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        []string
+		unwanted    []string
+	}{
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=authorization_code&code=AUTHCODE&client_secret=SHH",
+			want:        []string{"grant_type=authorization_code", "code=REDACTED", "client_secret=REDACTED"},
+			unwanted:    []string{"AUTHCODE", "SHH"},
+		},
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"access_token":"AT","nested":{"refresh_token":"RT"},"code":"NOT_FOUND"}`,
+			want:        []string{`"access_token":"REDACTED"`, `"refresh_token":"REDACTED"`, `"code":"NOT_FOUND"`},
+			unwanted:    []string{`"AT"`, `"RT"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactBody(tt.contentType, []byte(tt.body))
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("redactBody = %q, missing %q", got, w)
+				}
+			}
+			for _, u := range tt.unwanted {
+				if strings.Contains(got, u) {
+					t.Errorf("redactBody = %q, leaks %q", got, u)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/oauth?code=XYZ&state=abc", nil)
+	got := redactURL(req.URL)
+	if strings.Contains(got, "XYZ") || !strings.Contains(got, "state=abc") {
+		t.Errorf("redactURL = %q", got)
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportConfig describes how lcli reaches the network. The zero value
+// uses the proxy from HTTPS_PROXY, HTTP_PROXY and NO_PROXY and trusts the
+// system roots.
+type TransportConfig struct {
+	// ProxyURL, if set, replaces the proxy chosen by the environment.
+	ProxyURL string
+	// CABundle is a PEM file of certificates trusted in addition to the
+	// system roots, such as an inspecting proxy's CA.
+	CABundle string
+	// ClientCert and ClientKey are PEM files holding a certificate and key
+	// presented for mutual TLS. An empty ClientKey means the key is in the
+	// ClientCert file.
+	ClientCert string
+	ClientKey  string
+}
+
+// NewTransport returns an HTTP transport configured by tc. Like the
+// transport of New, it gives up on a server that accepts a request but never
+// responds.
+func NewTransport(tc TransportConfig) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ResponseHeaderTimeout = responseHeaderTimeout
+
+	if tc.ProxyURL != "" {
+		u, err := url.Parse(tc.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("proxy URL: %w", err)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	if tc.ClientKey != "" && tc.ClientCert == "" {
+		return nil, errors.New("client key given without a client certificate")
+	}
+	if tc.CABundle == "" && tc.ClientCert == "" {
+		return t, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if tc.CABundle != "" {
+		pool, err := loadCABundle(tc.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if tc.ClientCert != "" {
+		key := tc.ClientKey
+		if key == "" {
+			key = tc.ClientCert
+		}
+		cert, err := tls.LoadX509KeyPair(tc.ClientCert, key)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	t.TLSClientConfig = cfg
+	return t, nil
+}
+
+// loadCABundle returns the system roots plus the certificates in the PEM
+// file at path.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writePEM writes a single PEM block to a file in a temporary directory and
+// returns its path.
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// get sends a GET to url through rt and returns the status code.
+func get(t *testing.T, rt http.RoundTripper, url string) (int, error) {
+	t.Helper()
+	resp, err := (&http.Client{Transport: rt}).Get(url)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func TestNewTransportCABundle(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	// The handshake without the bundle is expected to fail; keep it quiet.
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	plain, err := NewTransport(TransportConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := get(t, plain, srv.URL); err == nil {
+		t.Fatal("expected an unknown authority error without the CA bundle")
+	}
+
+	bundle := writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+	rt, err := NewTransport(TransportConfig{CABundle: bundle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code, err := get(t, rt, srv.URL); err != nil || code != http.StatusOK {
+		t.Fatalf("GET = %d, %v", code, err)
+	}
+}
+
+func TestNewTransportClientCertificate(t *testing.T) {
+	var presented int
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "lcli test client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rt, err := NewTransport(TransportConfig{
+		CABundle:   writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw),
+		ClientCert: writePEM(t, "client.pem", "CERTIFICATE", der),
+		ClientKey:  writePEM(t, "client-key.pem", "EC PRIVATE KEY", keyDER),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code, err := get(t, rt, srv.URL); err != nil || code != http.StatusOK {
+		t.Fatalf("GET = %d, %v", code, err)
+	}
+	if presented != 1 {
+		t.Errorf("server saw %d client certificates, want 1", presented)
+	}
+}
+
+func TestNewTransportProxyURL(t *testing.T) {
+	var target string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	rt, err := NewTransport(TransportConfig{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code, err := get(t, rt, "http://api.example.test/rest/posts"); err != nil || code != http.StatusOK {
+		t.Fatalf("GET = %d, %v", code, err)
+	}
+	if target != "http://api.example.test/rest/posts" {
+		t.Errorf("proxy saw %q", target)
+	}
+}
+
+func TestNewTransportErrors(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		tc   TransportConfig
+	}{
+		{"missing bundle", TransportConfig{CABundle: filepath.Join(t.TempDir(), "missing.pem")}},
+		{"bundle without certificates", TransportConfig{CABundle: empty}},
+		{"key without certificate", TransportConfig{ClientKey: empty}},
+		{"unreadable certificate", TransportConfig{ClientCert: empty}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTransport(tt.tc); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"strconv"
@@ -25,7 +24,7 @@ func runAnalytics(args []string, deps *Deps) error {
 		printAnalyticsUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("analytics: unknown subcommand %q", args[0])
+		return usageErrorf("analytics: unknown subcommand %q", args[0])
 	}
 }
 
@@ -44,23 +43,27 @@ Use "lcli analytics <subcommand> -help" for more information.
 // runAnalyticsPost handles the analytics post subcommand.
 func runAnalyticsPost(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("analytics post", flag.ContinueOnError)
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if fs.NArg() < 1 {
-		return fmt.Errorf("analytics post: post URN argument is required")
+		return usageErrorf("analytics post: post URN argument is required")
+	}
+	urn, err := resolvePost("analytics post", fs.Arg(0))
+	if err != nil {
+		return err
 	}
 
-	if err := requireAuth(deps.Analytics); err != nil {
+	if err := requireAuth(deps, deps.Analytics, scopeOrgAdmin); err != nil {
 		return err
 	}
 
-	urn := fs.Arg(0)
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 
 	stats, err := deps.Analytics.PostAnalytics(ctx, urn)
 	if err != nil {
@@ -87,18 +90,19 @@ func runAnalyticsPost(args []string, deps *Deps) error {
 // runAnalyticsViews handles the analytics views subcommand.
 func runAnalyticsViews(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("analytics views", flag.ContinueOnError)
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
-	if err := requireAuth(deps.Analytics); err != nil {
+	if err := requireAuth(deps, deps.Analytics, scopeConnectionsSize); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 	count, err := deps.Analytics.ProfileViews(ctx)
 	if err != nil {
 		return fmt.Errorf("analytics views: %w", err)
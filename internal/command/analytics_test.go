@@ -44,7 +44,7 @@ func TestAnalyticsPostMissingURN(t *testing.T) {
 func TestAnalyticsPostNotAuthenticated(t *testing.T) {
 	deps, _, _ := testDeps()
 
-	err := runAnalyticsPost([]string{"urn"}, deps)
+	err := runAnalyticsPost([]string{"urn:li:share:123"}, deps)
 	if err == nil {
 		t.Fatal("expected auth error")
 	}
@@ -58,7 +58,7 @@ func TestAnalyticsPostServiceError(t *testing.T) {
 		},
 	}
 
-	err := runAnalyticsPost([]string{"urn"}, deps)
+	err := runAnalyticsPost([]string{"urn:li:share:123"}, deps)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -2,7 +2,8 @@ package command
 
 import "fmt"
 
-// runAuth dispatches to auth subcommands: login, logout, status.
+// runAuth dispatches to auth subcommands: login, logout, status, refresh,
+// list, switch, migrate-store.
 func runAuth(args []string, deps *Deps) error {
 	if len(args) == 0 {
 		printAuthUsage(deps)
@@ -16,11 +17,19 @@ func runAuth(args []string, deps *Deps) error {
 		return runAuthLogout(args[1:], deps)
 	case "status":
 		return runAuthStatus(args[1:], deps)
+	case "refresh":
+		return runAuthRefresh(args[1:], deps)
+	case "list":
+		return runAuthList(args[1:], deps)
+	case "switch":
+		return runAuthSwitch(args[1:], deps)
+	case "migrate-store":
+		return runAuthMigrateStore(args[1:], deps)
 	case "-help", "--help", "-h":
 		printAuthUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("auth: unknown subcommand %q", args[0])
+		return usageErrorf("auth: unknown subcommand %q", args[0])
 	}
 }
 
@@ -32,6 +41,11 @@ Subcommands:
   login     Authenticate with LinkedIn via OAuth
   logout    Remove stored credentials
   status    Show current authentication status
+  refresh   Renew the access token using the stored refresh token
+  list      List profiles and mark the active one
+  switch    Make a profile the current default
+  migrate-store
+            Move credentials to another store (file/encrypted/keyring)
 
 Use "lcli auth <subcommand> -help" for more information.
 `)
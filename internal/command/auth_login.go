@@ -1,65 +1,105 @@
 package command
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Softorize/lcli/internal/auth"
 	"github.com/Softorize/lcli/internal/config"
 )
 
+// defaultCallbackPort is used when neither --port nor redirect_uri names one.
+const defaultCallbackPort = 8484
+
+// defaultLoginTimeout bounds the login flow unless --timeout is given.
+const defaultLoginTimeout = 120 * time.Second
+
 // runAuthLogin handles the auth login subcommand.
 func runAuthLogin(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("auth login", flag.ContinueOnError)
-	port := fs.Int("port", 8484, "Local port for OAuth callback server")
-	timeout := fs.Int("timeout", 120, "Timeout in seconds for login flow")
+	port := fs.Int("port", 0, "Local port for OAuth callback server (default: the redirect_uri port, else 8484)")
+	timeout := defaultLoginTimeout
+	if deps.Global.Timeout > 0 {
+		timeout = deps.Global.Timeout
+	}
+	fs.Func("timeout", "Timeout for the login flow, e.g. 5m or 300 (default 120s, or the global --timeout)", func(v string) error {
+		d, err := parseTimeout(v)
+		timeout = d
+		return err
+	})
+	manual := fs.Bool("manual", false, "Paste the redirect URL instead of running a callback server")
+	noBrowser := fs.Bool("no-browser", false, "Alias for --manual")
+	clientCreds := fs.Bool("client-credentials", false, "Get an application token with the client-credentials grant (no browser)")
+	scopes := fs.String("scopes", "", "Extra OAuth scopes to request, comma-separated (e.g. rw_organization_admin)")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("auth login: %w", err)
+	if *clientCreds {
+		return loginClientCredentials(deps, deps.Cfg, timeout)
 	}
 
-	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+	// The extra scopes apply to this login only.
+	cfg := *deps.Cfg
+
+	if cfg.ClientID == "" || (cfg.ClientSecret == "" && !cfg.PKCE) {
 		return fmt.Errorf("auth login: credentials not configured — run 'lcli config setup' first")
 	}
 
-	authenticator := auth.NewAuthenticator(cfg)
+	cfg.Scopes = append(slices.Clone(cfg.Scopes), auth.ParseScopes(*scopes)...)
+	authenticator := auth.NewAuthenticator(&cfg, deps.HTTP)
+
+	if cfg.PKCE {
+		verifier, err := auth.NewVerifier()
+		if err != nil {
+			return fmt.Errorf("auth login: %w", err)
+		}
+		authenticator.UsePKCE(verifier)
+	}
 
 	state, err := auth.RandomState()
 	if err != nil {
 		return fmt.Errorf("auth login: %w", err)
 	}
 
-	srv := auth.NewCallbackServer(*port)
 	url := authenticator.AuthorizationURL(state)
 
-	fmt.Fprintf(deps.Stderr, "Open this URL in your browser:\n\n  %s\n\nWaiting for callback...\n", url)
-	openBrowser(url)
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*timeout)*time.Second)
+	ctx, cancel := context.WithTimeout(deps.rootContext(), timeout)
 	defer cancel()
 
-	code, returnedState, err := srv.Start(ctx)
+	var code string
+	if *manual || *noBrowser {
+		code, err = manualCallback(ctx, deps, url, state)
+	} else {
+		listen, perr := callbackPort(cfg.RedirectURI, *port)
+		if perr != nil {
+			return fmt.Errorf("auth login: %w", perr)
+		}
+		code, err = serverCallback(ctx, deps, listen, url, state)
+	}
 	if err != nil {
 		return fmt.Errorf("auth login: %w", err)
 	}
 
-	if returnedState != state {
-		return fmt.Errorf("auth login: state mismatch — possible CSRF attack")
-	}
-
 	token, err := authenticator.Exchange(ctx, code)
 	if err != nil {
 		return fmt.Errorf("auth login: %w", err)
 	}
 
+	// Some token responses omit the scope field; record what was requested.
+	if len(token.Scopes) == 0 {
+		token.Scopes = authenticator.Scopes()
+	}
+
 	if err := config.SaveToken(token); err != nil {
 		return fmt.Errorf("auth login: %w", err)
 	}
@@ -67,3 +107,120 @@ func runAuthLogin(args []string, deps *Deps) error {
 	fmt.Fprintf(deps.Stderr, "Authentication successful!\n")
 	return nil
 }
+
+// loginClientCredentials obtains and stores an application token.
+func loginClientCredentials(deps *Deps, cfg *config.Config, timeout time.Duration) error {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return fmt.Errorf("auth login: --client-credentials needs a client ID and secret — run 'lcli config setup' first")
+	}
+
+	ctx, cancel := context.WithTimeout(deps.rootContext(), timeout)
+	defer cancel()
+
+	token, err := auth.NewAuthenticator(cfg, deps.HTTP).ClientCredentials(ctx)
+	if err != nil {
+		return fmt.Errorf("auth login: %w", err)
+	}
+
+	if err := config.SaveToken(token); err != nil {
+		return fmt.Errorf("auth login: %w", err)
+	}
+
+	fmt.Fprintf(deps.Stderr, "Application token stored. Expires: %s\n", token.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
+
+// callbackPort returns the port for the local callback server. LinkedIn
+// redirects to redirect_uri, so an explicit --port must match its port.
+func callbackPort(redirectURI string, flagPort int) (int, error) {
+	uriPort, err := config.RedirectPort(redirectURI)
+	switch {
+	case flagPort == 0 && err == nil:
+		return uriPort, nil
+	case flagPort == 0:
+		return defaultCallbackPort, nil
+	case err == nil && flagPort != uriPort:
+		return 0, fmt.Errorf("--port %d does not match redirect_uri %s — run 'lcli config set redirect_uri http://localhost:%d/callback' and update the app's redirect URLs", flagPort, redirectURI, flagPort)
+	default:
+		return flagPort, nil
+	}
+}
+
+// serverCallback opens the authorization URL in a browser and waits for the
+// redirect on a local callback server.
+func serverCallback(ctx context.Context, deps *Deps, port int, url, state string) (string, error) {
+	srv := auth.NewCallbackServer(port)
+
+	fmt.Fprintf(deps.prompt(), "Open this URL in your browser:\n\n  %s\n\nWaiting for callback...\n", url)
+	openBrowser(url)
+
+	code, returnedState, err := srv.Start(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if returnedState != state {
+		return "", auth.ErrStateMismatch
+	}
+	return code, nil
+}
+
+// manualCallback prints the authorization URL and reads the redirect URL or
+// code pasted by the user. It is meant for SSH sessions and containers where
+// neither a browser nor a local port is reachable. A bare code carries no
+// state to check, so it is used only once the user confirms it.
+func manualCallback(ctx context.Context, deps *Deps, url, state string) (string, error) {
+	fmt.Fprintf(deps.prompt(), `Open this URL in a browser on any machine:
+
+  %s
+
+After approving, the browser is redirected to a page that may fail to load.
+Copy the full URL from its address bar and paste it here (or just the code):
+> `, url)
+
+	in := bufio.NewReader(deps.Stdin)
+	s, err := readLine(ctx, in, "redirect URL")
+	if err != nil {
+		return "", err
+	}
+	code, err := auth.ParseRedirect(s, state)
+	if !errors.Is(err, auth.ErrBareCode) {
+		return code, err
+	}
+
+	fmt.Fprint(deps.prompt(), `A bare code cannot be checked against the state of this login, which guards
+against forged redirects. Use it anyway? [y/N] `)
+	answer, err := readLine(ctx, in, "answer")
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return strings.TrimSpace(s), nil
+	}
+	return "", auth.ErrBareCode
+}
+
+// readLine reads a line from r, or the rest of the input if it does not end
+// in a newline, unless ctx is done first. what names the line in errors.
+func readLine(ctx context.Context, r *bufio.Reader, what string) (string, error) {
+	line := make(chan string, 1)
+	readErr := make(chan error, 1)
+	go func() {
+		s, err := r.ReadString('\n')
+		if err != nil && (s == "" || !errors.Is(err, io.EOF)) {
+			readErr <- err
+			return
+		}
+		line <- s
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("waiting for %s: %w", what, ctx.Err())
+	case err := <-readErr:
+		return "", fmt.Errorf("read %s: %w", what, err)
+	case s := <-line:
+		return s, nil
+	}
+}
@@ -3,31 +3,54 @@ package command
 import (
 	"flag"
 	"fmt"
-	"os"
-	"path/filepath"
 
+	"github.com/Softorize/lcli/internal/auth"
 	"github.com/Softorize/lcli/internal/config"
 )
 
 // runAuthLogout handles the auth logout subcommand.
 func runAuthLogout(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("auth logout", flag.ContinueOnError)
+	revoke := fs.Bool("revoke", false, "Revoke the token at LinkedIn before removing it locally")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
-	tokenPath := filepath.Join(config.ConfigDir(), "tokens.json")
-
-	if err := os.Remove(tokenPath); err != nil {
-		if os.IsNotExist(err) {
-			fmt.Fprintf(deps.Stderr, "No stored credentials found.\n")
-			return nil
+	if *revoke {
+		if err := revokeToken(deps); err != nil {
+			return fmt.Errorf("auth logout: %w — credentials were kept; run without --revoke to remove them anyway", err)
 		}
+	}
+
+	removed, err := config.DeleteToken()
+	if err != nil {
 		return fmt.Errorf("auth logout: %w", err)
 	}
+	if !removed {
+		fmt.Fprintf(deps.Stderr, "No stored credentials found.\n")
+		return nil
+	}
 
+	if *revoke {
+		fmt.Fprintf(deps.Stderr, "Token revoked and credentials removed.\n")
+		return nil
+	}
 	fmt.Fprintf(deps.Stderr, "Credentials removed.\n")
 	return nil
 }
+
+// revokeToken revokes the stored access token at LinkedIn. It does nothing
+// if no token is stored.
+func revokeToken(deps *Deps) error {
+	token, err := config.LoadToken()
+	if err != nil || token == nil {
+		return err
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
+
+	return auth.NewAuthenticator(deps.Cfg, deps.HTTP).Revoke(ctx, token.AccessToken)
+}
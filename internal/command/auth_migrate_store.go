@@ -0,0 +1,41 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Softorize/lcli/internal/config"
+)
+
+// runAuthMigrateStore handles the auth migrate-store subcommand.
+func runAuthMigrateStore(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("auth migrate-store", flag.ContinueOnError)
+	to := fs.String("to", "", "Destination credential store ("+strings.Join(config.StoreNames(), "/")+")")
+	force := fs.Bool("force", false, "Switch stores even if the current one cannot be read, leaving its credentials behind")
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+
+	if *to == "" {
+		return usageErrorf("auth migrate-store: --to is required")
+	}
+	if !slices.Contains(config.StoreNames(), *to) {
+		return usageErrorf("auth migrate-store: unknown credential store %q (use %s)", *to, strings.Join(config.StoreNames(), ", "))
+	}
+
+	moved, err := config.MigrateStore(*to, *force)
+	if err != nil {
+		return fmt.Errorf("auth migrate-store: %w", err)
+	}
+
+	if !moved {
+		fmt.Fprintf(deps.Stderr, "Profile %q now uses the %s store. Its credentials could not be read and were left behind; run 'lcli auth login' to store new ones.\n", config.ActiveProfile(), *to)
+		return nil
+	}
+	fmt.Fprintf(deps.Stderr, "Credentials for profile %q moved to the %s store.\n", config.ActiveProfile(), *to)
+	return nil
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/Softorize/lcli/internal/config"
+	"github.com/Softorize/lcli/internal/output"
+)
+
+// profileSummary describes a profile for auth list output.
+type profileSummary struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	Active  bool   `json:"active"`
+	Status  string `json:"status"`
+}
+
+// runAuthList handles the auth list subcommand.
+func runAuthList(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("auth list", flag.ContinueOnError)
+	outputFmt := outputFlag(fs, deps)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+
+	names, err := config.ListProfiles()
+	if err != nil {
+		return fmt.Errorf("auth list: %w", err)
+	}
+
+	current := config.CurrentProfile()
+	active := config.ActiveProfile()
+
+	profiles := make([]profileSummary, 0, len(names))
+	for _, name := range names {
+		profiles = append(profiles, profileSummary{
+			Name:    name,
+			Current: name == current,
+			Active:  name == active,
+			Status:  profileStatus(name),
+		})
+	}
+
+	printer, err := newPrinter(deps, *outputFmt)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format() == output.FormatTable {
+		headers := []string{"", "Profile", "Status"}
+		rows := make([][]string, 0, len(profiles))
+		for _, p := range profiles {
+			marker := ""
+			if p.Active {
+				marker = "*"
+			}
+			rows = append(rows, []string{marker, p.Name, p.Status})
+		}
+		return printer.PrintTable(headers, rows)
+	}
+
+	return printer.Print(profiles)
+}
+
+// profileStatus loads the named profile's token and describes it. A store
+// that cannot be read, such as the encrypted store without its passphrase,
+// is that profile's status rather than a failure of the whole list.
+func profileStatus(name string) string {
+	token, err := config.LoadProfileToken(name)
+	if err != nil {
+		return fmt.Sprintf("store unreadable: %v", err)
+	}
+
+	switch {
+	case token == nil:
+		return "not authenticated"
+	case token.Valid():
+		return "authenticated"
+	case token.CanRefresh():
+		return "expired (refreshable)"
+	default:
+		return "expired"
+	}
+}
+
+// runAuthSwitch handles the auth switch subcommand.
+func runAuthSwitch(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("auth switch", flag.ContinueOnError)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 {
+		return usageErrorf("auth switch: profile name argument is required")
+	}
+
+	name := fs.Arg(0)
+	if err := config.SetCurrentProfile(name); err != nil {
+		if errors.Is(err, config.ErrUnknownProfile) {
+			return fmt.Errorf("auth switch: profile %q does not exist — create it with 'lcli --profile %s config setup'", name, name)
+		}
+		return fmt.Errorf("auth switch: %w", err)
+	}
+
+	fmt.Fprintf(deps.Stderr, "Switched to profile %q.\n", name)
+	return nil
+}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Softorize/lcli/internal/auth"
+	"github.com/Softorize/lcli/internal/config"
+)
+
+// runAuthRefresh handles the auth refresh subcommand.
+func runAuthRefresh(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("auth refresh", flag.ContinueOnError)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+
+	token, err := config.LoadToken()
+	if err != nil {
+		return fmt.Errorf("auth refresh: %w", err)
+	}
+	if token == nil {
+		return errNotAuthenticated
+	}
+	// App tokens have no refresh token; Renew issues a new one instead.
+	if !token.IsApp() && !token.CanRefresh() {
+		return fmt.Errorf("auth refresh: no usable refresh token stored — run 'lcli auth login' again")
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
+
+	fresh, err := auth.NewAuthenticator(deps.Cfg, deps.HTTP).Renew(ctx, token)
+	if err != nil {
+		return fmt.Errorf("auth refresh: %w", err)
+	}
+
+	fmt.Fprintf(deps.Stderr, "Token refreshed. Expires: %s\n", fresh.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
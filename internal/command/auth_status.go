@@ -6,25 +6,33 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Softorize/lcli/internal/auth"
 	"github.com/Softorize/lcli/internal/config"
 )
 
 // runAuthStatus handles the auth status subcommand.
 func runAuthStatus(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("auth status", flag.ContinueOnError)
+	remote := fs.Bool("remote", false, "Ask LinkedIn whether the token is active (token introspection)")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	token, err := config.LoadToken()
+
+	fmt.Fprintf(deps.Stdout, "Profile: %s\n", config.ActiveProfile())
+
+	// An unreadable store, such as the encrypted store without LCLI_KEY,
+	// is a status to report rather than a failure of the command.
 	if err != nil {
-		return fmt.Errorf("auth status: %w", err)
+		fmt.Fprintf(deps.Stdout, "Status:  unknown: %v\n", err)
+		return nil
 	}
 
 	if token == nil {
-		fmt.Fprintf(deps.Stdout, "Status: not authenticated\n")
+		fmt.Fprintf(deps.Stdout, "Status:  not authenticated\n")
 		fmt.Fprintf(deps.Stdout, "Run 'lcli auth login' to authenticate.\n")
 		return nil
 	}
@@ -34,11 +42,89 @@ func runAuthStatus(args []string, deps *Deps) error {
 		status = "expired"
 	}
 
+	tokenType := config.TokenMember
+	if token.IsApp() {
+		tokenType = config.TokenApp
+	}
+
 	fmt.Fprintf(deps.Stdout, "Status:  %s\n", status)
-	fmt.Fprintf(deps.Stdout, "Expires: %s\n", token.ExpiresAt.Format(time.RFC3339))
+	fmt.Fprintf(deps.Stdout, "Type:    %s\n", tokenType)
+	if config.TokenFromEnv() {
+		fmt.Fprintf(deps.Stdout, "Source:  LCLI_ACCESS_TOKEN\n")
+	}
+	if token.ExpiresAt.IsZero() {
+		fmt.Fprintf(deps.Stdout, "Expires: unknown\n")
+	} else {
+		fmt.Fprintf(deps.Stdout, "Expires: %s\n", token.ExpiresAt.Format(time.RFC3339))
+	}
+	if token.CanRefresh() {
+		fmt.Fprintf(deps.Stdout, "Refresh: available")
+		if !token.RefreshExpiresAt.IsZero() {
+			fmt.Fprintf(deps.Stdout, " until %s", token.RefreshExpiresAt.Format(time.RFC3339))
+		}
+		fmt.Fprintln(deps.Stdout)
+	}
 	if len(token.Scopes) > 0 {
 		fmt.Fprintf(deps.Stdout, "Scopes:  %s\n", strings.Join(token.Scopes, ", "))
 	}
 
+	if *remote {
+		if err := printRemoteStatus(deps, token); err != nil {
+			return fmt.Errorf("auth status: %w", err)
+		}
+	}
+
+	// Identify the account behind the token when the services are available.
+	if requireAuth(deps, deps.Profile) == nil {
+		ctx, cancel := deps.context()
+		defer cancel()
+		if p, err := deps.Profile.Me(ctx); err == nil {
+			fmt.Fprintf(deps.Stdout, "Account: %s\n", identity(p.FirstName+" "+p.LastName, p.Email))
+		}
+	}
+
+	return nil
+}
+
+// printRemoteStatus introspects token at LinkedIn and prints what it reports.
+func printRemoteStatus(deps *Deps, token *config.Token) error {
+	ctx, cancel := deps.context()
+	defer cancel()
+
+	info, err := auth.NewAuthenticator(deps.Cfg, deps.HTTP).Introspect(ctx, token.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	remote := "inactive"
+	if info.Active {
+		remote = "active"
+	}
+	if info.Status != "" && info.Status != remote {
+		remote += " (" + info.Status + ")"
+	}
+	fmt.Fprintf(deps.Stdout, "Remote:  %s\n", remote)
+	if !info.ExpiresAt.IsZero() {
+		fmt.Fprintf(deps.Stdout, "Remote expiry: %s\n", info.ExpiresAt.Format(time.RFC3339))
+	}
+	if len(info.Scopes) > 0 {
+		fmt.Fprintf(deps.Stdout, "Remote scopes: %s\n", strings.Join(info.Scopes, ", "))
+	}
+	if info.ClientID != "" {
+		fmt.Fprintf(deps.Stdout, "App:     %s\n", info.ClientID)
+	}
 	return nil
 }
+
+// identity formats a display name and optional email address.
+func identity(name, email string) string {
+	name = strings.TrimSpace(name)
+	switch {
+	case email == "":
+		return name
+	case name == "":
+		return email
+	default:
+		return fmt.Sprintf("%s <%s>", name, email)
+	}
+}
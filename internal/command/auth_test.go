@@ -1,8 +1,16 @@
 package command
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/auth"
+	"github.com/Softorize/lcli/internal/config"
 )
 
 func TestAuthDispatchHelp(t *testing.T) {
@@ -38,3 +46,276 @@ func TestAuthDispatchHelpFlag(t *testing.T) {
 		}
 	}
 }
+
+func TestAuthRefreshNotAuthenticated(t *testing.T) {
+	isolateHome(t)
+	deps, _, _ := testDeps()
+
+	err := runAuth([]string{"refresh"}, deps)
+	if err == nil {
+		t.Fatal("expected error without stored token")
+	}
+	if !strings.Contains(err.Error(), "not authenticated") {
+		t.Errorf("error = %q", err)
+	}
+}
+
+func TestAuthListMarksActiveProfile(t *testing.T) {
+	isolateHome(t)
+	t.Setenv("LCLI_PROFILE", "")
+	deps, stdout, _ := testDeps()
+
+	if err := os.MkdirAll(config.ProfileDir("work"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := runAuth([]string{"switch", "work"}, deps); err != nil {
+		t.Fatalf("auth switch: %v", err)
+	}
+	if err := runAuth([]string{"list"}, deps); err != nil {
+		t.Fatalf("auth list: %v", err)
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, "*  work") {
+		t.Errorf("output does not mark work as active:\n%s", out)
+	}
+	if !strings.Contains(out, "default") {
+		t.Errorf("output missing default profile:\n%s", out)
+	}
+}
+
+func TestAuthListUnreadableStore(t *testing.T) {
+	isolateHome(t)
+	t.Setenv("LCLI_KEY", "passphrase")
+	t.Setenv("LCLI_PROFILE", "locked")
+	if err := config.Save(&config.Config{ClientID: "cid", CredentialStore: config.StoreEncrypted}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := config.SaveToken(&config.Token{AccessToken: "tok", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("SaveToken: %v", err)
+	}
+	t.Setenv("LCLI_KEY", "")
+	t.Setenv("LCLI_PROFILE", "")
+	deps, stdout, _ := testDeps()
+
+	if err := runAuth([]string{"list"}, deps); err != nil {
+		t.Fatalf("auth list: %v", err)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "locked") || !strings.Contains(out, "store unreadable: ") || !strings.Contains(out, "LCLI_KEY") {
+		t.Errorf("auth list should report the locked profile's store:\n%s", out)
+	}
+	if !strings.Contains(out, "default") {
+		t.Errorf("auth list should keep listing other profiles:\n%s", out)
+	}
+}
+
+func TestAuthSwitchUnknownProfile(t *testing.T) {
+	isolateHome(t)
+	deps, _, _ := testDeps()
+
+	err := runAuth([]string{"switch", "nope"}, deps)
+	if err == nil {
+		t.Fatal("expected error for unknown profile")
+	}
+	if !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("error = %q", err)
+	}
+}
+
+func TestAuthMigrateStoreValidatesDestination(t *testing.T) {
+	isolateHome(t)
+	deps, _, _ := testDeps()
+
+	if err := runAuth([]string{"migrate-store"}, deps); err == nil || !strings.Contains(err.Error(), "--to is required") {
+		t.Errorf("missing --to: err = %v", err)
+	}
+	if err := runAuth([]string{"migrate-store", "--to", "vault"}, deps); err == nil || !strings.Contains(err.Error(), "unknown credential store") {
+		t.Errorf("unknown store: err = %v", err)
+	}
+	if err := runAuth([]string{"migrate-store", "--to", "file"}, deps); err == nil || !strings.Contains(err.Error(), "already use") {
+		t.Errorf("same store: err = %v", err)
+	}
+}
+
+func TestAuthLoginManualRejectsStateMismatch(t *testing.T) {
+	isolateHome(t)
+	deps, _, stderr := testDeps()
+	deps.Cfg = &config.Config{ClientID: "cid", PKCE: true, RedirectURI: "http://localhost:8484/callback"}
+	deps.Stdin = strings.NewReader("http://localhost:8484/callback?code=abc&state=forged\n")
+
+	err := runAuth([]string{"login", "--manual"}, deps)
+	if err == nil || !strings.Contains(err.Error(), "state mismatch") {
+		t.Fatalf("err = %v, want state mismatch", err)
+	}
+	if !strings.Contains(stderr.String(), "code_challenge=") {
+		t.Errorf("authorization URL should carry a PKCE challenge:\n%s", stderr)
+	}
+}
+
+func TestAuthLoginManualBareCode(t *testing.T) {
+	var exchanged string
+	cfg := setupOAuthStub(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		exchanged = r.Form.Get("code")
+		w.Write([]byte(`{"access_token":"new","expires_in":3600}`))
+	})
+	cfg.RedirectURI = "http://localhost:8484/callback"
+
+	deps, _, stderr := testDeps()
+	deps.Cfg = cfg
+	deps.Stdin = strings.NewReader("abc-123\nn\n")
+	if err := runAuth([]string{"login", "--manual"}, deps); !errors.Is(err, auth.ErrBareCode) {
+		t.Fatalf("err = %v, want ErrBareCode when refused", err)
+	}
+	if !strings.Contains(stderr.String(), "Use it anyway? [y/N]") {
+		t.Errorf("expected a confirmation prompt:\n%s", stderr)
+	}
+
+	deps.Stdin = strings.NewReader("abc-123\ny\n")
+	if err := runAuth([]string{"login", "--manual"}, deps); err != nil {
+		t.Fatalf("auth login with a confirmed bare code: %v", err)
+	}
+	if exchanged != "abc-123" {
+		t.Errorf("exchanged code = %q, want abc-123", exchanged)
+	}
+}
+
+// setupOAuthStub stores a token and returns credentials whose OAuth
+// endpoints point at handler.
+func setupOAuthStub(t *testing.T, handler http.HandlerFunc) *config.Config {
+	t.Helper()
+	isolateHome(t)
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	if err := config.SaveToken(&config.Token{AccessToken: "tok", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("SaveToken: %v", err)
+	}
+	return &config.Config{ClientID: "cid", ClientSecret: "cs", OAuthBaseURL: srv.URL}
+}
+
+func TestAuthStatusRemote(t *testing.T) {
+	cfg := setupOAuthStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"active":false,"status":"revoked","client_id":"cid","scope":"openid","expires_at":1705184000}`))
+	})
+	deps, stdout, _ := testDeps()
+	deps.Cfg = cfg
+
+	if err := runAuth([]string{"status", "--remote"}, deps); err != nil {
+		t.Fatalf("auth status --remote: %v", err)
+	}
+	for _, want := range []string{"Remote:  inactive (revoked)", "Remote scopes: openid", "App:     cid"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestAuthLogoutRevoke(t *testing.T) {
+	var revoked string
+	cfg := setupOAuthStub(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		revoked = r.FormValue("token")
+	})
+	deps, _, _ := testDeps()
+	deps.Cfg = cfg
+
+	if err := runAuth([]string{"logout", "--revoke"}, deps); err != nil {
+		t.Fatalf("auth logout --revoke: %v", err)
+	}
+	if revoked != "tok" {
+		t.Errorf("revoked = %q, want tok", revoked)
+	}
+	if tok, _ := config.LoadToken(); tok != nil {
+		t.Error("token should be removed after revocation")
+	}
+}
+
+func TestAuthLogoutRevokeFailureKeepsToken(t *testing.T) {
+	cfg := setupOAuthStub(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	deps, _, _ := testDeps()
+	deps.Cfg = cfg
+
+	if err := runAuth([]string{"logout", "--revoke"}, deps); err == nil {
+		t.Fatal("expected revocation error")
+	}
+	if tok, _ := config.LoadToken(); tok == nil {
+		t.Error("token should be kept when revocation fails")
+	}
+}
+
+func TestAuthLoginClientCredentials(t *testing.T) {
+	cfg := setupOAuthStub(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if got := r.FormValue("grant_type"); got != "client_credentials" {
+			t.Errorf("grant_type = %q", got)
+		}
+		w.Write([]byte(`{"access_token":"app-tok","expires_in":1800}`))
+	})
+	deps, _, _ := testDeps()
+	deps.Cfg = cfg
+
+	if err := runAuth([]string{"login", "--client-credentials"}, deps); err != nil {
+		t.Fatalf("auth login --client-credentials: %v", err)
+	}
+	tok, err := config.LoadToken()
+	if err != nil || tok == nil || tok.AccessToken != "app-tok" || !tok.IsApp() {
+		t.Fatalf("stored token = %+v, %v", tok, err)
+	}
+
+	stdout := &strings.Builder{}
+	deps.Stdout = stdout
+	if err := runAuth([]string{"status"}, deps); err != nil {
+		t.Fatalf("auth status: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Type:    app") {
+		t.Errorf("status should report the app token type:\n%s", stdout)
+	}
+}
+
+func TestAuthRefreshAppToken(t *testing.T) {
+	cfg := setupOAuthStub(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if got := r.FormValue("grant_type"); got != "client_credentials" {
+			t.Errorf("grant_type = %q", got)
+		}
+		w.Write([]byte(`{"access_token":"app-tok-2","expires_in":1800}`))
+	})
+	if err := config.SaveToken(&config.Token{AccessToken: "app-tok", Type: config.TokenApp, ExpiresAt: time.Now().Add(time.Minute)}); err != nil {
+		t.Fatalf("SaveToken: %v", err)
+	}
+	deps, _, _ := testDeps()
+	deps.Cfg = cfg
+
+	if err := runAuth([]string{"refresh"}, deps); err != nil {
+		t.Fatalf("auth refresh: %v", err)
+	}
+	tok, err := config.LoadToken()
+	if err != nil || tok == nil || tok.AccessToken != "app-tok-2" || !tok.IsApp() {
+		t.Errorf("stored token = %+v, %v", tok, err)
+	}
+}
+
+func TestRequireAuthMemberOnlyWithAppToken(t *testing.T) {
+	deps, _, _ := testDeps()
+	deps.AppToken = true
+
+	err := runProfileMe(nil, deps)
+	if !errors.Is(err, errMemberOnly) {
+		t.Errorf("err = %v, want errMemberOnly", err)
+	}
+}
+
+// isolateHome points the configuration at a temporary home directory and
+// clears the environment overrides that would redirect it.
+func isolateHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	for _, env := range []string{"LCLI_CONFIG_DIR", "XDG_CONFIG_HOME", "LCLI_PROFILE", "LCLI_ACCESS_TOKEN"} {
+		t.Setenv(env, "")
+	}
+}
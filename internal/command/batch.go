@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// batchResult is the structured output of a command that fetched many
+// entities at once. Results keep the order of the keys; Errors lists the
+// keys that could not be fetched.
+type batchResult[T any] struct {
+	Results []T          `json:"results"`
+	Errors  []batchError `json:"errors,omitempty"`
+}
+
+// batchError is the failure to fetch one key.
+type batchError struct {
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+// batchFailure is returned when some keys of a batch could not be fetched.
+// It unwraps to the first failure, which decides the exit code.
+type batchFailure struct {
+	cmd    string
+	failed int
+	total  int
+	first  error
+}
+
+func (e *batchFailure) Error() string {
+	return fmt.Sprintf("%s: %d of %d could not be fetched", e.cmd, e.failed, e.total)
+}
+
+func (e *batchFailure) Unwrap() error { return e.first }
+
+// stdinArgs returns args, or the words read from stdin when args is the
+// single argument "-". Words are separated by white space, so keys can be
+// piped one per line.
+func stdinArgs(deps *Deps, args []string) ([]string, error) {
+	if len(args) != 1 || args[0] != "-" {
+		return args, nil
+	}
+	if deps.Stdin == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(deps.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("read stdin: %w", err)
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// collectBatch orders the results of b by keys, which may repeat, and
+// returns them with a *batchFailure if any key failed.
+func collectBatch[K comparable, T any](cmd string, keys []K, b *model.Batch[K, T], name func(K) string) (*batchResult[T], error) {
+	out := &batchResult[T]{Results: []T{}}
+	var failure *batchFailure
+	seen := make(map[K]bool)
+	for _, k := range keys {
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if r, ok := b.Results[k]; ok {
+			out.Results = append(out.Results, r)
+			continue
+		}
+		err := b.Errors[k]
+		if err == nil {
+			err = model.ErrNotFound
+		}
+		out.Errors = append(out.Errors, batchError{Key: name(k), Error: err.Error()})
+		if failure == nil {
+			failure = &batchFailure{cmd: cmd, first: err}
+		}
+		failure.failed++
+	}
+	if failure == nil {
+		return out, nil
+	}
+	failure.total = len(seen)
+	return out, failure
+}
+
+// printBatchErrors writes one line per failed key to stderr, for table
+// output, which has no room for them.
+func printBatchErrors(deps *Deps, cmd string, errs []batchError) {
+	for _, e := range errs {
+		fmt.Fprintf(deps.Stderr, "%s: %s: %s\n", cmd, e.Key, e.Error)
+	}
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/Softorize/lcli/internal/output"
+)
+
+// runCache dispatches to cache subcommands: stats, clear.
+func runCache(args []string, deps *Deps) error {
+	if len(args) == 0 {
+		printCacheUsage(deps)
+		return nil
+	}
+
+	switch args[0] {
+	case "stats":
+		return runCacheStats(args[1:], deps)
+	case "clear":
+		return runCacheClear(args[1:], deps)
+	case "-help", "--help", "-h":
+		printCacheUsage(deps)
+		return nil
+	default:
+		return usageErrorf("cache: unknown subcommand %q", args[0])
+	}
+}
+
+// printCacheUsage writes cache command help text.
+func printCacheUsage(deps *Deps) {
+	fmt.Fprint(deps.Stdout, `Usage: lcli cache <subcommand> [flags]
+
+Subcommands:
+  stats     Show cached responses per resource
+  clear     Remove all cached responses of the active profile
+
+API reads are cached per profile and revalidated with ETag and
+Last-Modified once stale. Use the global --no-cache flag to bypass the
+cache for one command.
+
+Use "lcli cache <subcommand> -help" for more information.
+`)
+}
+
+// errNoCache is returned when the response cache is not available.
+var errNoCache = errors.New("response cache is not configured")
+
+// runCacheStats handles the cache stats subcommand.
+func runCacheStats(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("cache stats", flag.ContinueOnError)
+	outputFmt := outputFlag(fs, deps)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+	if deps.Cache == nil {
+		return fmt.Errorf("cache stats: %w", errNoCache)
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
+
+	stats, err := deps.Cache.Stats(ctx)
+	if err != nil {
+		return fmt.Errorf("cache stats: %w", err)
+	}
+
+	printer, err := newPrinter(deps, *outputFmt)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format() != output.FormatTable {
+		return printer.Print(stats)
+	}
+
+	fmt.Fprintf(deps.Stderr, "Cache: %s\n", stats.Dir)
+	if stats.Entries == 0 {
+		fmt.Fprintln(deps.Stderr, "No cached responses.")
+		return nil
+	}
+	headers := []string{"Resource", "TTL", "Entries", "Fresh", "Size"}
+	rows := make([][]string, 0, len(stats.Resources)+1)
+	for _, r := range stats.Resources {
+		rows = append(rows, []string{r.Resource, r.TTL, strconv.Itoa(r.Entries), strconv.Itoa(r.Fresh), formatBytes(r.Bytes)})
+	}
+	rows = append(rows, []string{"total", "", strconv.Itoa(stats.Entries), strconv.Itoa(stats.Fresh), formatBytes(stats.Bytes)})
+	return printer.PrintTable(headers, rows)
+}
+
+// runCacheClear handles the cache clear subcommand.
+func runCacheClear(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("cache clear", flag.ContinueOnError)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+	if deps.Cache == nil {
+		return fmt.Errorf("cache clear: %w", errNoCache)
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
+
+	n, err := deps.Cache.Clear(ctx)
+	if err != nil {
+		return fmt.Errorf("cache clear: %w", err)
+	}
+	fmt.Fprintf(deps.Stderr, "Removed %d cached responses.\n", n)
+	return nil
+}
+
+// formatBytes formats a size in bytes with a binary unit, e.g. "12.3 KiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
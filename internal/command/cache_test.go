@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// mockCacheManager implements CacheManager for testing.
+type mockCacheManager struct {
+	stats   *model.CacheStats
+	cleared bool
+}
+
+func (m *mockCacheManager) Stats(ctx context.Context) (*model.CacheStats, error) {
+	return m.stats, nil
+}
+
+func (m *mockCacheManager) Clear(ctx context.Context) (int, error) {
+	m.cleared = true
+	return m.stats.Entries, nil
+}
+
+func TestCacheStatsTable(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Cache = &mockCacheManager{stats: &model.CacheStats{
+		Dir: "/tmp/cache", Entries: 2, Fresh: 1, Bytes: 2048,
+		Resources: []model.CacheResourceStats{
+			{Resource: "posts", TTL: "5m0s", Entries: 2, Fresh: 1, Bytes: 2048},
+		},
+	}}
+
+	if err := runCache([]string{"stats"}, deps); err != nil {
+		t.Fatalf("cache stats: %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{"Resource", "posts", "5m0s", "2.0 KiB", "total"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	deps, _, stderr := testDeps()
+	cache := &mockCacheManager{stats: &model.CacheStats{Entries: 3}}
+	deps.Cache = cache
+
+	if err := runCache([]string{"clear"}, deps); err != nil {
+		t.Fatalf("cache clear: %v", err)
+	}
+	if !cache.cleared || !strings.Contains(stderr.String(), "Removed 3 cached responses") {
+		t.Errorf("cleared = %v, stderr = %q", cache.cleared, stderr.String())
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KiB", 3 << 20: "3.0 MiB"}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -15,6 +15,7 @@ func testDeps() (*Deps, *bytes.Buffer, *bytes.Buffer) {
 	stderr := &bytes.Buffer{}
 	return &Deps{
 		Output: output.NewPrinter(stdout, output.FormatTable),
+		Stdin:  &bytes.Buffer{},
 		Stdout: stdout,
 		Stderr: stderr,
 	}, stdout, stderr
@@ -38,6 +39,8 @@ func (m *mockProfiler) GetByID(ctx context.Context, id string) (*model.Profile,
 type mockPoster struct {
 	createFunc      func(ctx context.Context, req *model.CreatePostRequest) (*model.Post, error)
 	getFunc         func(ctx context.Context, urn string) (*model.Post, error)
+	getManyFunc     func(ctx context.Context, urns []string) (*model.Batch[string, *model.Post], error)
+	updateFunc      func(ctx context.Context, urn string, req *model.UpdatePostRequest) error
 	deleteFunc      func(ctx context.Context, urn string) error
 	listByAuthorFunc func(ctx context.Context, authorURN string, start, count int) (*model.PostList, error)
 }
@@ -50,6 +53,14 @@ func (m *mockPoster) Get(ctx context.Context, urn string) (*model.Post, error) {
 	return m.getFunc(ctx, urn)
 }
 
+func (m *mockPoster) GetMany(ctx context.Context, urns []string) (*model.Batch[string, *model.Post], error) {
+	return m.getManyFunc(ctx, urns)
+}
+
+func (m *mockPoster) Update(ctx context.Context, urn string, req *model.UpdatePostRequest) error {
+	return m.updateFunc(ctx, urn, req)
+}
+
 func (m *mockPoster) Delete(ctx context.Context, urn string) error {
 	return m.deleteFunc(ctx, urn)
 }
@@ -118,6 +129,7 @@ func (m *mockMediaUploader) GetStatus(ctx context.Context, mediaURN string) (*mo
 // mockOrgReader implements OrgReader for testing.
 type mockOrgReader struct {
 	getFunc           func(ctx context.Context, id int64) (*model.Organization, error)
+	getManyFunc       func(ctx context.Context, ids []int64) (*model.Batch[int64, *model.Organization], error)
 	getByVanityFunc   func(ctx context.Context, vanityName string) (*model.Organization, error)
 	followerStatsFunc func(ctx context.Context, orgURN string) (*model.OrgFollowerStats, error)
 	pageStatsFunc     func(ctx context.Context, orgURN string) (*model.OrgPageStats, error)
@@ -127,6 +139,10 @@ func (m *mockOrgReader) Get(ctx context.Context, id int64) (*model.Organization,
 	return m.getFunc(ctx, id)
 }
 
+func (m *mockOrgReader) GetMany(ctx context.Context, ids []int64) (*model.Batch[int64, *model.Organization], error) {
+	return m.getManyFunc(ctx, ids)
+}
+
 func (m *mockOrgReader) GetByVanity(ctx context.Context, vanityName string) (*model.Organization, error) {
 	return m.getByVanityFunc(ctx, vanityName)
 }
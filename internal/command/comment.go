@@ -20,7 +20,7 @@ func runComment(args []string, deps *Deps) error {
 		printCommentUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("comment: unknown subcommand %q", args[0])
+		return usageErrorf("comment: unknown subcommand %q", args[0])
 	}
 }
 
@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 
@@ -15,22 +14,31 @@ func runCommentCreate(args []string, deps *Deps) error {
 	text := fs.String("text", "", "Comment text content (required)")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if *postURN == "" {
-		return fmt.Errorf("comment create: --post is required")
+		return usageErrorf("comment create: --post is required")
+	}
+	var err error
+	if *postURN, err = resolveEntity("comment create", *postURN); err != nil {
+		return err
 	}
 	if *text == "" {
-		return fmt.Errorf("comment create: --text is required")
+		return usageErrorf("comment create: --text is required")
 	}
 
-	if err := requireAuth(deps.Comments); err != nil {
+	if err := requireAuth(deps, deps.Comments, scopeMemberSocial); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	if dryRun(deps, "comment on %s with text %q", *postURN, truncate(*text, 60)) {
+		return nil
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
 	req := &model.CreateCommentRequest{
 		PostURN: *postURN,
 		Text:    *text,
@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 
@@ -14,22 +13,27 @@ func runCommentList(args []string, deps *Deps) error {
 	postURN := fs.String("post", "", "Post URN to list comments for (required)")
 	count := fs.Int("count", 10, "Number of comments to retrieve")
 	start := fs.Int("start", 0, "Pagination start index")
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if *postURN == "" {
-		return fmt.Errorf("comment list: --post is required")
+		return usageErrorf("comment list: --post is required")
+	}
+	var err error
+	if *postURN, err = resolveEntity("comment list", *postURN); err != nil {
+		return err
 	}
 
-	if err := requireAuth(deps.Comments); err != nil {
+	if err := requireAuth(deps, deps.Comments, scopeSocialRead); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 	list, err := deps.Comments.List(ctx, *postURN, *start, *count)
 	if err != nil {
 		return fmt.Errorf("comment list: %w", err)
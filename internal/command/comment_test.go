@@ -50,7 +50,7 @@ func TestCommentCreateMissingText(t *testing.T) {
 	deps, _, _ := testDeps()
 	deps.Comments = &mockCommenter{}
 
-	err := runCommentCreate([]string{"--post", "urn"}, deps)
+	err := runCommentCreate([]string{"--post", "urn:li:share:123"}, deps)
 	if err == nil {
 		t.Fatal("expected error for missing --text")
 	}
@@ -59,7 +59,7 @@ func TestCommentCreateMissingText(t *testing.T) {
 func TestCommentCreateNotAuthenticated(t *testing.T) {
 	deps, _, _ := testDeps()
 
-	err := runCommentCreate([]string{"--post", "urn", "--text", "hi"}, deps)
+	err := runCommentCreate([]string{"--post", "urn:li:share:123", "--text", "hi"}, deps)
 	if err == nil {
 		t.Fatal("expected auth error")
 	}
@@ -106,7 +106,7 @@ func TestCommentDeleteWithConfirm(t *testing.T) {
 		},
 	}
 
-	if err := runCommentDelete([]string{"--confirm", "urn:li:comment:456"}, deps); err != nil {
+	if err := runCommentDelete([]string{"--confirm", "urn:li:comment:(urn:li:share:123,456)"}, deps); err != nil {
 		t.Fatalf("runCommentDelete: %v", err)
 	}
 
@@ -121,7 +121,7 @@ func TestCommentDeleteWithConfirm(t *testing.T) {
 func TestCommentDeleteWithoutConfirm(t *testing.T) {
 	deps, _, stderr := testDeps()
 
-	if err := runCommentDelete([]string{"urn:li:comment:456"}, deps); err != nil {
+	if err := runCommentDelete([]string{"urn:li:comment:(urn:li:share:123,456)"}, deps); err != nil {
 		t.Fatalf("runCommentDelete: %v", err)
 	}
 
@@ -138,7 +138,7 @@ func TestCommentDeleteServiceError(t *testing.T) {
 		},
 	}
 
-	err := runCommentDelete([]string{"--confirm", "urn"}, deps)
+	err := runCommentDelete([]string{"--confirm", "urn:li:comment:(urn:li:share:123,456)"}, deps)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -1,6 +1,11 @@
 package command
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Softorize/lcli/internal/config"
+)
 
 // runCompletion handles the completion subcommand.
 func runCompletion(args []string, deps *Deps) error {
@@ -11,16 +16,16 @@ func runCompletion(args []string, deps *Deps) error {
 
 	switch args[0] {
 	case "bash":
-		fmt.Fprint(deps.Stdout, bashCompletion)
+		fmt.Fprint(deps.Stdout, withConfigKeys(bashCompletion))
 		return nil
 	case "zsh":
-		fmt.Fprint(deps.Stdout, zshCompletion)
+		fmt.Fprint(deps.Stdout, withConfigKeys(zshCompletion))
 		return nil
 	case "-help", "--help", "-h":
 		printCompletionUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("completion: unsupported shell %q (use bash or zsh)", args[0])
+		return usageErrorf("completion: unsupported shell %q (use bash or zsh)", args[0])
 	}
 }
 
@@ -38,6 +43,18 @@ Install:
 `)
 }
 
+// configKeysPlaceholder marks where completion scripts list config keys.
+const configKeysPlaceholder = "__CONFIG_KEYS__"
+
+// withConfigKeys fills the config keys into a completion script.
+func withConfigKeys(script string) string {
+	keys := make([]string, 0, len(config.Fields()))
+	for _, f := range config.Fields() {
+		keys = append(keys, f.Key)
+	}
+	return strings.ReplaceAll(script, configKeysPlaceholder, strings.Join(keys, " "))
+}
+
 const bashCompletion = `# bash completion for lcli -*- shell-script -*-
 
 _lcli() {
@@ -46,7 +63,7 @@ _lcli() {
     cur="${COMP_WORDS[COMP_CWORD]}"
     prev="${COMP_WORDS[COMP_CWORD-1]}"
 
-    commands="auth config profile post comment reaction media org analytics completion version help"
+    commands="auth config profile post comment reaction media org analytics ratelimit cache dev completion version help"
 
     case "${prev}" in
         lcli)
@@ -54,11 +71,17 @@ _lcli() {
             return 0
             ;;
         auth)
-            COMPREPLY=( $(compgen -W "login logout status" -- "${cur}") )
+            COMPREPLY=( $(compgen -W "login logout status refresh list switch migrate-store" -- "${cur}") )
             return 0
             ;;
         config)
-            COMPREPLY=( $(compgen -W "setup" -- "${cur}") )
+            COMPREPLY=( $(compgen -W "setup show get set unset list edit" -- "${cur}") )
+            return 0
+            ;;
+        get|set|unset)
+            if [[ "${COMP_WORDS[1]}" == "config" ]]; then
+                COMPREPLY=( $(compgen -W "__CONFIG_KEYS__" -- "${cur}") )
+            fi
             return 0
             ;;
         profile)
@@ -66,7 +89,7 @@ _lcli() {
             return 0
             ;;
         post)
-            COMPREPLY=( $(compgen -W "create list get delete" -- "${cur}") )
+            COMPREPLY=( $(compgen -W "create list get edit reshare delete" -- "${cur}") )
             return 0
             ;;
         comment)
@@ -89,6 +112,18 @@ _lcli() {
             COMPREPLY=( $(compgen -W "post views" -- "${cur}") )
             return 0
             ;;
+        ratelimit)
+            COMPREPLY=( $(compgen -W "status" -- "${cur}") )
+            return 0
+            ;;
+        cache)
+            COMPREPLY=( $(compgen -W "stats clear" -- "${cur}") )
+            return 0
+            ;;
+        dev)
+            COMPREPLY=( $(compgen -W "fake-server" -- "${cur}") )
+            return 0
+            ;;
         completion)
             COMPREPLY=( $(compgen -W "bash zsh" -- "${cur}") )
             return 0
@@ -113,6 +148,9 @@ _lcli() {
         'media:Upload images and videos'
         'org:Manage organization pages'
         'analytics:View post and profile analytics'
+        'ratelimit:Show API rate limits'
+        'cache:Inspect and clear the response cache'
+        'dev:Developer tools'
         'completion:Generate shell completions'
         'version:Print version information'
         'help:Show usage information'
@@ -129,16 +167,20 @@ _lcli() {
         args)
             case $words[1] in
                 auth)
-                    _values 'subcommand' 'login[Authenticate via OAuth]' 'logout[Remove stored credentials]' 'status[Show auth status]'
+                    _values 'subcommand' 'login[Authenticate via OAuth]' 'logout[Remove stored credentials]' 'status[Show auth status]' 'refresh[Renew the access token]' 'list[List profiles]' 'switch[Switch the current profile]' 'migrate-store[Move credentials to another store]'
                     ;;
                 config)
-                    _values 'subcommand' 'setup[Configure credentials]'
+                    if (( CURRENT == 2 )); then
+                        _values 'subcommand' 'setup[Configure credentials]' 'show[Show the effective configuration]' 'get[Print a setting]' 'set[Store a setting]' 'unset[Reset a setting]' 'list[List settings]' 'edit[Edit config.json]'
+                    elif [[ $words[2] == (get|set|unset) ]] && (( CURRENT == 3 )); then
+                        _values 'key' __CONFIG_KEYS__
+                    fi
                     ;;
                 profile)
                     _values 'subcommand' 'me[Show your profile]' 'view[View another profile]'
                     ;;
                 post)
-                    _values 'subcommand' 'create[Create a new post]' 'list[List recent posts]' 'get[Get a single post]' 'delete[Delete a post]'
+                    _values 'subcommand' 'create[Create a new post]' 'list[List recent posts]' 'get[Get posts]' 'edit[Edit a post]' 'reshare[Reshare a post]' 'delete[Delete a post]'
                     ;;
                 comment)
                     _values 'subcommand' 'create[Add a comment]' 'list[List comments]' 'delete[Delete a comment]'
@@ -155,6 +197,15 @@ _lcli() {
                 analytics)
                     _values 'subcommand' 'post[View post analytics]' 'views[View profile views]'
                     ;;
+                ratelimit)
+                    _values 'subcommand' 'status[Show remaining quota]'
+                    ;;
+                cache)
+                    _values 'subcommand' 'stats[Show cached responses]' 'clear[Remove cached responses]'
+                    ;;
+                dev)
+                    _values 'subcommand' 'fake-server[Run a fake LinkedIn API]'
+                    ;;
                 completion)
                     _values 'shell' 'bash[Generate bash completions]' 'zsh[Generate zsh completions]'
                     ;;
@@ -59,3 +59,16 @@ func TestCompletionHelp(t *testing.T) {
 		t.Error("help output missing 'bash'")
 	}
 }
+
+func TestCompletionListsConfigKeys(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh"} {
+		deps, stdout, _ := testDeps()
+		if err := runCompletion([]string{shell}, deps); err != nil {
+			t.Fatalf("completion %s: %v", shell, err)
+		}
+		out := stdout.String()
+		if strings.Contains(out, configKeysPlaceholder) || !strings.Contains(out, "api_version") {
+			t.Errorf("%s completion should list config keys", shell)
+		}
+	}
+}
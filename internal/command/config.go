@@ -7,7 +7,8 @@ import (
 	"github.com/Softorize/lcli/internal/config"
 )
 
-// runConfig dispatches to config subcommands: setup.
+// runConfig dispatches to config subcommands: setup, show, get, set, unset,
+// list, edit.
 func runConfig(args []string, deps *Deps) error {
 	if len(args) == 0 {
 		printConfigUsage(deps)
@@ -17,11 +18,23 @@ func runConfig(args []string, deps *Deps) error {
 	switch args[0] {
 	case "setup":
 		return runConfigSetup(args[1:], deps)
+	case "show":
+		return runConfigShow(args[1:], deps)
+	case "get":
+		return runConfigGet(args[1:], deps)
+	case "set":
+		return runConfigSet(args[1:], deps)
+	case "unset":
+		return runConfigUnset(args[1:], deps)
+	case "list":
+		return runConfigList(args[1:], deps)
+	case "edit":
+		return runConfigEdit(args[1:], deps)
 	case "-help", "--help", "-h":
 		printConfigUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("config: unknown subcommand %q", args[0])
+		return usageErrorf("config: unknown subcommand %q", args[0])
 	}
 }
 
@@ -31,6 +44,12 @@ func printConfigUsage(deps *Deps) {
 
 Subcommands:
   setup     Configure LinkedIn app credentials
+  show      Show the effective configuration (--sources for origins)
+  get       Print a setting from config.json
+  set       Validate and store a setting (lcli config set api_version 202604)
+  unset     Reset a setting to its default
+  list      List all settings stored in config.json
+  edit      Open config.json in $EDITOR and validate the result
 
 Use "lcli config <subcommand> -help" for more information.
 `)
@@ -40,27 +59,29 @@ Use "lcli config <subcommand> -help" for more information.
 func runConfigSetup(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("config setup", flag.ContinueOnError)
 	clientID := fs.String("client-id", "", "LinkedIn app client ID (required)")
-	clientSecret := fs.String("client-secret", "", "LinkedIn app client secret (required)")
+	clientSecret := fs.String("client-secret", "", "LinkedIn app client secret (required unless --pkce)")
+	pkce := fs.Bool("pkce", false, "Native client: log in with PKCE instead of a client secret")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if *clientID == "" {
-		return fmt.Errorf("config setup: --client-id is required")
+		return usageErrorf("config setup: --client-id is required")
 	}
-	if *clientSecret == "" {
-		return fmt.Errorf("config setup: --client-secret is required")
+	if *clientSecret == "" && !*pkce {
+		return usageErrorf("config setup: --client-secret is required (or use --pkce for a native client)")
 	}
 
-	cfg, err := config.Load()
+	cfg, err := config.LoadFile()
 	if err != nil {
 		return fmt.Errorf("config setup: %w", err)
 	}
 
 	cfg.ClientID = *clientID
 	cfg.ClientSecret = *clientSecret
+	cfg.PKCE = *pkce
 
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("config setup: %w", err)
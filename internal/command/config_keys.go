@@ -0,0 +1,196 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Softorize/lcli/internal/config"
+	"github.com/Softorize/lcli/internal/output"
+)
+
+// lookupConfigKey resolves a config key given on the command line.
+func lookupConfigKey(cmd, key string) (config.Field, error) {
+	f, ok := config.LookupField(key)
+	if !ok {
+		keys := make([]string, 0, len(config.Fields()))
+		for _, f := range config.Fields() {
+			keys = append(keys, f.Key)
+		}
+		return config.Field{}, usageErrorf("config %s: unknown key %q (known keys: %s)", cmd, key, strings.Join(keys, ", "))
+	}
+	return f, nil
+}
+
+// runConfigGet handles the config get subcommand.
+func runConfigGet(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("config get", flag.ContinueOnError)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("config get: exactly one key argument is required")
+	}
+
+	f, err := lookupConfigKey("get", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("config get: %w", err)
+	}
+
+	fmt.Fprintln(deps.Stdout, f.Get(cfg))
+	return nil
+}
+
+// runConfigSet handles the config set subcommand.
+func runConfigSet(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("config set", flag.ContinueOnError)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usageErrorf("config set: key and value arguments are required")
+	}
+
+	f, err := lookupConfigKey("set", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	value := fs.Arg(1)
+
+	if f.Key == "credential_store" {
+		return fmt.Errorf("config set: %w", config.ErrStoreChange)
+	}
+	if err := f.Validate(value); err != nil {
+		return fmt.Errorf("config set: %w", err)
+	}
+
+	return updateConfig("set", deps, f, value)
+}
+
+// runConfigUnset handles the config unset subcommand.
+func runConfigUnset(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("config unset", flag.ContinueOnError)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("config unset: exactly one key argument is required")
+	}
+
+	f, err := lookupConfigKey("unset", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if f.Key == "credential_store" {
+		return fmt.Errorf("config unset: %w", config.ErrStoreChange)
+	}
+
+	return updateConfig("unset", deps, f, f.Default())
+}
+
+// updateConfig stores value for f in the active profile's config.json.
+func updateConfig(cmd string, deps *Deps, f config.Field, value string) error {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("config %s: %w", cmd, err)
+	}
+	if err := f.Set(cfg, value); err != nil {
+		return fmt.Errorf("config %s: %w", cmd, err)
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("config %s: %w", cmd, err)
+	}
+
+	shown := value
+	if f.Secret {
+		shown = config.Redact(value)
+	}
+	fmt.Fprintf(deps.Stderr, "%s = %q\n", f.Key, shown)
+	return nil
+}
+
+// runConfigList handles the config list subcommand.
+func runConfigList(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("config list", flag.ContinueOnError)
+	outputFmt := outputFlag(fs, deps)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("config list: %w", err)
+	}
+
+	values := make(map[string]string, len(config.Fields()))
+	rows := make([][]string, 0, len(config.Fields()))
+	for _, f := range config.Fields() {
+		v := f.Get(cfg)
+		if f.Secret {
+			v = config.Redact(v)
+		}
+		values[f.Key] = v
+		rows = append(rows, []string{f.Key, v, f.Usage})
+	}
+
+	printer, err := newPrinter(deps, *outputFmt)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format() == output.FormatTable {
+		return printer.PrintTable([]string{"Key", "Value", "Description"}, rows)
+	}
+	return printer.Print(values)
+}
+
+// runConfigEdit handles the config edit subcommand.
+func runConfigEdit(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("config edit", flag.ContinueOnError)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	err := config.Edit(func(path string) error {
+		argv := append(strings.Fields(editor), path)
+		cmd := exec.Command(argv[0], argv[1:]...)
+		cmd.Stdin = deps.Stdin
+		cmd.Stdout = deps.Stdout
+		cmd.Stderr = deps.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("run editor %q: %w", editor, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("config edit: %w", err)
+	}
+
+	fmt.Fprintf(deps.Stderr, "Configuration saved to %s\n", config.ConfigDir())
+	return nil
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Softorize/lcli/internal/config"
+	"github.com/Softorize/lcli/internal/output"
+)
+
+// configEntry describes one effective setting for config show output.
+type configEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source,omitempty"`
+	Env    string `json:"env,omitempty"`
+}
+
+// runConfigShow handles the config show subcommand.
+func runConfigShow(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("config show", flag.ContinueOnError)
+	sources := fs.Bool("sources", false, "Show where each value came from (default, file, env, flag)")
+	outputFmt := outputFlag(fs, deps)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+
+	cfg, origins, err := config.LoadWithSources()
+	if err != nil {
+		return fmt.Errorf("config show: %w", err)
+	}
+
+	entries := make([]configEntry, 0, len(config.Fields())+2)
+	for _, f := range config.Fields() {
+		value := f.Get(cfg)
+		if f.Secret {
+			value = config.Redact(value)
+		}
+		entries = append(entries, configEntry{Key: f.Key, Value: value, Source: string(origins[f.Key]), Env: f.Env})
+	}
+
+	dir, dirSource := config.ConfigDirSource()
+	entries = append(entries, configEntry{Key: "config_dir", Value: dir, Source: string(dirSource), Env: "LCLI_CONFIG_DIR"})
+
+	if config.TokenFromEnv() {
+		entries = append(entries, configEntry{Key: "access_token", Value: config.Redact("set"), Source: string(config.SourceEnv), Env: "LCLI_ACCESS_TOKEN"})
+	}
+
+	if !*sources {
+		for i := range entries {
+			entries[i].Source = ""
+			entries[i].Env = ""
+		}
+	}
+
+	printer, err := newPrinter(deps, *outputFmt)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format() == output.FormatTable {
+		headers := []string{"Key", "Value"}
+		if *sources {
+			headers = append(headers, "Source", "Env")
+		}
+		rows := make([][]string, 0, len(entries))
+		for _, e := range entries {
+			row := []string{e.Key, e.Value}
+			if *sources {
+				row = append(row, e.Source, e.Env)
+			}
+			rows = append(rows, row)
+		}
+		return printer.PrintTable(headers, rows)
+	}
+
+	return printer.Print(entries)
+}
@@ -0,0 +1,139 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/config"
+)
+
+func TestConfigShowSourcesRedactsSecrets(t *testing.T) {
+	isolateHome(t)
+	for _, f := range config.Fields() {
+		t.Setenv(f.Env, "")
+	}
+	if err := config.Save(&config.Config{ClientID: "file-id", ClientSecret: "top-secret"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	version := time.Now().Format("200601")
+	t.Setenv("LCLI_API_VERSION", version)
+
+	deps, stdout, _ := testDeps()
+	if err := runConfig([]string{"show", "--sources"}, deps); err != nil {
+		t.Fatalf("config show: %v", err)
+	}
+
+	out := stdout.String()
+	if strings.Contains(out, "top-secret") {
+		t.Errorf("client secret not redacted:\n%s", out)
+	}
+	for _, want := range []string{"file-id", version, "env", "LCLI_API_VERSION", "file"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestConfigSetupIgnoresEnvOverrides(t *testing.T) {
+	isolateHome(t)
+	t.Setenv("LCLI_API_VERSION", "202604")
+
+	deps, _, _ := testDeps()
+	if err := runConfig([]string{"setup", "--client-id", "id", "--client-secret", "s"}, deps); err != nil {
+		t.Fatalf("config setup: %v", err)
+	}
+
+	stored, err := config.LoadFile()
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if stored.APIVersion == "202604" {
+		t.Error("config setup persisted an environment override")
+	}
+}
+
+func TestConfigSetGetUnset(t *testing.T) {
+	isolateHome(t)
+
+	deps, stdout, _ := testDeps()
+	if err := runConfig([]string{"set", "max_retries", "7"}, deps); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	if err := runConfig([]string{"get", "max_retries"}, deps); err != nil {
+		t.Fatalf("config get: %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "7" {
+		t.Errorf("config get = %q, want 7", got)
+	}
+
+	if err := runConfig([]string{"unset", "max_retries"}, deps); err != nil {
+		t.Fatalf("config unset: %v", err)
+	}
+	cfg, err := config.LoadFile()
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if cfg.MaxRetries != 3 {
+		t.Errorf("MaxRetries after unset = %d, want default 3", cfg.MaxRetries)
+	}
+}
+
+func TestConfigSetValidates(t *testing.T) {
+	isolateHome(t)
+	deps, _, _ := testDeps()
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"set", "api_version", "2026-01"}, "YYYYMM"},
+		{[]string{"set", "redirect_uri", "http://localhost/callback"}, "no port"},
+		{[]string{"set", "retry_post", "sometimes"}, "invalid boolean"},
+		{[]string{"set", "nope", "x"}, "unknown key"},
+		{[]string{"set", "credential_store", "encrypted"}, "migrate-store"},
+		{[]string{"get"}, "key argument"},
+	}
+	for _, tt := range tests {
+		err := runConfig(tt.args, deps)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: err = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestConfigListRedactsSecret(t *testing.T) {
+	isolateHome(t)
+	if err := config.Save(&config.Config{ClientID: "id", ClientSecret: "top-secret"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	deps, stdout, _ := testDeps()
+	if err := runConfig([]string{"list"}, deps); err != nil {
+		t.Fatalf("config list: %v", err)
+	}
+	if strings.Contains(stdout.String(), "top-secret") {
+		t.Errorf("client secret not redacted:\n%s", stdout)
+	}
+}
+
+func TestCallbackPort(t *testing.T) {
+	tests := []struct {
+		uri     string
+		flag    int
+		want    int
+		wantErr bool
+	}{
+		{"http://localhost:9090/callback", 0, 9090, false},
+		{"http://localhost:9090/callback", 9090, 9090, false},
+		{"http://localhost:9090/callback", 8484, 0, true},
+		{"https://example.com/oauth", 0, defaultCallbackPort, false},
+		{"https://example.com/oauth", 7000, 7000, false},
+	}
+	for _, tt := range tests {
+		got, err := callbackPort(tt.uri, tt.flag)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("callbackPort(%q, %d) = %d, %v", tt.uri, tt.flag, got, err)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package command
 
 import (
 	"context"
+	"errors"
 	"io"
+	"net/http"
 	"reflect"
 
 	"github.com/Softorize/lcli/internal/config"
@@ -21,6 +23,8 @@ type Profiler interface {
 type Poster interface {
 	Create(ctx context.Context, req *model.CreatePostRequest) (*model.Post, error)
 	Get(ctx context.Context, urn string) (*model.Post, error)
+	GetMany(ctx context.Context, urns []string) (*model.Batch[string, *model.Post], error)
+	Update(ctx context.Context, urn string, req *model.UpdatePostRequest) error
 	Delete(ctx context.Context, urn string) error
 	ListByAuthor(ctx context.Context, authorURN string, start, count int) (*model.PostList, error)
 }
@@ -49,6 +53,7 @@ type MediaUploader interface {
 // OrgReader retrieves organization data and statistics.
 type OrgReader interface {
 	Get(ctx context.Context, id int64) (*model.Organization, error)
+	GetMany(ctx context.Context, ids []int64) (*model.Batch[int64, *model.Organization], error)
 	GetByVanity(ctx context.Context, vanityName string) (*model.Organization, error)
 	FollowerStats(ctx context.Context, orgURN string) (*model.OrgFollowerStats, error)
 	PageStats(ctx context.Context, orgURN string) (*model.OrgPageStats, error)
@@ -60,6 +65,17 @@ type AnalyticsReader interface {
 	ProfileViews(ctx context.Context) (int, error)
 }
 
+// QuotaReader reports the rate limit state shared by lcli processes.
+type QuotaReader interface {
+	Quotas(ctx context.Context) ([]model.Quota, error)
+}
+
+// CacheManager inspects and clears the API response cache.
+type CacheManager interface {
+	Stats(ctx context.Context) (*model.CacheStats, error)
+	Clear(ctx context.Context) (int, error)
+}
+
 // Deps holds injected dependencies for all commands.
 type Deps struct {
 	// Cfg provides access to application configuration.
@@ -78,23 +94,89 @@ type Deps struct {
 	Orgs OrgReader
 	// Analytics provides access to LinkedIn analytics endpoints.
 	Analytics AnalyticsReader
+	// RateLimits reports the client-side rate limits and observed quotas.
+	// Unlike the API services it is set without a token.
+	RateLimits QuotaReader
+	// Cache manages the API response cache. Like RateLimits it is set
+	// without a token, and also with --no-cache.
+	Cache CacheManager
+	// HTTP sends the requests that bypass the API client, such as OAuth
+	// calls, over the configured proxy and TLS settings. A nil HTTP means
+	// http.DefaultClient.
+	HTTP *http.Client
+	// AppToken reports that the stored token is an application token from
+	// the client-credentials grant. Member-only services such as Profile
+	// are left nil.
+	AppToken bool
+	// Scopes lists the OAuth scopes granted to the stored token. It is empty
+	// when the token did not report them.
+	Scopes []string
+	// Ctx is the root context, cancelled when the user interrupts lcli. A
+	// nil Ctx means context.Background().
+	Ctx context.Context
+	// Global holds the global flags parsed by Run.
+	Global Globals
+	// Init, if set, is called by Run after the global flags are parsed and
+	// before dispatch. It loads the configuration and services, which depend
+	// on flags such as --profile and --api-version.
+	Init func(deps *Deps) error
+	// Closers are resources Init opened for the whole run, such as the
+	// --trace-file. The caller releases them with Close once Run returns.
+	Closers []io.Closer
 	// Output is the configured printer for structured results.
 	Output *output.Printer
+	// Stdin is the reader for interactive input and piped arguments.
+	Stdin io.Reader
 	// Stdout is the writer for command results.
 	Stdout io.Writer
 	// Stderr is the writer for progress messages and errors.
 	Stderr io.Writer
+	// Prompt is the writer for questions and instructions the user must
+	// see to go on, such as a confirmation prompt. Run keeps it on the
+	// original stderr when --quiet discards Stderr; if nil, Stderr is used.
+	Prompt io.Writer
+}
+
+// Close closes the Closers, newest first, and returns their errors.
+func (d *Deps) Close() error {
+	var errs []error
+	for i := len(d.Closers) - 1; i >= 0; i-- {
+		errs = append(errs, d.Closers[i].Close())
+	}
+	d.Closers = nil
+	return errors.Join(errs...)
+}
+
+// prompt returns the writer for interactive prompts.
+func (d *Deps) prompt() io.Writer {
+	if d.Prompt != nil {
+		return d.Prompt
+	}
+	return d.Stderr
 }
 
 // requireAuth returns an error if the given service pointer is nil,
-// indicating the user has not authenticated yet.
-func requireAuth(svc any) error {
-	if svc == nil {
+// indicating the user has not authenticated yet, or if the stored token
+// lacks any of the scopes the command declares. It runs before any network
+// call so a missing scope is reported instead of an opaque 403.
+func requireAuth(deps *Deps, svc any, scopes ...string) error {
+	if isNil(svc) {
+		if deps.AppToken {
+			return errMemberOnly
+		}
 		return errNotAuthenticated
 	}
-	v := reflect.ValueOf(svc)
-	if v.Kind() == reflect.Ptr && v.IsNil() {
-		return errNotAuthenticated
+	if missing := missingScopes(deps.Scopes, scopes); len(missing) > 0 {
+		return &scopeError{missing: missing}
 	}
 	return nil
 }
+
+// isNil reports whether svc is nil or a nil pointer wrapped in an interface.
+func isNil(svc any) bool {
+	if svc == nil {
+		return true
+	}
+	v := reflect.ValueOf(svc)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Softorize/lcli/internal/linkedintest"
+)
+
+// defaultFakeServerPort is the port lcli dev fake-server listens on unless
+// --port is given.
+const defaultFakeServerPort = 8585
+
+// runDev dispatches to dev subcommands: fake-server.
+func runDev(args []string, deps *Deps) error {
+	if len(args) == 0 {
+		printDevUsage(deps)
+		return nil
+	}
+
+	switch args[0] {
+	case "fake-server":
+		return runDevFakeServer(args[1:], deps)
+	case "-help", "--help", "-h":
+		printDevUsage(deps)
+		return nil
+	default:
+		return usageErrorf("dev: unknown subcommand %q", args[0])
+	}
+}
+
+// printDevUsage writes dev command help text.
+func printDevUsage(deps *Deps) {
+	fmt.Fprint(deps.Stdout, `Usage: lcli dev <subcommand> [flags]
+
+Subcommands:
+  fake-server   Run an in-memory fake of the LinkedIn API for local testing
+
+Use "lcli dev <subcommand> -help" for more information.
+`)
+}
+
+// runDevFakeServer handles the dev fake-server subcommand. It prints the
+// environment that points lcli at the fake, then serves until interrupted.
+func runDevFakeServer(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("dev fake-server", flag.ContinueOnError)
+	port := fs.Int("port", defaultFakeServerPort, "Local port to listen on (0 picks a free port)")
+	rateLimit := fs.Int("rate-limit", 0, "Allow only N API calls per minute, then answer 429 (0 means no limit)")
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(*port)))
+	if err != nil {
+		return fmt.Errorf("dev fake-server: %w", err)
+	}
+
+	fake := linkedintest.New()
+	if *rateLimit > 0 {
+		fake.SetRateLimit(*rateLimit, time.Minute)
+	}
+	srv := &http.Server{Handler: fake, ReadHeaderTimeout: 10 * time.Second}
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ln) }()
+
+	base := "http://" + ln.Addr().String()
+	fmt.Fprintf(deps.Stdout, `export LCLI_API_BASE_URL=%s
+export LCLI_OAUTH_BASE_URL=%s
+export LCLI_USERINFO_URL=%s
+export LCLI_ACCESS_TOKEN=%s
+`, base+linkedintest.RESTPath, base+linkedintest.OAuthPath, base+linkedintest.UserinfoPath, linkedintest.Token)
+	fmt.Fprintf(deps.Stderr, "Fake LinkedIn API listening on %s. Press Ctrl-C to stop.\n", base)
+
+	select {
+	case <-deps.rootContext().Done():
+	case err := <-served:
+		return fmt.Errorf("dev fake-server: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("dev fake-server: %w", err)
+	}
+	return nil
+}
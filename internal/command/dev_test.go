@@ -0,0 +1,43 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDevFakeServerPrintsEnvironment(t *testing.T) {
+	// A cancelled context makes the server stop as soon as it has started.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	deps, stdout, stderr := testDeps()
+	deps.Ctx = ctx
+
+	if err := runDevFakeServer([]string{"--port", "0"}, deps); err != nil {
+		t.Fatalf("runDevFakeServer: %v", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"export LCLI_API_BASE_URL=http://127.0.0.1:",
+		"/rest\n",
+		"/oauth/v2\n",
+		"/v2/userinfo\n",
+		"export LCLI_ACCESS_TOKEN=fake-token\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stdout missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(stderr.String(), "listening on") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestDevUnknownSubcommand(t *testing.T) {
+	deps, _, _ := testDeps()
+	if err := runDev([]string{"nope"}, deps); err == nil {
+		t.Fatal("expected error")
+	}
+}
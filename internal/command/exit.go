@@ -0,0 +1,156 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// Exit codes returned by ExitCode. They are part of lcli's interface and
+// documented in the README, so scripts can branch on them; do not renumber.
+// Timeouts and interrupts get the codes used by timeout(1) and shells.
+const (
+	ExitFailure         = 1
+	ExitUsage           = 2
+	ExitUnauthenticated = 3
+	ExitForbidden       = 4
+	ExitNotFound        = 5
+	ExitRateLimited     = 6
+	ExitServer          = 7
+	ExitTimeout         = 124
+	ExitCanceled        = 130 // 128 + SIGINT
+)
+
+// errorCodes names each exit code in JSON error output.
+var errorCodes = map[int]string{
+	ExitFailure:         "error",
+	ExitUsage:           "usage",
+	ExitUnauthenticated: "unauthenticated",
+	ExitForbidden:       "forbidden",
+	ExitNotFound:        "not_found",
+	ExitRateLimited:     "rate_limited",
+	ExitServer:          "server_error",
+	ExitTimeout:         "timeout",
+	ExitCanceled:        "canceled",
+}
+
+// ExitCode returns the process exit status for an error returned by Run.
+func ExitCode(err error) int {
+	var usage *usageError
+	var scopes *scopeError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, context.Canceled):
+		return ExitCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitTimeout
+	case errors.As(err, &usage):
+		return ExitUsage
+	case errors.Is(err, errNotAuthenticated), errors.Is(err, model.ErrUnauthorized):
+		return ExitUnauthenticated
+	case errors.Is(err, errMemberOnly), errors.As(err, &scopes), errors.Is(err, model.ErrForbidden):
+		return ExitForbidden
+	case errors.Is(err, model.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, model.ErrRateLimited):
+		return ExitRateLimited
+	case errors.Is(err, model.ErrServer):
+		return ExitServer
+	default:
+		return ExitFailure
+	}
+}
+
+// contextError explains an error caused by an interrupt or a timeout.
+func contextError(err error, deps *Deps) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("interrupted: %w", err)
+	case errors.Is(err, context.DeadlineExceeded) && deps.Global.Timeout > 0:
+		return fmt.Errorf("timed out after %s (raise --timeout): %w", deps.Global.Timeout, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("timed out: %w", err)
+	default:
+		return err
+	}
+}
+
+// usageError marks a mistake in how lcli was invoked, such as an unknown
+// flag or a missing argument.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// usageErrorf formats a usage error like fmt.Errorf.
+func usageErrorf(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+// parseFlags parses args into fs, reporting failures as usage errors. For
+// -h or -help, fs has printed its usage and help is true: the command
+// should stop and succeed.
+func parseFlags(fs *flag.FlagSet, args []string) (help bool, err error) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return true, nil
+		}
+		return false, &usageError{err: err}
+	}
+	return false, nil
+}
+
+// parseInterspersed is parseFlags for commands whose arguments may come
+// before, between or after the flags, as in "post edit URN --text x". The
+// arguments are left in fs.Args in order; after "--" all are arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) (help bool, err error) {
+	var positional []string
+	for {
+		if help, err := parseFlags(fs, args); help || err != nil {
+			return help, err
+		}
+		rest := fs.Args()
+		if n := len(args) - len(rest); len(rest) == 0 || n > 0 && args[n-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+	// Parsing the arguments alone leaves them in fs.Args.
+	return false, fs.Parse(append([]string{"--"}, positional...))
+}
+
+// jsonError is the object WriteJSONError writes. Status, ServiceErrorCode
+// and TraceID are set only for errors returned by the LinkedIn API.
+type jsonError struct {
+	Code             string `json:"code"`
+	ExitCode         int    `json:"exitCode"`
+	Message          string `json:"message"`
+	Status           int    `json:"status,omitempty"`
+	ServiceErrorCode int    `json:"serviceErrorCode,omitempty"`
+	TraceID          string `json:"traceId,omitempty"`
+}
+
+// WriteJSONError writes err to w as a single-line JSON object of the form
+// {"error": {...}}, for scripts that run lcli with --output json.
+func WriteJSONError(w io.Writer, err error) error {
+	code := ExitCode(err)
+	je := jsonError{Code: errorCodes[code], ExitCode: code, Message: err.Error()}
+
+	var apiErr *model.APIError
+	if errors.As(err, &apiErr) {
+		je.Status = apiErr.StatusCode
+		je.ServiceErrorCode = apiErr.ServiceErrorCode
+		je.TraceID = apiErr.RequestID
+	}
+	return json.NewEncoder(w).Encode(map[string]jsonError{"error": je})
+}
@@ -0,0 +1,193 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Softorize/lcli/internal/config"
+	"github.com/Softorize/lcli/internal/output"
+)
+
+// Globals holds the flags accepted before the command name. Subcommands
+// inherit them; a subcommand flag of the same name, such as --output or
+// auth login --timeout, overrides the global value for that command.
+type Globals struct {
+	// Output is the default output format: json, table or yaml.
+	Output string
+	// Profile is the named profile selected with --profile.
+	Profile string
+	// Timeout bounds each command's API calls. Zero means no limit.
+	Timeout time.Duration
+	// Verbose asks for extra diagnostics on stderr, including a summary of
+	// every HTTP request.
+	Verbose bool
+	// Trace prints every HTTP request and response in full, with secrets
+	// redacted. It is also set by LCLI_DEBUG.
+	Trace bool
+	// TraceFile, if set, receives every HTTP exchange as NDJSON.
+	TraceFile string
+	// Quiet suppresses progress messages and warnings on stderr.
+	Quiet bool
+	// NoColor disables colored output. It is also set by NO_COLOR.
+	NoColor bool
+	// DryRun reports API writes instead of performing them.
+	DryRun bool
+	// NoCache sends every API read to the network instead of answering it
+	// from the response cache.
+	NoCache bool
+	// APIVersion overrides the configured LinkedIn API version.
+	APIVersion string
+}
+
+// parseGlobals consumes the flags that precede the command name, stores them
+// in deps.Global and returns the remaining arguments. Every config setting
+// also has a global flag, e.g. --max-retries, recorded with config.SetFlag;
+// secrets are left out, as flags show up in shell history and ps output.
+func parseGlobals(args []string, deps *Deps) ([]string, error) {
+	g := &deps.Global
+	g.NoColor = g.NoColor || noColorEnv()
+	g.Trace = g.Trace || debugEnv()
+
+	fs := flag.NewFlagSet("lcli", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&g.Output, "output", g.Output, "Default output format (json/table/yaml)")
+	fs.StringVar(&g.Profile, "profile", g.Profile, "Named profile to use")
+	fs.Func("timeout", "Timeout for API calls, e.g. 30s or 30", func(v string) error {
+		d, err := parseTimeout(v)
+		if err != nil {
+			return err
+		}
+		g.Timeout = d
+		return nil
+	})
+	fs.BoolVar(&g.Verbose, "verbose", g.Verbose, "Print extra diagnostics to stderr")
+	fs.BoolVar(&g.Trace, "trace", g.Trace, "Print HTTP requests and responses in full")
+	fs.StringVar(&g.TraceFile, "trace-file", g.TraceFile, "Append HTTP exchanges to a file as NDJSON")
+	fs.BoolVar(&g.Quiet, "quiet", g.Quiet, "Suppress progress messages and warnings")
+	fs.BoolVar(&g.NoColor, "no-color", g.NoColor, "Disable colored output")
+	fs.BoolVar(&g.DryRun, "dry-run", g.DryRun, "Show API writes without performing them")
+	fs.BoolVar(&g.NoCache, "no-cache", g.NoCache, "Fetch API reads from LinkedIn instead of the response cache")
+	fs.Func("api-version", "LinkedIn API version (YYYYMM)", func(v string) error {
+		g.APIVersion = v
+		return config.SetFlag("api_version", v)
+	})
+	// Generated flags for the remaining config settings; the flags defined
+	// above take precedence.
+	for _, f := range config.Fields() {
+		if f.Secret || fs.Lookup(f.FlagName()) != nil {
+			continue
+		}
+		set := func(v string) error { return config.SetFlag(f.Key, v) }
+		if f.Bool {
+			fs.BoolFunc(f.FlagName(), f.Usage, set)
+		} else {
+			fs.Func(f.FlagName(), f.Usage, set)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return []string{"help"}, nil
+		}
+		return nil, usageErrorf("%w\nRun \"lcli help\" for usage", err)
+	}
+
+	if (g.Verbose || g.Trace) && g.Quiet {
+		return nil, usageErrorf("--quiet cannot be used with --verbose or --trace")
+	}
+	if g.Output != "" {
+		if _, err := output.ParseFormat(g.Output); err != nil {
+			return nil, usageErrorf("--output: %w", err)
+		}
+	}
+	if g.Profile != "" {
+		if err := config.SelectProfile(g.Profile); err != nil {
+			return nil, err
+		}
+	}
+
+	return fs.Args(), nil
+}
+
+// parseTimeout accepts a Go duration or a whole number of seconds.
+func parseTimeout(v string) (time.Duration, error) {
+	s := v
+	if _, err := strconv.Atoi(v); err == nil {
+		s += "s"
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid timeout %q (use e.g. 30s, 2m or 30)", v)
+	}
+	return d, nil
+}
+
+// noColorEnv reports whether the NO_COLOR convention is in effect.
+func noColorEnv() bool {
+	_, ok := os.LookupEnv("NO_COLOR")
+	return ok
+}
+
+// debugEnv reports whether LCLI_DEBUG asks for HTTP tracing.
+func debugEnv() bool {
+	v := os.Getenv("LCLI_DEBUG")
+	if v == "" {
+		return false
+	}
+	on, err := strconv.ParseBool(v)
+	return on || err != nil
+}
+
+// outputFlag registers the --output flag on fs. It defaults to the global
+// --output value, so "lcli --output json post list" and
+// "lcli post list --output json" are equivalent; the subcommand value is
+// stored back into deps.Global.Output so errors follow it.
+func outputFlag(fs *flag.FlagSet, deps *Deps) *string {
+	def := deps.Global.Output
+	if def == "" {
+		def = string(output.FormatTable)
+	}
+	fs.StringVar(&deps.Global.Output, "output", def, "Output format (json/table/yaml)")
+	return &deps.Global.Output
+}
+
+// JSONOutput reports whether the global or subcommand --output flag selected
+// JSON, in which case errors are reported as JSON too; see WriteJSONError.
+func JSONOutput(deps *Deps) bool {
+	format, err := output.ParseFormat(deps.Global.Output)
+	return err == nil && format == output.FormatJSON
+}
+
+// rootContext returns deps.Ctx, or context.Background() if it is unset.
+func (d *Deps) rootContext() context.Context {
+	if d.Ctx == nil {
+		return context.Background()
+	}
+	return d.Ctx
+}
+
+// context returns the context for a command's API calls. It is cancelled on
+// interrupt and bounded by the global --timeout when one is set.
+func (d *Deps) context() (context.Context, context.CancelFunc) {
+	if d.Global.Timeout > 0 {
+		return context.WithTimeout(d.rootContext(), d.Global.Timeout)
+	}
+	return context.WithCancel(d.rootContext())
+}
+
+// dryRun reports whether --dry-run is in effect. If so, it describes the
+// skipped action on stdout so the command can return without calling the
+// API. The report is the command's result, so --quiet does not hide it.
+func dryRun(deps *Deps, format string, args ...any) bool {
+	if !deps.Global.DryRun {
+		return false
+	}
+	fmt.Fprintf(deps.Stdout, "Dry run: would "+format+"\n", args...)
+	return true
+}
@@ -1,9 +1,13 @@
 package command
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
+	"io"
 	"os/exec"
 	"runtime"
+	"strings"
 
 	"github.com/Softorize/lcli/internal/output"
 )
@@ -12,6 +16,10 @@ import (
 // but no valid token is available.
 var errNotAuthenticated = errors.New("not authenticated — run 'lcli auth login' first")
 
+// errMemberOnly is returned when a command needs a member token but the
+// active profile holds an application token.
+var errMemberOnly = errors.New("this command needs a member token, but an app token is in use — run 'lcli auth login' without --client-credentials")
+
 // newPrinter creates an output.Printer from a format string flag value.
 // It writes to deps.Stdout with the parsed format.
 func newPrinter(deps *Deps, fmtStr string) (*output.Printer, error) {
@@ -45,3 +53,38 @@ func truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// confirmPrompt asks question on the prompt writer and reads the answer from stdin.
+// Only "y" and "yes" confirm; end of input declines. An interrupt ends the
+// wait with an error.
+func confirmPrompt(deps *Deps, question string) (bool, error) {
+	if deps.Stdin == nil {
+		return false, nil
+	}
+	fmt.Fprintf(deps.prompt(), "%s [y/N] ", question)
+
+	answer := make(chan string, 1)
+	readErr := make(chan error, 1)
+	go func() {
+		s, err := bufio.NewReader(deps.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			readErr <- err
+			return
+		}
+		answer <- s
+	}()
+
+	ctx := deps.rootContext()
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case err := <-readErr:
+		return false, fmt.Errorf("read answer: %w", err)
+	case s := <-answer:
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "y", "yes":
+			return true, nil
+		}
+		return false, nil
+	}
+}
@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -23,7 +22,7 @@ func runMedia(args []string, deps *Deps) error {
 		printMediaUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("media: unknown subcommand %q", args[0])
+		return usageErrorf("media: unknown subcommand %q", args[0])
 	}
 }
 
@@ -45,12 +44,12 @@ func runMediaUpload(args []string, deps *Deps) error {
 	owner := fs.String("owner", "me", "Owner URN (defaults to 'me')")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if fs.NArg() < 1 {
-		return fmt.Errorf("media upload: file path argument is required")
+		return usageErrorf("media upload: file path argument is required")
 	}
 
 	filePath := fs.Arg(0)
@@ -60,15 +59,20 @@ func runMediaUpload(args []string, deps *Deps) error {
 	}
 
 	if detectedType != "image" && detectedType != "video" && detectedType != "document" {
-		return fmt.Errorf("media upload: unable to detect type for %q, use --type", filePath)
+		return usageErrorf("media upload: unable to detect type for %q, use --type", filePath)
 	}
 
-	if err := requireAuth(deps.Media); err != nil {
+	if err := requireAuth(deps, deps.Media, scopeMemberSocial); err != nil {
 		return err
 	}
 
 	apiType := strings.ToUpper(detectedType)
-	ctx := context.Background()
+	if dryRun(deps, "upload %s as %s for %s", filePath, apiType, *owner) {
+		return nil
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
 
 	upload, err := deps.Media.InitUpload(ctx, *owner, apiType)
 	if err != nil {
@@ -20,7 +20,7 @@ func runOrg(args []string, deps *Deps) error {
 		printOrgUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("org: unknown subcommand %q", args[0])
+		return usageErrorf("org: unknown subcommand %q", args[0])
 	}
 }
 
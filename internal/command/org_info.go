@@ -1,44 +1,73 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/Softorize/lcli/internal/model"
 	"github.com/Softorize/lcli/internal/output"
 )
 
-// runOrgInfo handles the org info subcommand.
+// runOrgInfo handles the org info subcommand. Several comma-separated IDs,
+// or "-" to read them from stdin, are fetched with batch requests.
 func runOrgInfo(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("org info", flag.ContinueOnError)
-	id := fs.String("id", "", "Organization ID")
+	id := fs.String("id", "", "Organization ID, URN or company URL; several comma-separated, or - to read them from stdin")
 	vanity := fs.String("vanity", "", "Organization vanity name")
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if *id == "" && *vanity == "" {
-		return fmt.Errorf("org info: --id or --vanity is required")
+		return usageErrorf("org info: --id or --vanity is required")
 	}
 
-	if err := requireAuth(deps.Orgs); err != nil {
+	var keys []string
+	if *id != "" {
+		words, err := stdinArgs(deps, []string{*id})
+		if err != nil {
+			return fmt.Errorf("org info: %w", err)
+		}
+		for _, w := range words {
+			for k := range strings.SplitSeq(w, ",") {
+				if k = strings.TrimSpace(k); k != "" {
+					keys = append(keys, k)
+				}
+			}
+		}
+		if len(keys) == 0 {
+			return usageErrorf("org info: --id names no organizations")
+		}
+		if len(keys) > 1 || *id == "-" {
+			return runOrgInfoBatch(deps, *outputFmt, keys)
+		}
+	}
+
+	// --id also takes an organization URN or company URL; a company URL
+	// that names the organization by vanity name is looked up by it.
+	var numID int64
+	if len(keys) == 1 {
+		var err error
+		if numID, *vanity, err = resolveOrgID("org info", keys[0]); err != nil {
+			return err
+		}
+	}
+
+	if err := requireAuth(deps, deps.Orgs, scopeOrgRead); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 	var org *model.Organization
 	var err error
 
-	if *id != "" {
-		numID, parseErr := strconv.ParseInt(*id, 10, 64)
-		if parseErr != nil {
-			return fmt.Errorf("org info: invalid id %q: %w", *id, parseErr)
-		}
+	if numID != 0 {
 		org, err = deps.Orgs.Get(ctx, numID)
 	} else {
 		org, err = deps.Orgs.GetByVanity(ctx, *vanity)
@@ -73,3 +102,63 @@ func printOrg(deps *Deps, fmtStr string, org *model.Organization) error {
 
 	return printer.Print(org)
 }
+
+// runOrgInfoBatch fetches the organizations named by keys with batch
+// requests. Vanity names cannot be batched and are rejected.
+func runOrgInfoBatch(deps *Deps, fmtStr string, keys []string) error {
+	ids := make([]int64, len(keys))
+	for i, k := range keys {
+		id, vanity, err := resolveOrgID("org info", k)
+		if err != nil {
+			return err
+		}
+		if vanity != "" {
+			return usageErrorf("org info: %s names the organization by vanity name, which cannot be fetched with other IDs (use --vanity %s)", k, vanity)
+		}
+		ids[i] = id
+	}
+
+	if err := requireAuth(deps, deps.Orgs, scopeOrgRead); err != nil {
+		return err
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
+	batch, err := deps.Orgs.GetMany(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("org info: %w", err)
+	}
+
+	result, failure := collectBatch("org info", ids, batch, func(id int64) string {
+		return strconv.FormatInt(id, 10)
+	})
+
+	printer, err := newPrinter(deps, fmtStr)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format() == output.FormatTable {
+		headers := []string{"ID", "Name", "Vanity", "Website", "Followers"}
+		rows := make([][]string, 0, len(result.Results))
+		for _, o := range result.Results {
+			rows = append(rows, []string{
+				strconv.FormatInt(o.ID, 10),
+				o.Name,
+				o.VanityName,
+				o.Website,
+				strconv.Itoa(o.FollowerCount),
+			})
+		}
+		if err := printer.PrintTable(headers, rows); err != nil {
+			return err
+		}
+		printBatchErrors(deps, "org info", result.Errors)
+		return failure
+	}
+
+	if err := printer.Print(result); err != nil {
+		return err
+	}
+	return failure
+}
@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"strconv"
@@ -13,22 +12,27 @@ import (
 func runOrgStats(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("org stats", flag.ContinueOnError)
 	orgURN := fs.String("org", "", "Organization URN (required)")
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if *orgURN == "" {
-		return fmt.Errorf("org stats: --org is required")
+		return usageErrorf("org stats: --org is required")
+	}
+	var err error
+	if *orgURN, err = resolveOrg("org stats", *orgURN); err != nil {
+		return err
 	}
 
-	if err := requireAuth(deps.Orgs); err != nil {
+	if err := requireAuth(deps, deps.Orgs, scopeOrgAdmin); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 	stats, err := deps.Orgs.PageStats(ctx, *orgURN)
 	if err != nil {
 		return fmt.Errorf("org stats: %w", err)
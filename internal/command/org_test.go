@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -71,6 +72,62 @@ func TestOrgInfoInvalidID(t *testing.T) {
 	}
 }
 
+func TestOrgInfoByCompanyURL(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Orgs = &mockOrgReader{
+		getFunc: func(_ context.Context, id int64) (*model.Organization, error) {
+			return &model.Organization{ID: id, Name: "ByID"}, nil
+		},
+		getByVanityFunc: func(_ context.Context, name string) (*model.Organization, error) {
+			return &model.Organization{Name: "ByVanity", VanityName: name}, nil
+		},
+	}
+
+	if err := runOrgInfo([]string{"--id", "urn:li:organization:12345"}, deps); err != nil {
+		t.Fatalf("runOrgInfo: %v", err)
+	}
+	if err := runOrgInfo([]string{"--id", "https://www.linkedin.com/company/vanity-corp/"}, deps); err != nil {
+		t.Fatalf("runOrgInfo: %v", err)
+	}
+
+	if !strings.Contains(stdout.String(), "ByID") || !strings.Contains(stdout.String(), "vanity-corp") {
+		t.Errorf("output:\n%s", stdout.String())
+	}
+}
+
+func TestOrgInfoMany(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Stdin = strings.NewReader("1001\nurn:li:organization:1002,1003\n")
+	var requested []int64
+	deps.Orgs = &mockOrgReader{
+		getManyFunc: func(_ context.Context, ids []int64) (*model.Batch[int64, *model.Organization], error) {
+			requested = ids
+			b := &model.Batch[int64, *model.Organization]{Results: map[int64]*model.Organization{}}
+			for _, id := range ids {
+				b.Results[id] = &model.Organization{ID: id, Name: fmt.Sprintf("Org%d", id)}
+			}
+			return b, nil
+		},
+	}
+
+	if err := runOrgInfo([]string{"--id", "-"}, deps); err != nil {
+		t.Fatalf("runOrgInfo: %v", err)
+	}
+	if len(requested) != 3 || requested[1] != 1002 {
+		t.Errorf("requested %v", requested)
+	}
+	for _, name := range []string{"Org1001", "Org1002", "Org1003"} {
+		if !strings.Contains(stdout.String(), name) {
+			t.Errorf("output missing %s:\n%s", name, stdout.String())
+		}
+	}
+
+	err := runOrgInfo([]string{"--id", "1,https://www.linkedin.com/company/acme"}, deps)
+	if code := ExitCode(err); code != ExitUsage {
+		t.Errorf("vanity in batch: ExitCode = %d, want %d (err = %v)", code, ExitUsage, err)
+	}
+}
+
 func TestOrgInfoNotAuthenticated(t *testing.T) {
 	deps, _, _ := testDeps()
 
@@ -2,7 +2,8 @@ package command
 
 import "fmt"
 
-// runPost dispatches to post subcommands: create, list, get, delete.
+// runPost dispatches to post subcommands: create, list, get, edit,
+// reshare, delete.
 func runPost(args []string, deps *Deps) error {
 	if len(args) == 0 {
 		printPostUsage(deps)
@@ -16,13 +17,17 @@ func runPost(args []string, deps *Deps) error {
 		return runPostList(args[1:], deps)
 	case "get":
 		return runPostGet(args[1:], deps)
+	case "edit":
+		return runPostEdit(args[1:], deps)
+	case "reshare":
+		return runPostReshare(args[1:], deps)
 	case "delete":
 		return runPostDelete(args[1:], deps)
 	case "-help", "--help", "-h":
 		printPostUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("post: unknown subcommand %q", args[0])
+		return usageErrorf("post: unknown subcommand %q", args[0])
 	}
 }
 
@@ -33,7 +38,9 @@ func printPostUsage(deps *Deps) {
 Subcommands:
   create    Create a new LinkedIn post
   list      List your recent posts
-  get       Get a single post by URN
+  get       Get one or more posts by URN
+  edit      Edit the text or call-to-action of a post
+  reshare   Reshare a post, optionally with commentary
   delete    Delete a post by URN
 
 Use "lcli post <subcommand> -help" for more information.
@@ -20,28 +20,33 @@ func runPostCreate(args []string, deps *Deps) error {
 	title := fs.String("title", "", "Title for document/carousel post")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if *text == "" {
-		return fmt.Errorf("post create: --text is required")
+		return usageErrorf("post create: --text is required")
 	}
 	if err := validateVisibility(*visibility); err != nil {
 		return err
 	}
-	if err := requireAuth(deps.Posts); err != nil {
+	if err := requireAuth(deps, deps.Posts, scopeMemberSocial); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	if dryRun(deps, "create a %s post with text %q%s", *visibility, truncate(*text, 60), uploadNote(*image, *video, *document)) {
+		return nil
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
 	req := &model.CreatePostRequest{
 		Text:       *text,
 		Visibility: *visibility,
 	}
 
 	// Resolve the full person URN for the author (required by API v202601+).
-	if err := requireAuth(deps.Profile); err == nil {
+	if err := requireAuth(deps, deps.Profile); err == nil {
 		if profile, err := deps.Profile.Me(ctx); err == nil {
 			req.AuthorURN = "urn:li:person:" + profile.ID
 		}
@@ -65,7 +70,7 @@ func attachMedia(ctx context.Context, deps *Deps, req *model.CreatePostRequest,
 	if imagePath == "" && videoPath == "" && documentPath == "" {
 		return nil
 	}
-	if err := requireAuth(deps.Media); err != nil {
+	if err := requireAuth(deps, deps.Media, scopeMemberSocial); err != nil {
 		return err
 	}
 
@@ -84,7 +89,7 @@ func attachMedia(ctx context.Context, deps *Deps, req *model.CreatePostRequest,
 	// while images and videos accept "me".
 	owner := "me"
 	if mediaType == "DOCUMENT" {
-		if err := requireAuth(deps.Profile); err != nil {
+		if err := requireAuth(deps, deps.Profile); err != nil {
 			return fmt.Errorf("post create: profile required for document upload: %w", err)
 		}
 		profile, err := deps.Profile.Me(ctx)
@@ -116,12 +121,22 @@ func attachMedia(ctx context.Context, deps *Deps, req *model.CreatePostRequest,
 	return nil
 }
 
+// uploadNote describes the file attachMedia would upload, for --dry-run.
+func uploadNote(paths ...string) string {
+	for _, p := range paths {
+		if p != "" {
+			return fmt.Sprintf(", uploading %s", p)
+		}
+	}
+	return ""
+}
+
 // validateVisibility checks that the visibility value is valid.
 func validateVisibility(v string) error {
 	switch v {
 	case "PUBLIC", "CONNECTIONS":
 		return nil
 	default:
-		return fmt.Errorf("invalid visibility %q: use PUBLIC or CONNECTIONS", v)
+		return usageErrorf("invalid visibility %q: use PUBLIC or CONNECTIONS", v)
 	}
 }
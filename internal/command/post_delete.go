@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 )
@@ -12,25 +11,32 @@ func runPostDelete(args []string, deps *Deps) error {
 	confirm := fs.Bool("confirm", false, "Skip confirmation prompt")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if fs.NArg() < 1 {
-		return fmt.Errorf("post delete: post URN argument is required")
+		return usageErrorf("post delete: post URN argument is required")
 	}
 
-	urn := fs.Arg(0)
+	urn, err := resolvePost("post delete", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if dryRun(deps, "delete post %s", urn) {
+		return nil
+	}
 	if !*confirm {
 		fmt.Fprintf(deps.Stderr, "Delete post %s? Use --confirm to skip this prompt.\n", urn)
 		return nil
 	}
 
-	if err := requireAuth(deps.Posts); err != nil {
+	if err := requireAuth(deps, deps.Posts, scopeMemberSocial); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 	if err := deps.Posts.Delete(ctx, urn); err != nil {
 		return fmt.Errorf("post delete: %w", err)
 	}
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// callToActionLabels are the call-to-action labels LinkedIn accepts.
+var callToActionLabels = []string{
+	"APPLY", "DOWNLOAD", "VIEW_QUOTE", "LEARN_MORE", "SIGN_UP", "SUBSCRIBE",
+	"REGISTER", "JOIN", "ATTEND", "REQUEST_DEMO", "SEE_MORE", "BUY_NOW", "SHOP_NOW",
+}
+
+// lifecycleStates are the post lifecycle states LinkedIn knows.
+var lifecycleStates = []string{"DRAFT", "PUBLISHED", "PUBLISH_REQUESTED", "PUBLISH_FAILED"}
+
+// runPostEdit handles the post edit subcommand. It shows how the post
+// changes and asks before applying the edit, unless --confirm is given.
+func runPostEdit(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("post edit", flag.ContinueOnError)
+	text := fs.String("text", "", "New post text")
+	ctaLabel := fs.String("cta-label", "", "Call-to-action label, e.g. LEARN_MORE")
+	landingPage := fs.String("landing-page", "", "Call-to-action landing page URL")
+	state := fs.String("lifecycle-state", "", "Lifecycle state, e.g. PUBLISHED to publish a draft")
+	confirm := fs.Bool("confirm", false, "Skip confirmation prompt")
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseInterspersed(fs, args); help || err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 {
+		return usageErrorf("post edit: post URN argument is required")
+	}
+	if fs.NArg() > 1 {
+		return usageErrorf("post edit: unexpected arguments %q", fs.Args()[1:])
+	}
+
+	// Only the flags given are changed, so an empty --cta-label or
+	// --landing-page clears it.
+	req := &model.UpdatePostRequest{}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "text":
+			req.Text = text
+		case "cta-label":
+			req.CallToActionLabel = ctaLabel
+		case "landing-page":
+			req.LandingPage = landingPage
+		case "lifecycle-state":
+			req.LifecycleState = state
+		}
+	})
+	switch {
+	case *req == (model.UpdatePostRequest{}):
+		return usageErrorf("post edit: nothing to change: use --text, --cta-label, --landing-page or --lifecycle-state")
+	case req.Text != nil && strings.TrimSpace(*text) == "":
+		return usageErrorf("post edit: --text must not be empty")
+	case req.CallToActionLabel != nil && *ctaLabel != "" && !slices.Contains(callToActionLabels, *ctaLabel):
+		return usageErrorf("post edit: invalid --cta-label %q: use one of %s", *ctaLabel, strings.Join(callToActionLabels, ", "))
+	case req.LifecycleState != nil && !slices.Contains(lifecycleStates, *state):
+		return usageErrorf("post edit: invalid --lifecycle-state %q: use one of %s", *state, strings.Join(lifecycleStates, ", "))
+	}
+
+	urn, err := resolvePost("post edit", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(deps, deps.Posts, scopeMemberSocial); err != nil {
+		return err
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
+	post, err := deps.Posts.Get(ctx, urn)
+	if err != nil {
+		return fmt.Errorf("post edit: %w", err)
+	}
+
+	if req.Text != nil && *req.Text == post.Text {
+		req.Text = nil
+		if *req == (model.UpdatePostRequest{}) {
+			fmt.Fprintf(deps.Stderr, "Post %s already has this text.\n", urn)
+			return nil
+		}
+	}
+	// The changes are part of the question when one is asked, so they are
+	// shown even with --quiet.
+	ask := !*confirm && !deps.Global.DryRun
+	w := deps.Stderr
+	if ask {
+		w = deps.prompt()
+	}
+	printPostEdit(w, post, req)
+
+	if dryRun(deps, "edit post %s", urn) {
+		return nil
+	}
+	if ask {
+		ok, err := confirmPrompt(deps, fmt.Sprintf("Apply these changes to post %s?", urn))
+		if err != nil {
+			return fmt.Errorf("post edit: %w", err)
+		}
+		if !ok {
+			fmt.Fprintln(deps.prompt(), "Post not changed. Use --confirm to skip this prompt.")
+			return nil
+		}
+	}
+
+	if err := deps.Posts.Update(ctx, urn, req); err != nil {
+		return fmt.Errorf("post edit: %w", err)
+	}
+
+	fmt.Fprintf(deps.Stderr, "Post %s updated.\n", urn)
+	return nil
+}
+
+// printPostEdit describes the changes req makes to post on w: a diff of the
+// text, and the other fields that are set.
+func printPostEdit(w io.Writer, post *model.Post, req *model.UpdatePostRequest) {
+	if req.Text != nil {
+		fmt.Fprintf(w, "--- %s (current)\n+++ %s (edited)\n", post.ID, post.ID)
+		for _, line := range lineDiff(post.Text, *req.Text) {
+			fmt.Fprintln(w, line)
+		}
+	}
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{"Call to action", req.CallToActionLabel},
+		{"Landing page", req.LandingPage},
+		{"Lifecycle state", req.LifecycleState},
+	} {
+		if f.value != nil {
+			fmt.Fprintf(w, "%s: %q\n", f.name, *f.value)
+		}
+	}
+}
+
+// lineDiff compares two texts line by line and returns the lines of both,
+// in order, prefixed with "-" if only in a, "+" if only in b and " " if in
+// both. It uses a longest common subsequence, which is fine for texts as
+// short as posts.
+func lineDiff(a, b string) []string {
+	x, y := strings.Split(a, "\n"), strings.Split(b, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			out = append(out, " "+x[i])
+			i++
+			j++
+		case j == len(y) || (i < len(x) && lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, "-"+x[i])
+			i++
+		default:
+			out = append(out, "+"+y[j])
+			j++
+		}
+	}
+	return out
+}
@@ -1,35 +1,55 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
+	"slices"
 
+	"github.com/Softorize/lcli/internal/model"
 	"github.com/Softorize/lcli/internal/output"
 )
 
-// runPostGet handles the post get subcommand.
+// runPostGet handles the post get subcommand. Several URNs, or "-" to read
+// them from stdin, are fetched with batch requests.
 func runPostGet(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("post get", flag.ContinueOnError)
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
-	if fs.NArg() < 1 {
-		return fmt.Errorf("post get: post URN argument is required")
+	keys, err := stdinArgs(deps, fs.Args())
+	if err != nil {
+		return fmt.Errorf("post get: %w", err)
+	}
+	if len(keys) == 0 {
+		return usageErrorf("post get: post URN argument is required")
 	}
 
-	if err := requireAuth(deps.Posts); err != nil {
+	urns := make([]string, len(keys))
+	for i, k := range keys {
+		if urns[i], err = resolvePost("post get", k); err != nil {
+			return err
+		}
+	}
+	if err := requireAuth(deps, deps.Posts, scopeSocialRead); err != nil {
 		return err
 	}
 
-	urn := fs.Arg(0)
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 
-	post, err := deps.Posts.Get(ctx, urn)
+	if fs.NArg() > 1 || fs.Arg(0) == "-" {
+		batch, err := deps.Posts.GetMany(ctx, urns)
+		if err != nil {
+			return fmt.Errorf("post get: %w", err)
+		}
+		return printPostBatch(deps, *outputFmt, urns, batch)
+	}
+
+	post, err := deps.Posts.Get(ctx, urns[0])
 	if err != nil {
 		return fmt.Errorf("post get: %w", err)
 	}
@@ -49,8 +69,62 @@ func runPostGet(args []string, deps *Deps) error {
 			{"State", post.LifecycleState},
 			{"Created", post.CreatedAt.Format("2006-01-02 15:04")},
 		}
+		if post.ReshareParent != "" {
+			rows = append(rows, []string{"Reshare of", post.ReshareParent})
+		}
+		if post.ReshareRoot != "" && post.ReshareRoot != post.ReshareParent {
+			rows = append(rows, []string{"Original", post.ReshareRoot})
+		}
 		return printer.PrintTable(headers, rows)
 	}
 
 	return printer.Print(post)
 }
+
+// printPostBatch renders the posts fetched for urns, one row each, and
+// reports the URNs that failed. If any post is a reshare, the table also
+// names the post it reshares and the original, as post get does for one.
+func printPostBatch(deps *Deps, fmtStr string, urns []string, batch *model.Batch[string, *model.Post]) error {
+	result, failure := collectBatch("post get", urns, batch, func(urn string) string { return urn })
+
+	printer, err := newPrinter(deps, fmtStr)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format() == output.FormatTable {
+		headers := []string{"ID", "Author", "Text", "Visibility", "Created"}
+		reshares := slices.ContainsFunc(result.Results, func(p *model.Post) bool { return p.ReshareParent != "" })
+		if reshares {
+			headers = append(headers, "Reshare of", "Original")
+		}
+		rows := make([][]string, 0, len(result.Results))
+		for _, p := range result.Results {
+			row := []string{
+				p.ID,
+				p.Author,
+				truncate(p.Text, 50),
+				p.Visibility,
+				p.CreatedAt.Format("2006-01-02 15:04"),
+			}
+			if reshares {
+				root := p.ReshareRoot
+				if root == p.ReshareParent {
+					root = ""
+				}
+				row = append(row, p.ReshareParent, root)
+			}
+			rows = append(rows, row)
+		}
+		if err := printer.PrintTable(headers, rows); err != nil {
+			return err
+		}
+		printBatchErrors(deps, "post get", result.Errors)
+		return failure
+	}
+
+	if err := printer.Print(result); err != nil {
+		return err
+	}
+	return failure
+}
@@ -1,9 +1,9 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
+	"strings"
 
 	"github.com/Softorize/lcli/internal/output"
 )
@@ -14,18 +14,30 @@ func runPostList(args []string, deps *Deps) error {
 	count := fs.Int("count", 10, "Number of posts to retrieve")
 	start := fs.Int("start", 0, "Pagination start index")
 	author := fs.String("author", "me", "Author URN (defaults to 'me')")
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
-	if err := requireAuth(deps.Posts); err != nil {
+	var err error
+	if *author, err = resolveActor("post list", *author); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	// Reading a member's posts needs the member read scope, and an
+	// organization's the organization scope.
+	scope := scopeMemberRead
+	if strings.HasPrefix(*author, "urn:li:organization:") {
+		scope = scopeOrgSocial
+	}
+	if err := requireAuth(deps, deps.Posts, scope); err != nil {
+		return err
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
 	list, err := deps.Posts.ListByAuthor(ctx, *author, *start, *count)
 	if err != nil {
 		return fmt.Errorf("post list: %w", err)
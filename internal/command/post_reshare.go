@@ -0,0 +1,81 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// runPostReshare handles the post reshare subcommand. The reshare is posted
+// by the authenticated member, or by an organization they administer with
+// --as-org, with optional commentary of its own.
+func runPostReshare(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("post reshare", flag.ContinueOnError)
+	text := fs.String("text", "", "Commentary to add above the reshared post")
+	asOrg := fs.String("as-org", "", "Reshare as this organization (ID or URN)")
+	visibility := fs.String("visibility", "PUBLIC", "Visibility: PUBLIC or CONNECTIONS")
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseInterspersed(fs, args); help || err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 {
+		return usageErrorf("post reshare: post URN argument is required")
+	}
+	if fs.NArg() > 1 {
+		return usageErrorf("post reshare: unexpected arguments %q", fs.Args()[1:])
+	}
+	if err := validateVisibility(*visibility); err != nil {
+		return err
+	}
+
+	parent, err := resolvePost("post reshare", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	req := &model.CreatePostRequest{
+		Text:          *text,
+		Visibility:    *visibility,
+		ReshareParent: parent,
+	}
+	scope := scopeMemberSocial
+	if *asOrg != "" {
+		if req.AuthorURN, err = resolveOrg("post reshare", *asOrg); err != nil {
+			return err
+		}
+		scope = scopeOrgWrite
+	}
+	if err := requireAuth(deps, deps.Posts, scope); err != nil {
+		return err
+	}
+
+	author := "you"
+	if req.AuthorURN != "" {
+		author = req.AuthorURN
+	}
+	if dryRun(deps, "reshare %s as %s with text %q", parent, author, truncate(*text, 60)) {
+		return nil
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
+
+	// Resolve the full person URN for the author (required by API v202601+).
+	if req.AuthorURN == "" {
+		if err := requireAuth(deps, deps.Profile); err == nil {
+			if profile, err := deps.Profile.Me(ctx); err == nil {
+				req.AuthorURN = "urn:li:person:" + profile.ID
+			}
+		}
+	}
+
+	post, err := deps.Posts.Create(ctx, req)
+	if err != nil {
+		return fmt.Errorf("post reshare: %w", err)
+	}
+
+	fmt.Fprintf(deps.Stderr, "Post reshared: %s\n", post.ID)
+	return nil
+}
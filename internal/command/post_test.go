@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -131,6 +132,294 @@ func TestPostGetMissingURN(t *testing.T) {
 	}
 }
 
+func TestPostGetNormalizesURN(t *testing.T) {
+	for _, in := range []string{"123", "https://www.linkedin.com/feed/update/urn:li:share:123/"} {
+		deps, _, _ := testDeps()
+		var got string
+		deps.Posts = &mockPoster{
+			getFunc: func(_ context.Context, urn string) (*model.Post, error) {
+				got = urn
+				return &model.Post{ID: urn}, nil
+			},
+		}
+
+		if err := runPostGet([]string{in}, deps); err != nil {
+			t.Fatalf("runPostGet(%q): %v", in, err)
+		}
+		if got != "urn:li:share:123" {
+			t.Errorf("runPostGet(%q) fetched %q", in, got)
+		}
+	}
+}
+
+func TestPostGetRejectsActivityURN(t *testing.T) {
+	deps, _, _ := testDeps()
+	deps.Posts = &mockPoster{}
+
+	err := runPostGet([]string{"urn:li:activity:123"}, deps)
+	if code := ExitCode(err); code != ExitUsage {
+		t.Fatalf("ExitCode = %d, want %d (err = %v)", code, ExitUsage, err)
+	}
+	if !strings.Contains(err.Error(), "share or ugcPost") {
+		t.Errorf("error = %q", err)
+	}
+}
+
+func TestPostGetMany(t *testing.T) {
+	deps, stdout, stderr := testDeps()
+	var requested []string
+	deps.Posts = &mockPoster{
+		getManyFunc: func(_ context.Context, urns []string) (*model.Batch[string, *model.Post], error) {
+			requested = urns
+			return &model.Batch[string, *model.Post]{
+				Results: map[string]*model.Post{
+					"urn:li:share:1":   {ID: "urn:li:share:1", Text: "One"},
+					"urn:li:ugcPost:3": {ID: "urn:li:ugcPost:3", Text: "Three"},
+				},
+				Errors: map[string]error{"urn:li:share:2": &model.APIError{StatusCode: 404, Message: "gone"}},
+			}, nil
+		},
+	}
+
+	err := runPostGet([]string{"1", "urn:li:share:2", "urn:li:ugcPost:3"}, deps)
+	if code := ExitCode(err); code != ExitNotFound {
+		t.Fatalf("ExitCode = %d, want %d (err = %v)", code, ExitNotFound, err)
+	}
+	if len(requested) != 3 || requested[0] != "urn:li:share:1" {
+		t.Errorf("requested %v", requested)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "One") || !strings.Contains(out, "Three") {
+		t.Errorf("output:\n%s", out)
+	}
+	if !strings.Contains(stderr.String(), "urn:li:share:2: linkedin api 404") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	if !strings.Contains(err.Error(), "1 of 3") {
+		t.Errorf("error = %q", err)
+	}
+}
+
+func TestPostGetManyShowsReshares(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Posts = &mockPoster{
+		getManyFunc: func(_ context.Context, urns []string) (*model.Batch[string, *model.Post], error) {
+			return &model.Batch[string, *model.Post]{
+				Results: map[string]*model.Post{
+					"urn:li:share:1": {ID: "urn:li:share:1", Text: "Original"},
+					"urn:li:share:2": {ID: "urn:li:share:2", ReshareParent: "urn:li:share:1", ReshareRoot: "urn:li:share:1"},
+					"urn:li:share:3": {ID: "urn:li:share:3", ReshareParent: "urn:li:share:2", ReshareRoot: "urn:li:share:1"},
+				},
+			}, nil
+		},
+	}
+
+	if err := runPostGet([]string{"1", "2", "3"}, deps); err != nil {
+		t.Fatalf("runPostGet: %v", err)
+	}
+	lines := strings.Split(stdout.String(), "\n")
+	if !strings.Contains(lines[0], "Reshare of") || !strings.Contains(lines[0], "Original") {
+		t.Fatalf("headers = %q", lines[0])
+	}
+	var three string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "urn:li:share:3") {
+			three = line
+		}
+	}
+	if !strings.Contains(three, "urn:li:share:2") || !strings.Contains(three, "urn:li:share:1") {
+		t.Errorf("row for share 3 = %q", three)
+	}
+}
+
+func TestPostGetManyFromStdinJSON(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Stdin = strings.NewReader("urn:li:share:1\nurn:li:share:2\n")
+	deps.Posts = &mockPoster{
+		getManyFunc: func(_ context.Context, urns []string) (*model.Batch[string, *model.Post], error) {
+			b := &model.Batch[string, *model.Post]{Results: map[string]*model.Post{}}
+			for _, urn := range urns {
+				b.Results[urn] = &model.Post{ID: urn}
+			}
+			return b, nil
+		},
+	}
+
+	if err := runPostGet([]string{"--output", "json", "-"}, deps); err != nil {
+		t.Fatalf("runPostGet: %v", err)
+	}
+	var got struct {
+		Results []model.Post `json:"results"`
+		Errors  []any        `json:"errors"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if len(got.Results) != 2 || got.Results[1].ID != "urn:li:share:2" || got.Errors != nil {
+		t.Errorf("output = %+v", got)
+	}
+}
+
+func TestPostEdit(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		updated bool
+	}{
+		{"answered yes", []string{"--text", "Hello world", "urn:li:share:1"}, "y\n", true},
+		{"answered no", []string{"--text", "Hello world", "urn:li:share:1"}, "n\n", false},
+		{"no answer", []string{"--text", "Hello world", "urn:li:share:1"}, "", false},
+		{"confirmed by flag", []string{"--confirm", "--text", "Hello world", "urn:li:share:1"}, "", true},
+		{"flags after the URN", []string{"urn:li:share:1", "--text", "Hello world", "--confirm"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, _, stderr := testDeps()
+			deps.Stdin = strings.NewReader(tt.stdin)
+			var got *model.UpdatePostRequest
+			deps.Posts = &mockPoster{
+				getFunc: func(_ context.Context, urn string) (*model.Post, error) {
+					return &model.Post{ID: urn, Text: "Intro\nHelo wrold"}, nil
+				},
+				updateFunc: func(_ context.Context, _ string, req *model.UpdatePostRequest) error {
+					got = req
+					return nil
+				},
+			}
+
+			if err := runPostEdit(tt.args, deps); err != nil {
+				t.Fatalf("runPostEdit: %v", err)
+			}
+			if (got != nil) != tt.updated {
+				t.Fatalf("updated = %v, want %v", got != nil, tt.updated)
+			}
+			if got != nil && (got.Text == nil || *got.Text != "Hello world" || got.CallToActionLabel != nil) {
+				t.Errorf("request = %+v", got)
+			}
+			for _, want := range []string{"-Intro", "-Helo wrold", "+Hello world"} {
+				if !strings.Contains(stderr.String(), want+"\n") {
+					t.Errorf("stderr missing %q:\n%s", want, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPostEditUsage(t *testing.T) {
+	for _, args := range [][]string{
+		{"urn:li:share:1"},
+		{"--text", "x"},
+		{"--text", " ", "urn:li:share:1"},
+		{"--cta-label", "CLICK", "urn:li:share:1"},
+		{"--lifecycle-state", "ARCHIVED", "urn:li:share:1"},
+		{"--text", "x", "urn:li:activity:1"},
+		{"urn:li:share:1", "--text", "x", "urn:li:share:2"},
+	} {
+		deps, _, _ := testDeps()
+		deps.Posts = &mockPoster{}
+		if code := ExitCode(runPostEdit(args, deps)); code != ExitUsage {
+			t.Errorf("runPostEdit(%q): ExitCode = %d, want %d", args, code, ExitUsage)
+		}
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	got := lineDiff("a\nb\nc", "a\nB\nc\nd")
+	want := []string{" a", "-b", "+B", " c", "+d"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("lineDiff = %q, want %q", got, want)
+	}
+}
+
+func TestPostReshare(t *testing.T) {
+	deps, _, stderr := testDeps()
+	deps.Profile = &mockProfiler{
+		meFunc: func(context.Context) (*model.Profile, error) { return &model.Profile{ID: "abc"}, nil },
+	}
+	deps.Posts = &mockPoster{
+		createFunc: func(_ context.Context, req *model.CreatePostRequest) (*model.Post, error) {
+			if req.ReshareParent != "urn:li:share:123" || req.Text != "Worth a read" || req.AuthorURN != "urn:li:person:abc" {
+				t.Errorf("request = %+v", req)
+			}
+			return &model.Post{ID: "urn:li:share:124"}, nil
+		},
+	}
+
+	if err := runPostReshare([]string{"--text", "Worth a read", "123"}, deps); err != nil {
+		t.Fatalf("runPostReshare: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Post reshared: urn:li:share:124") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestPostReshareAsOrg(t *testing.T) {
+	deps, _, _ := testDeps()
+	deps.Posts = &mockPoster{
+		createFunc: func(_ context.Context, req *model.CreatePostRequest) (*model.Post, error) {
+			if req.ReshareParent != "urn:li:ugcPost:9" || req.Text != "" || req.AuthorURN != "urn:li:organization:1001" {
+				t.Errorf("request = %+v", req)
+			}
+			return &model.Post{ID: "urn:li:share:124"}, nil
+		},
+	}
+
+	if err := runPostReshare([]string{"--as-org", "1001", "urn:li:ugcPost:9"}, deps); err != nil {
+		t.Fatalf("runPostReshare: %v", err)
+	}
+}
+
+func TestPostReshareFlagsAfterURN(t *testing.T) {
+	deps, _, _ := testDeps()
+	deps.Posts = &mockPoster{
+		createFunc: func(_ context.Context, req *model.CreatePostRequest) (*model.Post, error) {
+			if req.ReshareParent != "urn:li:share:1" || req.Text != "x" || req.AuthorURN != "urn:li:organization:1001" {
+				t.Errorf("request = %+v", req)
+			}
+			return &model.Post{ID: "urn:li:share:124"}, nil
+		},
+	}
+
+	if err := runPostReshare([]string{"urn:li:share:1", "--text", "x", "--as-org", "1001"}, deps); err != nil {
+		t.Fatalf("runPostReshare: %v", err)
+	}
+}
+
+func TestPostReshareUsage(t *testing.T) {
+	for _, args := range [][]string{
+		nil,
+		{"urn:li:share:1", "--text", "x", "urn:li:share:2"},
+		{"--visibility", "LOGGED_IN", "urn:li:share:1"},
+		{"--as-org", "urn:li:person:abc", "urn:li:share:1"},
+		{"urn:li:comment:(urn:li:share:1,2)"},
+	} {
+		deps, _, _ := testDeps()
+		deps.Posts = &mockPoster{}
+		if code := ExitCode(runPostReshare(args, deps)); code != ExitUsage {
+			t.Errorf("runPostReshare(%q): ExitCode = %d, want %d", args, code, ExitUsage)
+		}
+	}
+}
+
+func TestPostGetShowsReshareLineage(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Posts = &mockPoster{
+		getFunc: func(_ context.Context, urn string) (*model.Post, error) {
+			return &model.Post{ID: urn, ReshareParent: "urn:li:share:2", ReshareRoot: "urn:li:share:1"}, nil
+		},
+	}
+
+	if err := runPostGet([]string{"urn:li:share:3"}, deps); err != nil {
+		t.Fatalf("runPostGet: %v", err)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "Reshare of") || !strings.Contains(out, "urn:li:share:2") ||
+		!strings.Contains(out, "Original") || !strings.Contains(out, "urn:li:share:1") {
+		t.Errorf("output missing lineage:\n%s", out)
+	}
+}
+
 func TestPostDeleteWithConfirm(t *testing.T) {
 	deps, _, stderr := testDeps()
 	deleted := false
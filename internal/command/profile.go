@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 
@@ -25,7 +24,7 @@ func runProfile(args []string, deps *Deps) error {
 		printProfileUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("profile: unknown subcommand %q", args[0])
+		return usageErrorf("profile: unknown subcommand %q", args[0])
 	}
 }
 
@@ -44,18 +43,19 @@ Use "lcli profile <subcommand> -help" for more information.
 // runProfileMe handles the profile me subcommand.
 func runProfileMe(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("profile me", flag.ContinueOnError)
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
-	if err := requireAuth(deps.Profile); err != nil {
+	if err := requireAuth(deps, deps.Profile, scopeOpenID, scopeProfile); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 	profile, err := deps.Profile.Me(ctx)
 	if err != nil {
 		return fmt.Errorf("profile me: %w", err)
@@ -68,22 +68,27 @@ func runProfileMe(args []string, deps *Deps) error {
 func runProfileView(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("profile view", flag.ContinueOnError)
 	id := fs.String("id", "", "LinkedIn profile ID to view")
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if *id == "" {
-		return fmt.Errorf("profile view: --id is required")
+		return usageErrorf("profile view: --id is required")
+	}
+	var err error
+	if *id, err = resolvePersonID("profile view", *id); err != nil {
+		return err
 	}
 
-	if err := requireAuth(deps.Profile); err != nil {
+	if err := requireAuth(deps, deps.Profile, scopeBasicProfile); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 	profile, err := deps.Profile.GetByID(ctx, *id)
 	if err != nil {
 		return fmt.Errorf("profile view: %w", err)
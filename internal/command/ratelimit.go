@@ -0,0 +1,114 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/output"
+)
+
+// runRateLimit dispatches to ratelimit subcommands: status.
+func runRateLimit(args []string, deps *Deps) error {
+	if len(args) == 0 {
+		printRateLimitUsage(deps)
+		return nil
+	}
+
+	switch args[0] {
+	case "status":
+		return runRateLimitStatus(args[1:], deps)
+	case "-help", "--help", "-h":
+		printRateLimitUsage(deps)
+		return nil
+	default:
+		return usageErrorf("ratelimit: unknown subcommand %q", args[0])
+	}
+}
+
+// printRateLimitUsage writes ratelimit command help text.
+func printRateLimitUsage(deps *Deps) {
+	fmt.Fprint(deps.Stdout, `Usage: lcli ratelimit <subcommand> [flags]
+
+Subcommands:
+  status    Show client-side limits, remaining quota and reset times
+
+Limits are set per endpoint family with rate_limits, e.g.
+  lcli config set rate_limits posts=100/d,reactions=30/m
+
+Use "lcli ratelimit <subcommand> -help" for more information.
+`)
+}
+
+// runRateLimitStatus handles the ratelimit status subcommand.
+func runRateLimitStatus(args []string, deps *Deps) error {
+	fs := flag.NewFlagSet("ratelimit status", flag.ContinueOnError)
+	outputFmt := outputFlag(fs, deps)
+	fs.SetOutput(deps.Stderr)
+
+	if help, err := parseFlags(fs, args); help || err != nil {
+		return err
+	}
+	if deps.RateLimits == nil {
+		return errors.New("ratelimit status: rate limiter is not configured")
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
+
+	quotas, err := deps.RateLimits.Quotas(ctx)
+	if err != nil {
+		return fmt.Errorf("ratelimit status: %w", err)
+	}
+
+	printer, err := newPrinter(deps, *outputFmt)
+	if err != nil {
+		return err
+	}
+
+	if printer.Format() != output.FormatTable {
+		if quotas == nil {
+			quotas = []model.Quota{}
+		}
+		return printer.Print(quotas)
+	}
+
+	if len(quotas) == 0 {
+		fmt.Fprintln(deps.Stderr, "No rate limits configured and no quota observed yet.")
+		return nil
+	}
+	headers := []string{"Family", "Budget", "Available", "Limit", "Remaining", "Resets"}
+	rows := make([][]string, 0, len(quotas))
+	for _, q := range quotas {
+		limit := ""
+		if q.Limit > 0 {
+			limit = strconv.Itoa(q.Limit)
+		}
+		rows = append(rows, []string{q.Family, q.Budget, optionalInt(q.Available), limit, optionalInt(q.Remaining), resetTime(q.Reset)})
+	}
+	return printer.PrintTable(headers, rows)
+}
+
+// optionalInt formats n, or returns "-" if it is unset.
+func optionalInt(n *int) string {
+	if n == nil {
+		return "-"
+	}
+	return strconv.Itoa(*n)
+}
+
+// resetTime formats a quota reset time in local time, noting resets that
+// have already passed.
+func resetTime(t time.Time) string {
+	switch {
+	case t.IsZero():
+		return "-"
+	case t.Before(time.Now()):
+		return "passed"
+	default:
+		return t.Local().Format(time.DateTime)
+	}
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// mockQuotaReader implements QuotaReader for testing.
+type mockQuotaReader struct {
+	quotas []model.Quota
+}
+
+func (m *mockQuotaReader) Quotas(ctx context.Context) ([]model.Quota, error) {
+	return m.quotas, nil
+}
+
+func TestRateLimitStatusTable(t *testing.T) {
+	available, remaining := 4, 0
+	deps, stdout, _ := testDeps()
+	deps.RateLimits = &mockQuotaReader{quotas: []model.Quota{
+		{Family: "posts", Budget: "5/1m0s", Available: &available},
+		{Family: "reactions", Limit: 100, Remaining: &remaining, Reset: time.Now().Add(time.Hour)},
+	}}
+
+	if err := runRateLimit([]string{"status"}, deps); err != nil {
+		t.Fatalf("ratelimit status: %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{"Family", "posts", "5/1m0s", "reactions", "100"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRateLimitStatusJSON(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.RateLimits = &mockQuotaReader{}
+
+	if err := runRateLimit([]string{"status", "--output", "json"}, deps); err != nil {
+		t.Fatalf("ratelimit status: %v", err)
+	}
+	var quotas []model.Quota
+	if err := json.Unmarshal(stdout.Bytes(), &quotas); err != nil || len(quotas) != 0 {
+		t.Errorf("output = %q, err = %v", stdout.String(), err)
+	}
+}
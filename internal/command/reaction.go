@@ -20,7 +20,7 @@ func runReaction(args []string, deps *Deps) error {
 		printReactionUsage(deps)
 		return nil
 	default:
-		return fmt.Errorf("reaction: unknown subcommand %q", args[0])
+		return usageErrorf("reaction: unknown subcommand %q", args[0])
 	}
 }
 
@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 
@@ -25,26 +24,37 @@ func runReactionLike(args []string, deps *Deps) error {
 	actor := fs.String("actor", "me", "Actor URN (defaults to 'me')")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if fs.NArg() < 1 {
-		return fmt.Errorf("reaction like: post URN argument is required")
+		return usageErrorf("reaction like: post URN argument is required")
 	}
 
 	if !validReactionTypes[*reactionType] {
-		return fmt.Errorf("reaction like: invalid type %q", *reactionType)
+		return usageErrorf("reaction like: invalid type %q", *reactionType)
+	}
+	urn, err := resolveEntity("reaction like", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *actor, err = resolveActor("reaction like", *actor); err != nil {
+		return err
 	}
 
-	if err := requireAuth(deps.Reactions); err != nil {
+	if err := requireAuth(deps, deps.Reactions, writeScope(*actor)); err != nil {
 		return err
 	}
 
-	urn := fs.Arg(0)
-	ctx := context.Background()
+	if dryRun(deps, "react to %s with %s as %s", urn, *reactionType, *actor) {
+		return nil
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
 
-	err := deps.Reactions.React(ctx, *actor, urn, model.ReactionType(*reactionType))
+	err = deps.Reactions.React(ctx, *actor, urn, model.ReactionType(*reactionType))
 	if err != nil {
 		return fmt.Errorf("reaction like: %w", err)
 	}
@@ -59,20 +69,31 @@ func runReactionUnlike(args []string, deps *Deps) error {
 	actor := fs.String("actor", "me", "Actor URN (defaults to 'me')")
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if fs.NArg() < 1 {
-		return fmt.Errorf("reaction unlike: post URN argument is required")
+		return usageErrorf("reaction unlike: post URN argument is required")
+	}
+	urn, err := resolveEntity("reaction unlike", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *actor, err = resolveActor("reaction unlike", *actor); err != nil {
+		return err
 	}
 
-	if err := requireAuth(deps.Reactions); err != nil {
+	if err := requireAuth(deps, deps.Reactions, writeScope(*actor)); err != nil {
 		return err
 	}
 
-	urn := fs.Arg(0)
-	ctx := context.Background()
+	if dryRun(deps, "remove the reaction of %s from %s", *actor, urn) {
+		return nil
+	}
+
+	ctx, cancel := deps.context()
+	defer cancel()
 
 	if err := deps.Reactions.Unreact(ctx, *actor, urn); err != nil {
 		return fmt.Errorf("reaction unlike: %w", err)
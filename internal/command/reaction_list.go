@@ -1,7 +1,6 @@
 package command
 
 import (
-	"context"
 	"flag"
 	"fmt"
 
@@ -13,23 +12,27 @@ func runReactionList(args []string, deps *Deps) error {
 	fs := flag.NewFlagSet("reaction list", flag.ContinueOnError)
 	count := fs.Int("count", 10, "Number of reactions to retrieve")
 	start := fs.Int("start", 0, "Pagination start index")
-	outputFmt := fs.String("output", "table", "Output format (json/table/yaml)")
+	outputFmt := outputFlag(fs, deps)
 	fs.SetOutput(deps.Stderr)
 
-	if err := fs.Parse(args); err != nil {
+	if help, err := parseFlags(fs, args); help || err != nil {
 		return err
 	}
 
 	if fs.NArg() < 1 {
-		return fmt.Errorf("reaction list: post URN argument is required")
+		return usageErrorf("reaction list: post URN argument is required")
 	}
 
-	if err := requireAuth(deps.Reactions); err != nil {
+	urn, err := resolveEntity("reaction list", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if err := requireAuth(deps, deps.Reactions, scopeSocialRead); err != nil {
 		return err
 	}
 
-	urn := fs.Arg(0)
-	ctx := context.Background()
+	ctx, cancel := deps.context()
+	defer cancel()
 
 	list, err := deps.Reactions.List(ctx, urn, *start, *count)
 	if err != nil {
@@ -41,7 +41,7 @@ func TestReactionLikeCustomType(t *testing.T) {
 		},
 	}
 
-	if err := runReactionLike([]string{"--type", "CELEBRATE", "urn"}, deps); err != nil {
+	if err := runReactionLike([]string{"--type", "CELEBRATE", "urn:li:share:123"}, deps); err != nil {
 		t.Fatalf("runReactionLike: %v", err)
 	}
 }
@@ -60,7 +60,7 @@ func TestReactionLikeInvalidType(t *testing.T) {
 	deps, _, _ := testDeps()
 	deps.Reactions = &mockReacter{}
 
-	err := runReactionLike([]string{"--type", "INVALID", "urn"}, deps)
+	err := runReactionLike([]string{"--type", "INVALID", "urn:li:share:123"}, deps)
 	if err == nil {
 		t.Fatal("expected error for invalid type")
 	}
@@ -69,7 +69,7 @@ func TestReactionLikeInvalidType(t *testing.T) {
 func TestReactionLikeNotAuthenticated(t *testing.T) {
 	deps, _, _ := testDeps()
 
-	err := runReactionLike([]string{"urn"}, deps)
+	err := runReactionLike([]string{"urn:li:share:123"}, deps)
 	if err == nil {
 		t.Fatal("expected auth error")
 	}
@@ -110,7 +110,7 @@ func TestReactionUnlikeServiceError(t *testing.T) {
 		},
 	}
 
-	err := runReactionUnlike([]string{"urn"}, deps)
+	err := runReactionUnlike([]string{"urn:li:share:123"}, deps)
 	if err == nil {
 		t.Fatal("expected error")
 	}
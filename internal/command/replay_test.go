@@ -0,0 +1,71 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Softorize/lcli/internal/client"
+	"github.com/Softorize/lcli/internal/linkedin"
+)
+
+// replayDeps returns test deps whose services answer from the cassette in
+// testdata/cassettes/name. The cassettes were recorded with LCLI_RECORD
+// against "lcli dev fake-server", so the requests are the ones lcli sends.
+func replayDeps(t *testing.T, name string) (*Deps, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	rep, err := client.NewReplayer("testdata/cassettes/" + name)
+	if err != nil {
+		t.Fatalf("NewReplayer: %v", err)
+	}
+	cli := client.New("replay", "202601", client.WithTransport(rep))
+
+	deps, stdout, stderr := testDeps()
+	deps.Profile = linkedin.NewProfileService(cli)
+	deps.Posts = linkedin.NewPostService(cli)
+	deps.Comments = linkedin.NewCommentService(cli)
+	deps.Reactions = linkedin.NewReactionService(cli)
+	deps.Orgs = linkedin.NewOrgService(cli)
+	return deps, stdout, stderr
+}
+
+func TestReplayCommands(t *testing.T) {
+	const post = "urn:li:share:7000000000000000001"
+	tests := []struct {
+		cassette string
+		args     []string
+		want     []string
+	}{
+		{"post-create", []string{"post", "create", "--text", "Hello from a cassette"},
+			[]string{"Post created: " + post}},
+		{"post-list", []string{"post", "list"},
+			[]string{post, "Hello from a cassette", "PUBLIC"}},
+		{"comment-create", []string{"comment", "create", "--post", post, "--text", "Nice post"},
+			[]string{"Comment created: urn:li:comment:(" + post + ",2)"}},
+		{"comment-list", []string{"comment", "list", "--post", post},
+			[]string{"urn:li:person:fake-member", "Nice post"}},
+		{"reaction-like", []string{"reaction", "like", "--type", "CELEBRATE", post},
+			[]string{"Reacted to " + post + " with CELEBRATE."}},
+		{"reaction-list", []string{"reaction", "list", post},
+			[]string{"urn:li:person:fake-member", "CELEBRATE"}},
+		{"org-info", []string{"org", "info", "--id", "1001"},
+			[]string{"Fake Corp", "fakecorp", "https://fakecorp.example.com"}},
+		{"org-followers", []string{"org", "followers", "--org", "urn:li:organization:1001"},
+			[]string{"1234", "1200", "34"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cassette, func(t *testing.T) {
+			deps, stdout, stderr := replayDeps(t, tt.cassette)
+			if err := Run(tt.args, deps); err != nil {
+				t.Fatalf("Run(%v): %v", tt.args, err)
+			}
+
+			out := stdout.String() + stderr.String()
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package command
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"github.com/Softorize/lcli/internal/output"
+)
 
 // Version information set via ldflags at build time.
 var (
@@ -13,12 +18,49 @@ var (
 )
 
 // Run is the main dispatch function that routes to the appropriate subcommand.
+// It parses the global flags first, then calls deps.Init unless the command
+// needs no configuration, and dispatches.
+// Errors caused by an interrupt or a timeout are reported as such; see
+// ExitCode.
 func Run(args []string, deps *Deps) error {
+	args, err := parseGlobals(args, deps)
+	if err != nil {
+		return err
+	}
+
+	if deps.Global.Quiet {
+		// Prompts still need to be seen, or the command waits on an
+		// answer to a question it never asked.
+		deps.Prompt = deps.prompt()
+		deps.Stderr = io.Discard
+	}
+	if deps.Init != nil && len(args) > 0 && !standalone[args[0]] {
+		if err := deps.Init(deps); err != nil {
+			return err
+		}
+	}
+	if deps.Global.Output != "" {
+		format, _ := output.ParseFormat(deps.Global.Output)
+		deps.Output = output.NewPrinter(deps.Stdout, format)
+	}
+
 	if len(args) == 0 {
 		printUsage(deps)
 		return nil
 	}
 
+	return contextError(dispatch(args, deps), deps)
+}
+
+// standalone lists the commands that need neither the configuration nor the
+// API services, so they run even when loading those fails.
+var standalone = map[string]bool{
+	"help": true, "-help": true, "--help": true, "-h": true,
+	"version": true, "completion": true, "dev": true,
+}
+
+// dispatch routes args to the subcommand named by args[0].
+func dispatch(args []string, deps *Deps) error {
 	cmd := args[0]
 	sub := args[1:]
 
@@ -44,12 +86,18 @@ func Run(args []string, deps *Deps) error {
 		return runOrg(sub, deps)
 	case "analytics":
 		return runAnalytics(sub, deps)
+	case "ratelimit":
+		return runRateLimit(sub, deps)
+	case "cache":
+		return runCache(sub, deps)
+	case "dev":
+		return runDev(sub, deps)
 	case "completion":
 		return runCompletion(sub, deps)
 	case "version":
 		return runVersion(deps)
 	default:
-		return fmt.Errorf("unknown command: %s\nRun \"lcli help\" for usage", cmd)
+		return usageErrorf("unknown command: %s\nRun \"lcli help\" for usage", cmd)
 	}
 }
 
@@ -58,11 +106,30 @@ func printUsage(deps *Deps) {
 	fmt.Fprint(deps.Stdout, `lcli - LinkedIn CLI tool
 
 Usage:
-  lcli <command> [flags]
+  lcli [global flags] <command> [flags]
+
+Global flags:
+  --output FORMAT   Default output format: json, table or yaml
+  --profile NAME    Use a named profile (or set LCLI_PROFILE)
+  --timeout D       Give up on API calls after D, e.g. 30s or 2m
+  --verbose         Print extra diagnostics and a summary of each HTTP request
+  --trace           Print HTTP requests and responses in full (or set LCLI_DEBUG=1)
+  --trace-file F    Append every HTTP exchange to F as NDJSON
+  --quiet           Suppress progress messages and warnings
+  --no-color        Disable colored output (or set NO_COLOR)
+  --dry-run         Show API writes (create, delete, react, upload) without sending them
+  --no-cache        Fetch every API read from LinkedIn instead of the response cache
+  --api-version V   Use LinkedIn API version V (YYYYMM)
+  --max-retries N   Retry transient API failures up to N times (default 3)
+  --<key> VALUE     Override any config setting, e.g. --client-id or --retry-post
+                    (keys are listed by "lcli config show")
+
+Subcommand flags of the same name, e.g. "post list --output yaml", override
+the global value.
 
 Commands:
-  auth        Authenticate with LinkedIn (login, logout, status)
-  config      Configure client credentials (setup)
+  auth        Authenticate and manage profiles (login, status, switch, ...)
+  config      Configure credentials and settings (setup, show, get, set, ...)
   profile     View LinkedIn profiles
   post        Create, list, and manage posts
   comment     Manage comments on posts
@@ -70,6 +137,9 @@ Commands:
   media       Upload images and videos
   org         Manage organization pages
   analytics   View post and profile analytics
+  ratelimit   Show API rate limits and remaining quota
+  cache       Inspect and clear the API response cache
+  dev         Developer tools, such as a fake LinkedIn API server
   completion  Generate shell completion scripts
   version     Print version information
 
@@ -1,8 +1,15 @@
 package command
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/model"
 )
 
 func TestRunHelp(t *testing.T) {
@@ -58,3 +65,324 @@ func TestRunDispatchesCompletion(t *testing.T) {
 		t.Error("bash completion missing expected marker")
 	}
 }
+
+// postGetter returns a Poster whose Get returns a fixed post.
+func postGetter() *mockPoster {
+	return &mockPoster{
+		getFunc: func(_ context.Context, urn string) (*model.Post, error) {
+			return &model.Post{ID: urn, Text: "Hello"}, nil
+		},
+	}
+}
+
+func TestRunGlobalOutput(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Posts = postGetter()
+
+	if err := Run([]string{"--output", "json", "post", "get", "urn:li:share:1"}, deps); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	var post model.Post
+	if err := json.Unmarshal(stdout.Bytes(), &post); err != nil {
+		t.Fatalf("global --output json not applied: %v\n%s", err, stdout)
+	}
+	if deps.Output.Format() != "json" {
+		t.Errorf("deps.Output format = %q, want json", deps.Output.Format())
+	}
+}
+
+func TestRunSubcommandFlagOverridesGlobal(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Posts = postGetter()
+
+	if err := Run([]string{"--output", "json", "post", "get", "--output", "table", "urn:li:share:1"}, deps); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Field") {
+		t.Errorf("subcommand --output table should win:\n%s", stdout)
+	}
+}
+
+func TestRunGlobalFlagErrors(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--output", "xml", "version"}, "unknown format"},
+		{[]string{"--verbose", "--quiet", "version"}, "cannot be used with"},
+		{[]string{"--timeout", "soon", "version"}, "invalid timeout"},
+		{[]string{"--bogus", "version"}, "flag provided but not defined"},
+		{[]string{"--api-version", "2026", "version"}, "invalid API version"},
+		{[]string{"--client-secret", "s", "version"}, "flag provided but not defined"},
+	}
+	for _, tt := range tests {
+		deps, _, _ := testDeps()
+		err := Run(tt.args, deps)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Run(%v) error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRunInitSeesGlobals(t *testing.T) {
+	deps, _, _ := testDeps()
+	var got Globals
+	deps.Init = func(d *Deps) error {
+		got = d.Global
+		return nil
+	}
+
+	if err := Run([]string{"--verbose", "--dry-run", "--no-color", "--timeout", "90", "post"}, deps); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !got.Verbose || !got.DryRun || !got.NoColor || got.Timeout != 90*time.Second {
+		t.Errorf("Init saw %+v", got)
+	}
+}
+
+func TestRunTraceFlags(t *testing.T) {
+	tests := []struct {
+		args  []string
+		debug string
+		trace bool
+		file  string
+	}{
+		{[]string{"version"}, "", false, ""},
+		{[]string{"--trace", "version"}, "", true, ""},
+		{[]string{"version"}, "1", true, ""},
+		{[]string{"version"}, "0", false, ""},
+		{[]string{"--trace-file", "trace.ndjson", "version"}, "", false, "trace.ndjson"},
+	}
+	for _, tt := range tests {
+		t.Setenv("LCLI_DEBUG", tt.debug)
+		deps, _, _ := testDeps()
+		if err := Run(tt.args, deps); err != nil {
+			t.Fatalf("Run(%v): %v", tt.args, err)
+		}
+		if deps.Global.Trace != tt.trace || deps.Global.TraceFile != tt.file {
+			t.Errorf("Run(%v) with LCLI_DEBUG=%q: Trace=%v TraceFile=%q", tt.args, tt.debug, deps.Global.Trace, deps.Global.TraceFile)
+		}
+	}
+}
+
+func TestRunSubcommandHelp(t *testing.T) {
+	for _, args := range [][]string{{"post", "get", "-h"}, {"org", "info", "--help"}} {
+		deps, _, stderr := testDeps()
+		if err := Run(args, deps); err != nil {
+			t.Errorf("Run(%q) = %v, want nil", args, err)
+		}
+		if !strings.Contains(stderr.String(), "-output") {
+			t.Errorf("Run(%q) stderr = %q, want the flag usage", args, stderr)
+		}
+	}
+}
+
+func TestRunStandaloneCommandsSkipInit(t *testing.T) {
+	broken := func(*Deps) error { return errors.New("load config: broken") }
+	for _, args := range [][]string{nil, {"help"}, {"--help"}, {"version"}, {"completion", "bash"}} {
+		deps, _, _ := testDeps()
+		deps.Init = broken
+		if err := Run(args, deps); err != nil {
+			t.Errorf("Run(%q) = %v, want it to run without Init", args, err)
+		}
+	}
+
+	deps, _, _ := testDeps()
+	deps.Init = broken
+	if err := Run([]string{"post", "list"}, deps); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Run(post list) = %v, want the Init error", err)
+	}
+}
+
+func TestRunQuietSilencesStderr(t *testing.T) {
+	deps, _, stderr := testDeps()
+	deps.Posts = &mockPoster{
+		deleteFunc: func(context.Context, string) error { return nil },
+	}
+
+	if err := Run([]string{"--quiet", "post", "delete", "--confirm", "urn:li:share:1"}, deps); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want nothing with --quiet", stderr)
+	}
+}
+
+func TestRunQuietKeepsPrompts(t *testing.T) {
+	deps, _, stderr := testDeps()
+	deps.Stdin = strings.NewReader("y\n")
+	updated := false
+	deps.Posts = &mockPoster{
+		getFunc: func(_ context.Context, urn string) (*model.Post, error) {
+			return &model.Post{ID: urn, Text: "Helo"}, nil
+		},
+		updateFunc: func(context.Context, string, *model.UpdatePostRequest) error {
+			updated = true
+			return nil
+		},
+	}
+
+	if err := Run([]string{"--quiet", "post", "edit", "--text", "Hello", "urn:li:share:1"}, deps); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	out := stderr.String()
+	if !updated || !strings.Contains(out, "+Hello") || !strings.Contains(out, "[y/N]") {
+		t.Errorf("updated = %v, stderr = %q; want the diff and the prompt", updated, out)
+	}
+	if strings.Contains(out, "updated.") {
+		t.Errorf("stderr = %q, want no progress message with --quiet", out)
+	}
+}
+
+func TestRunDryRunSkipsWrites(t *testing.T) {
+	deps, stdout, _ := testDeps()
+	deps.Posts = &mockPoster{
+		deleteFunc: func(context.Context, string) error {
+			t.Error("Delete called during --dry-run")
+			return nil
+		},
+	}
+
+	if err := Run([]string{"--dry-run", "post", "delete", "--confirm", "urn:li:share:1"}, deps); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "would delete post urn:li:share:1") {
+		t.Errorf("stdout = %q", stdout)
+	}
+}
+
+func TestRunGlobalTimeout(t *testing.T) {
+	deps, _, _ := testDeps()
+	deps.Posts = &mockPoster{
+		getFunc: func(ctx context.Context, _ string) (*model.Post, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	err := Run([]string{"--timeout", "10ms", "post", "get", "urn:li:share:1"}, deps)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if !strings.Contains(err.Error(), "raise --timeout") {
+		t.Errorf("err = %q, want a --timeout hint", err)
+	}
+	if code := ExitCode(err); code != ExitTimeout {
+		t.Errorf("ExitCode = %d, want %d", code, ExitTimeout)
+	}
+}
+
+func TestRunInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	deps, _, _ := testDeps()
+	deps.Ctx = ctx
+	deps.Posts = &mockPoster{
+		getFunc: func(ctx context.Context, _ string) (*model.Post, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	err := Run([]string{"post", "get", "urn:li:share:1"}, deps)
+	if err == nil || !strings.HasPrefix(err.Error(), "interrupted") {
+		t.Fatalf("err = %v, want interrupted", err)
+	}
+	if code := ExitCode(err); code != ExitCanceled {
+		t.Errorf("ExitCode = %d, want %d", code, ExitCanceled)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{errors.New("boom"), ExitFailure},
+		{fmt.Errorf("post get: %w", context.Canceled), ExitCanceled},
+		{fmt.Errorf("post get: %w", context.DeadlineExceeded), ExitTimeout},
+		{usageErrorf("post get: post URN argument is required"), ExitUsage},
+		{errNotAuthenticated, ExitUnauthenticated},
+		{fmt.Errorf("post get: %w", &model.APIError{StatusCode: 401}), ExitUnauthenticated},
+		{errMemberOnly, ExitForbidden},
+		{&scopeError{missing: []string{scopeOrgAdmin}}, ExitForbidden},
+		{fmt.Errorf("post get: %w", &model.APIError{StatusCode: 403}), ExitForbidden},
+		{fmt.Errorf("post get: %w", &model.APIError{StatusCode: 404}), ExitNotFound},
+		{fmt.Errorf("post get: %w", &model.APIError{StatusCode: 429}), ExitRateLimited},
+		{fmt.Errorf("post get: %w", &model.APIError{StatusCode: 503}), ExitServer},
+		{fmt.Errorf("post get: %w", &model.APIError{StatusCode: 400}), ExitFailure},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestUsageErrorsExitWithUsageCode(t *testing.T) {
+	for _, args := range [][]string{
+		{"nope"},
+		{"post", "nope"},
+		{"post", "create"},
+		{"post", "list", "--no-such-flag"},
+		{"--timeout", "soon", "post", "list"},
+	} {
+		deps, _, _ := testDeps()
+		err := Run(args, deps)
+		if code := ExitCode(err); code != ExitUsage {
+			t.Errorf("Run(%v) = %v, exit code %d, want %d", args, err, code, ExitUsage)
+		}
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	var buf strings.Builder
+	apiErr := &model.APIError{StatusCode: 404, ServiceErrorCode: 100, Message: "Not Found", RequestID: "abc"}
+	if err := WriteJSONError(&buf, fmt.Errorf("post get: %w", apiErr)); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Error jsonError `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(buf.String()), &got); err != nil {
+		t.Fatalf("decode %q: %v", buf.String(), err)
+	}
+	want := jsonError{
+		Code: "not_found", ExitCode: ExitNotFound, Message: "post get: " + apiErr.Error(),
+		Status: 404, ServiceErrorCode: 100, TraceID: "abc",
+	}
+	if got.Error != want {
+		t.Errorf("error = %+v, want %+v", got.Error, want)
+	}
+}
+
+func TestJSONOutputFollowsSubcommandFlag(t *testing.T) {
+	deps, _, _ := testDeps()
+	_ = Run([]string{"post", "get", "--output", "json"}, deps)
+	if !JSONOutput(deps) {
+		t.Error("JSONOutput = false after post get --output json")
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30", 30 * time.Second, false},
+		{"2m", 2 * time.Minute, false},
+		{"1m30s", 90 * time.Second, false},
+		{"-5s", 0, true},
+		{"later", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseTimeout(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseTimeout(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// OAuth scopes declared by commands. LinkedIn grants openid, profile, email
+// and w_member_social to self-serve apps; the organization scopes require
+// the Community Management API product, and the member read scopes a
+// partner program.
+const (
+	scopeOpenID          = "openid"
+	scopeProfile         = "profile"
+	scopeBasicProfile    = "r_basicprofile"
+	scopeMemberSocial    = "w_member_social"
+	scopeMemberRead      = "r_member_social"
+	scopeConnectionsSize = "r_1st_connections_size"
+	scopeOrgSocial       = "r_organization_social"
+	scopeOrgWrite        = "w_organization_social"
+	scopeOrgAdmin        = "rw_organization_admin"
+)
+
+// Scope requirements met by any one of several scopes, written as the
+// alternatives separated by "|". The first alternative is the one suggested
+// when none is granted.
+const (
+	// scopeSocialRead reads posts, comments and reactions: a member's with
+	// r_member_social, an organization's with r_organization_social.
+	scopeSocialRead = scopeMemberRead + "|" + scopeOrgSocial
+	// scopeOrgRead reads organization pages.
+	scopeOrgRead = scopeOrgSocial + "|" + scopeOrgAdmin
+)
+
+// writeScope returns the scope needed to act as actor: w_member_social for
+// the member, w_organization_social for an organization.
+func writeScope(actor string) string {
+	if strings.HasPrefix(actor, "urn:li:organization:") {
+		return scopeOrgWrite
+	}
+	return scopeMemberSocial
+}
+
+// scopeError reports scopes a command needs that the stored token lacks.
+type scopeError struct {
+	missing []string
+}
+
+func (e *scopeError) Error() string {
+	noun := "scope"
+	if len(e.missing) > 1 {
+		noun = "scopes"
+	}
+	names := make([]string, len(e.missing))
+	suggest := make([]string, len(e.missing))
+	for i, s := range e.missing {
+		names[i] = strings.ReplaceAll(s, "|", " or ")
+		suggest[i], _, _ = strings.Cut(s, "|")
+	}
+	return fmt.Sprintf("this command needs the %s %s; re-run 'lcli auth login --scopes %s'",
+		noun, strings.Join(names, ", "), strings.Join(suggest, ","))
+}
+
+// missingScopes returns the entries of required that are not in granted. An
+// entry listing alternatives is met by any of them. An empty granted list
+// means the token did not report its scopes, in which case nothing is
+// considered missing and LinkedIn has the final say.
+func missingScopes(granted, required []string) []string {
+	if len(granted) == 0 {
+		return nil
+	}
+	var missing []string
+	for _, s := range required {
+		if !slices.ContainsFunc(strings.Split(s, "|"), func(alt string) bool { return slices.Contains(granted, alt) }) {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+func TestRequireAuthMissingScope(t *testing.T) {
+	deps, _, _ := testDeps()
+	deps.Scopes = []string{"openid", "profile", "email", "w_member_social"}
+	deps.Orgs = &mockOrgReader{
+		followerStatsFunc: func(context.Context, string) (*model.OrgFollowerStats, error) {
+			t.Fatal("FollowerStats should not be called without the scope")
+			return nil, nil
+		},
+	}
+
+	err := runOrgFollowers([]string{"--org", "urn:li:organization:1"}, deps)
+	if err == nil {
+		t.Fatal("expected scope error")
+	}
+	for _, want := range []string{"rw_organization_admin", "lcli auth login --scopes rw_organization_admin"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestRequireAuthGrantedScope(t *testing.T) {
+	deps, _, _ := testDeps()
+	deps.Scopes = []string{"rw_organization_admin"}
+	deps.Orgs = &mockOrgReader{
+		followerStatsFunc: func(context.Context, string) (*model.OrgFollowerStats, error) {
+			return &model.OrgFollowerStats{TotalCount: 7}, nil
+		},
+	}
+
+	if err := runOrgFollowers([]string{"--org", "urn:li:organization:1"}, deps); err != nil {
+		t.Fatalf("runOrgFollowers: %v", err)
+	}
+}
+
+func TestPostListOrgAuthorNeedsScope(t *testing.T) {
+	deps, _, _ := testDeps()
+	deps.Scopes = []string{"w_member_social"}
+	deps.Posts = &mockPoster{}
+
+	err := runPostList([]string{"--author", "urn:li:organization:1"}, deps)
+	if err == nil || !strings.Contains(err.Error(), "r_organization_social") {
+		t.Errorf("err = %v, want r_organization_social scope error", err)
+	}
+}
+
+func TestReactionOrgActorNeedsScope(t *testing.T) {
+	for name, run := range map[string]func([]string, *Deps) error{
+		"like":   runReactionLike,
+		"unlike": runReactionUnlike,
+	} {
+		deps, _, _ := testDeps()
+		deps.Scopes = []string{"w_member_social"}
+		deps.Reactions = &mockReacter{}
+
+		err := run([]string{"--actor", "urn:li:organization:1", "urn:li:share:1"}, deps)
+		if err == nil || !strings.Contains(err.Error(), "w_organization_social") {
+			t.Errorf("reaction %s: err = %v, want w_organization_social scope error", name, err)
+		}
+	}
+}
+
+func TestCommandScopes(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func([]string, *Deps) error
+		args []string
+		want string
+	}{
+		{"post create", runPostCreate, []string{"--text", "hi"}, "w_member_social"},
+		{"post get", runPostGet, []string{"urn:li:share:1"}, "r_member_social or r_organization_social"},
+		{"post list", runPostList, nil, "r_member_social"},
+		{"post reshare as org", runPostReshare, []string{"--as-org", "1", "urn:li:share:1"}, "w_organization_social"},
+		{"comment list", runCommentList, []string{"--post", "urn:li:share:1"}, "r_member_social or r_organization_social"},
+		{"reaction list", runReactionList, []string{"urn:li:share:1"}, "r_member_social or r_organization_social"},
+		{"org info", runOrgInfo, []string{"--id", "1001"}, "r_organization_social or rw_organization_admin"},
+		{"org info batch", runOrgInfo, []string{"--id", "1001,1002"}, "r_organization_social or rw_organization_admin"},
+		{"org followers", runOrgFollowers, []string{"--org", "1001"}, "rw_organization_admin"},
+		{"profile view", runProfileView, []string{"--id", "abc"}, "r_basicprofile"},
+		{"analytics views", runAnalyticsViews, nil, "r_1st_connections_size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, _, _ := testDeps()
+			deps.Scopes = []string{"openid"}
+			deps.Posts = &mockPoster{}
+			deps.Comments = &mockCommenter{}
+			deps.Reactions = &mockReacter{}
+			deps.Orgs = &mockOrgReader{}
+			deps.Profile = &mockProfiler{}
+			deps.Analytics = &mockAnalyticsReader{}
+
+			err := tt.run(tt.args, deps)
+			var se *scopeError
+			if !errors.As(err, &se) || !strings.Contains(err.Error(), "needs the scope "+tt.want+";") {
+				t.Errorf("err = %v, want a scope error naming %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingScopesAlternatives(t *testing.T) {
+	if got := missingScopes([]string{"r_organization_social"}, []string{scopeSocialRead}); got != nil {
+		t.Errorf("one alternative granted, missingScopes = %v", got)
+	}
+	err := &scopeError{missing: missingScopes([]string{"openid"}, []string{scopeSocialRead})}
+	if !strings.Contains(err.Error(), "--scopes r_member_social'") {
+		t.Errorf("error = %q, want the first alternative suggested", err)
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	if got := missingScopes(nil, []string{"rw_organization_admin"}); got != nil {
+		t.Errorf("unknown grants should not report missing scopes, got %v", got)
+	}
+	got := missingScopes([]string{"openid"}, []string{"openid", "profile", "email"})
+	if strings.Join(got, ",") != "profile,email" {
+		t.Errorf("missingScopes = %v, want [profile email]", got)
+	}
+}
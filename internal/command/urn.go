@@ -0,0 +1,83 @@
+package command
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/Softorize/lcli/internal/urn"
+)
+
+// resolveURN normalizes a URN given on the command line, which may also be
+// a bare ID or a linkedin.com URL, to one of the wanted types. Input that
+// cannot be resolved is reported as a usage error.
+func resolveURN(cmd, input string, want ...urn.Type) (urn.URN, error) {
+	u, err := urn.Normalize(input, want...)
+	if err != nil {
+		return urn.URN{}, usageErrorf("%s: %w", cmd, err)
+	}
+	return u, nil
+}
+
+// resolvePost resolves the URN of a post as the posts API expects it: a
+// share or ugcPost. A bare ID is taken to be a share.
+func resolvePost(cmd, input string) (string, error) {
+	u, err := resolveURN(cmd, input, urn.Posts...)
+	return u.String(), err
+}
+
+// resolveEntity resolves the URN of a post or comment to comment on or
+// react to. Activities are accepted too.
+func resolveEntity(cmd, input string) (string, error) {
+	u, err := resolveURN(cmd, input, urn.Entities...)
+	return u.String(), err
+}
+
+// resolveComment resolves a composite comment URN.
+func resolveComment(cmd, input string) (string, error) {
+	u, err := resolveURN(cmd, input, urn.Comment)
+	if err == nil && u.Parent == nil {
+		return "", usageErrorf("%s: %s does not name the post it is on (want urn:li:comment:(POST,ID), as comment list prints)", cmd, u)
+	}
+	return u.String(), err
+}
+
+// resolveOrg resolves the URN of an organization. A bare ID is taken to be
+// an organization ID.
+func resolveOrg(cmd, input string) (string, error) {
+	u, err := resolveURN(cmd, input, urn.Organization)
+	return u.String(), err
+}
+
+// resolveActor resolves the author or actor of a post, comment or
+// reaction. "me" stands for the authenticated member and is kept as is.
+func resolveActor(cmd, input string) (string, error) {
+	if input == "me" {
+		return input, nil
+	}
+	u, err := resolveURN(cmd, input, urn.Actors...)
+	return u.String(), err
+}
+
+// resolvePersonID returns the member ID in a person URN or bare ID.
+func resolvePersonID(cmd, input string) (string, error) {
+	u, err := resolveURN(cmd, input, urn.Person)
+	return u.ID, err
+}
+
+// resolveOrgID returns the organization ID in an organization URN, bare ID
+// or company URL, or the vanity name of a company URL that names the
+// organization by vanity name.
+func resolveOrgID(cmd, input string) (id int64, vanity string, err error) {
+	u, err := urn.Normalize(input, urn.Organization)
+	var ve *urn.VanityError
+	if errors.As(err, &ve) && ve.Type == urn.Organization {
+		return 0, ve.Name, nil
+	}
+	if err == nil {
+		id, err = strconv.ParseInt(u.ID, 10, 64)
+	}
+	if err != nil {
+		return 0, "", usageErrorf("%s: %w", cmd, err)
+	}
+	return id, "", nil
+}
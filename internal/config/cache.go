@@ -0,0 +1,13 @@
+package config
+
+import "path/filepath"
+
+// cacheDirName is the directory under ConfigDir() holding the response
+// cache, with one subdirectory per profile.
+const cacheDirName = "cache"
+
+// CacheDir returns the directory in which the active profile's API
+// responses are cached. It is created on first use.
+func CacheDir() string {
+	return filepath.Join(ConfigDir(), cacheDirName, ActiveProfile())
+}
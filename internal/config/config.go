@@ -1,10 +1,13 @@
 // Package config manages lcli configuration and credential storage.
 // Config is stored at ~/.config/lcli/config.json
-// Tokens are stored at ~/.config/lcli/tokens.json
+// Tokens are stored at ~/.config/lcli/tokens.json, or in the credential
+// store selected by credential_store.
+// Named profiles keep their own copies under ~/.config/lcli/profiles/<name>/
 package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,88 +20,362 @@ const (
 	tokensFileName  = "tokens.json"
 	defaultRedirect = "http://localhost:8484/callback"
 	defaultVersion  = "202601"
+	defaultRetries  = 3
 	expiryBuffer    = 5 * time.Minute
+	refreshWindow   = 24 * time.Hour
+
+	configDirEnv   = "LCLI_CONFIG_DIR"
+	accessTokenEnv = "LCLI_ACCESS_TOKEN"
 )
 
 // Config holds the LinkedIn application credentials and API settings.
 type Config struct {
+	// ClientID and ClientSecret identify the LinkedIn application.
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
-	RedirectURI  string `json:"redirect_uri"`
-	APIVersion   string `json:"api_version"`
+	// RedirectURI is the OAuth callback registered for the application.
+	RedirectURI string `json:"redirect_uri"`
+	// APIVersion is the LinkedIn-Version sent with REST API requests, in
+	// YYYYMM form.
+	APIVersion string `json:"api_version"`
+
+	// MaxRetries bounds how often transient API failures are retried.
+	MaxRetries int `json:"max_retries"`
+	// RetryPOST extends retries to POST requests, which may create
+	// duplicates.
+	RetryPOST bool `json:"retry_post"`
+
+	// PKCE marks the app as a native client that logs in with a code
+	// verifier, making ClientSecret optional.
+	PKCE bool `json:"pkce,omitempty"`
+
+	// Scopes lists OAuth scopes requested in addition to the defaults.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// OAuthBaseURL, APIBaseURL and UserinfoURL override the base of the
+	// LinkedIn OAuth endpoints, the REST API base and the OpenID Connect
+	// userinfo endpoint, e.g. to run against a fake server.
+	OAuthBaseURL string `json:"oauth_base_url,omitempty"`
+	APIBaseURL   string `json:"api_base_url,omitempty"`
+	UserinfoURL  string `json:"userinfo_url,omitempty"`
+	// AuthURL and TokenURL override the authorization and token endpoints
+	// individually.
+	AuthURL  string `json:"auth_url,omitempty"`
+	TokenURL string `json:"token_url,omitempty"`
+
+	// ProxyURL routes all HTTP traffic through a proxy instead of the one
+	// named by HTTPS_PROXY.
+	ProxyURL string `json:"proxy_url,omitempty"`
+	// CABundle names PEM certificates added to the trusted roots.
+	CABundle string `json:"ca_bundle,omitempty"`
+	// ClientCert and ClientKey name a PEM certificate and key presented
+	// for mutual TLS.
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
+
+	// RateLimits throttles API calls per endpoint family, e.g.
+	// "posts=100/d,reactions=30/m"; see ParseRateLimits.
+	RateLimits string `json:"rate_limits,omitempty"`
+
+	// CredentialStore names the backend holding the token and, unless it
+	// is the file store, the client secret.
+	CredentialStore string `json:"credential_store,omitempty"`
+
+	// SecretErr is why the client secret could not be read from the
+	// credential store, such as a missing LCLI_KEY. ClientSecret is then
+	// empty, and Save leaves the stored secret alone.
+	SecretErr error `json:"-"`
 }
 
-// Token holds OAuth 2.0 credentials obtained from LinkedIn.
+// store returns the configured credential store name, defaulting to StoreFile.
+func (c *Config) store() string {
+	if c.CredentialStore == "" {
+		return StoreFile
+	}
+	return c.CredentialStore
+}
+
+// Token types recorded in Token.Type.
+const (
+	// TokenMember is a three-legged token acting on behalf of a member.
+	TokenMember = "member"
+	// TokenApp is a two-legged token from the client-credentials grant,
+	// acting as the application itself.
+	TokenApp = "app"
+)
+
+// Token holds OAuth 2.0 credentials obtained from LinkedIn. An empty Type
+// is a member token, as written by versions before app tokens existed.
 type Token struct {
-	AccessToken  string   `json:"access_token"`
-	RefreshToken string   `json:"refresh_token"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	Scopes       []string `json:"scopes"`
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at,omitzero"`
+	Scopes           []string  `json:"scopes"`
+	Type             string    `json:"type,omitempty"`
+}
+
+// IsApp reports whether the token is an application token.
+func (t *Token) IsApp() bool {
+	return t.Type == TokenApp
 }
 
 // Valid reports whether the token is present and not expired.
 // A 5-minute buffer is applied so tokens about to expire are treated as invalid.
+// A zero ExpiresAt means the expiry is unknown, as for LCLI_ACCESS_TOKEN, and
+// the token is assumed valid.
 func (t *Token) Valid() bool {
 	if t.AccessToken == "" {
 		return false
 	}
+	if t.ExpiresAt.IsZero() {
+		return true
+	}
 	return time.Now().Add(expiryBuffer).Before(t.ExpiresAt)
 }
 
-// ConfigDir returns the path to the lcli configuration directory
-// (~/.config/lcli) and creates it if it does not exist.
+// CanRefresh reports whether the token carries a refresh token that has not
+// yet expired. A zero RefreshExpiresAt is treated as unknown and allowed.
+func (t *Token) CanRefresh() bool {
+	if t.RefreshToken == "" {
+		return false
+	}
+	return t.RefreshExpiresAt.IsZero() || time.Now().Before(t.RefreshExpiresAt)
+}
+
+// NeedsRefresh reports whether the access token expires within the next
+// 24 hours and can be renewed with its refresh token. App tokens are short
+// lived and have no refresh token; they need renewal once no longer Valid.
+func (t *Token) NeedsRefresh() bool {
+	if t.IsApp() {
+		return !t.Valid()
+	}
+	return t.CanRefresh() && time.Now().Add(refreshWindow).After(t.ExpiresAt)
+}
+
+// ConfigDir returns the path to the lcli configuration directory and
+// creates it if it does not exist. LCLI_CONFIG_DIR wins, then
+// $XDG_CONFIG_HOME/lcli, then ~/.config/lcli.
 func ConfigDir() string {
+	dir, _ := configDir()
+	_ = os.MkdirAll(dir, 0o700)
+	return dir
+}
+
+// ConfigDirSource returns the configuration directory and the layer that
+// chose it.
+func ConfigDirSource() (string, Source) {
+	return configDir()
+}
+
+// configDir resolves the configuration directory without creating it.
+func configDir() (string, Source) {
+	if dir := os.Getenv(configDirEnv); dir != "" {
+		return dir, SourceEnv
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, dirName), SourceEnv
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		home = "."
 	}
-	dir := filepath.Join(home, ".config", dirName)
-	_ = os.MkdirAll(dir, 0o700)
-	return dir
+	return filepath.Join(home, ".config", dirName), SourceDefault
 }
 
-// Load reads the configuration from config.json.
-// If the file does not exist, default values are returned.
+// Load returns the effective configuration of the active profile: defaults,
+// overridden by config.json, then by LCLI_* environment variables, then by
+// global flags recorded with SetFlag.
 func Load() (*Config, error) {
-	cfg := &Config{
+	cfg, _, err := LoadWithSources()
+	return cfg, err
+}
+
+// LoadWithSources is like Load but also reports which layer each setting
+// came from. When a credential store other than the file store is in use
+// and no override supplies one, the client secret is read from the store.
+// A store that cannot be opened or read does not fail the load; the reason
+// is recorded in SecretErr instead, so commands that need no secret work.
+func LoadWithSources() (*Config, Sources, error) {
+	profile := ActiveProfile()
+
+	cfg, sources, err := loadFile(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := applyOverrides(cfg, sources); err != nil {
+		return nil, nil, err
+	}
+
+	if sources["client_secret"] != SourceEnv && sources["client_secret"] != SourceFlag {
+		if loadSecret(profile, cfg) {
+			sources["client_secret"] = SourceStore
+		}
+	}
+	return cfg, sources, nil
+}
+
+// LoadFile returns the active profile's configuration as stored: defaults
+// and config.json plus the client secret from the credential store, without
+// environment or flag overrides. Use it to edit and Save the configuration
+// so overrides are not persisted.
+func LoadFile() (*Config, error) {
+	profile := ActiveProfile()
+
+	cfg, _, err := loadFile(profile)
+	if err != nil {
+		return nil, err
+	}
+	loadSecret(profile, cfg)
+	return cfg, nil
+}
+
+// loadSecret reads the client secret from cfg's credential store unless it
+// is the file store. It reports whether a secret was found, and records
+// why in cfg.SecretErr if the store could not be opened or read.
+func loadSecret(profile string, cfg *Config) bool {
+	if cfg.store() == StoreFile {
+		return false
+	}
+
+	store, err := OpenStore(cfg.store())
+	if err != nil {
+		cfg.SecretErr = fmt.Errorf("read client secret: %w", err)
+		return false
+	}
+	secret, err := store.Get(profile, keyClientSecret)
+	switch {
+	case err == nil:
+		cfg.ClientSecret = string(secret)
+		return true
+	case errors.Is(err, ErrCredentialNotFound):
+		return false
+	default:
+		cfg.SecretErr = fmt.Errorf("read client secret: %w", err)
+		return false
+	}
+}
+
+// defaultConfig returns the built-in defaults.
+func defaultConfig() *Config {
+	return &Config{
 		RedirectURI: defaultRedirect,
 		APIVersion:  defaultVersion,
+		MaxRetries:  defaultRetries,
+	}
+}
+
+// loadFile reads the named profile's config.json on top of the defaults.
+func loadFile(profile string) (*Config, Sources, error) {
+	cfg := defaultConfig()
+	sources := make(Sources, len(fields))
+	for _, f := range fields {
+		sources[f.Key] = SourceDefault
 	}
 
-	data, err := os.ReadFile(filepath.Join(ConfigDir(), configFileName))
+	if err := ValidateProfileName(profile); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(ProfileDir(profile), configFileName))
 	if err != nil {
 		if os.IsNotExist(err) {
-			return cfg, nil
+			return cfg, sources, nil
 		}
-		return nil, fmt.Errorf("read config: %w", err)
+		return nil, nil, fmt.Errorf("read config: %w", err)
 	}
 
 	if err := json.Unmarshal(data, cfg); err != nil {
-		return nil, fmt.Errorf("parse config: %w", err)
+		return nil, nil, fmt.Errorf("parse config: %w", err)
 	}
-	return cfg, nil
+
+	// Record the keys present in the file. An empty client secret is what
+	// Save writes when the secret lives in a credential store.
+	var present map[string]json.RawMessage
+	if err := json.Unmarshal(data, &present); err != nil {
+		return nil, nil, fmt.Errorf("parse config: %w", err)
+	}
+	for key, raw := range present {
+		if _, ok := sources[key]; !ok || (key == "client_secret" && string(raw) == `""`) {
+			continue
+		}
+		sources[key] = SourceFile
+	}
+	return cfg, sources, nil
 }
 
-// Save writes the configuration to config.json.
+// Save writes the configuration to the active profile's config.json,
+// creating the profile if it does not exist yet. With a credential store
+// other than the file store, the client secret is written to the store and
+// omitted from config.json.
 func Save(cfg *Config) error {
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	profile := ActiveProfile()
+
+	onDisk := *cfg
+	// A secret that could not be read is kept in the store untouched,
+	// rather than being deleted as if it had been cleared.
+	keepSecret := cfg.SecretErr != nil && cfg.ClientSecret == ""
+	if cfg.store() != StoreFile && !keepSecret {
+		store, err := OpenStore(cfg.store())
+		if err != nil {
+			return err
+		}
+		if cfg.ClientSecret != "" {
+			err = store.Set(profile, keyClientSecret, []byte(cfg.ClientSecret))
+		} else {
+			err = store.Delete(profile, keyClientSecret)
+		}
+		if err != nil {
+			return fmt.Errorf("write client secret: %w", err)
+		}
+	}
+	if cfg.store() != StoreFile {
+		onDisk.ClientSecret = ""
+	}
+
+	data, err := json.MarshalIndent(&onDisk, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal config: %w", err)
 	}
 
-	path := filepath.Join(ConfigDir(), configFileName)
-	if err := os.WriteFile(path, data, 0o600); err != nil {
+	dir, err := activeDir(true)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, configFileName)
+	if err := writeFileAtomic(path, data, 0o600); err != nil {
 		return fmt.Errorf("write config: %w", err)
 	}
 	return nil
 }
 
-// LoadToken reads the OAuth token from tokens.json.
-// If the file does not exist, nil is returned without error.
+// LoadToken reads the OAuth token of the active profile from its credential
+// store. If no token is stored, nil is returned without error. An access
+// token in LCLI_ACCESS_TOKEN takes precedence; its expiry is unknown.
 func LoadToken() (*Token, error) {
-	data, err := os.ReadFile(filepath.Join(ConfigDir(), tokensFileName))
+	if TokenFromEnv() {
+		return &Token{AccessToken: os.Getenv(accessTokenEnv), Type: TokenMember}, nil
+	}
+	return LoadProfileToken(ActiveProfile())
+}
+
+// TokenFromEnv reports whether LoadToken returns the LCLI_ACCESS_TOKEN token.
+func TokenFromEnv() bool {
+	return os.Getenv(accessTokenEnv) != ""
+}
+
+// LoadProfileToken reads the OAuth token stored for the named profile.
+// If no token is stored, nil is returned without error.
+func LoadProfileToken(profile string) (*Token, error) {
+	store, err := profileStore(profile)
 	if err != nil {
-		if os.IsNotExist(err) {
+		return nil, err
+	}
+
+	data, err := store.Get(profile, keyToken)
+	if err != nil {
+		if errors.Is(err, ErrCredentialNotFound) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("read token: %w", err)
@@ -111,16 +388,71 @@ func LoadToken() (*Token, error) {
 	return &tok, nil
 }
 
-// SaveToken writes the OAuth token to tokens.json.
+// SaveToken writes the OAuth token to the active profile's credential store.
+// The file-based stores replace their file atomically so a concurrent reader
+// never observes a partial token.
 func SaveToken(tok *Token) error {
 	data, err := json.MarshalIndent(tok, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal token: %w", err)
 	}
 
-	path := filepath.Join(ConfigDir(), tokensFileName)
-	if err := os.WriteFile(path, data, 0o600); err != nil {
+	profile := ActiveProfile()
+	store, err := profileStore(profile)
+	if err != nil {
+		return err
+	}
+
+	if err := store.Set(profile, keyToken, data); err != nil {
 		return fmt.Errorf("write token: %w", err)
 	}
 	return nil
 }
+
+// DeleteToken removes the active profile's stored token. It reports whether
+// a token was present.
+func DeleteToken() (bool, error) {
+	profile := ActiveProfile()
+	store, err := profileStore(profile)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := store.Get(profile, keyToken); err != nil {
+		if errors.Is(err, ErrCredentialNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read token: %w", err)
+	}
+	if err := store.Delete(profile, keyToken); err != nil {
+		return false, fmt.Errorf("remove token: %w", err)
+	}
+	return true, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and
+// renames it over path once it has been flushed to disk.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -150,3 +150,56 @@ func TestConfigDirExists(t *testing.T) {
 		t.Error("config dir is not a directory")
 	}
 }
+
+func TestTokenNeedsRefresh(t *testing.T) {
+	tests := []struct {
+		name string
+		tok  Token
+		want bool
+	}{
+		{"no refresh token", Token{AccessToken: "a", ExpiresAt: time.Now().Add(time.Hour)}, false},
+		{"far from expiry", Token{AccessToken: "a", RefreshToken: "r", ExpiresAt: time.Now().Add(30 * 24 * time.Hour)}, false},
+		{"near expiry", Token{AccessToken: "a", RefreshToken: "r", ExpiresAt: time.Now().Add(time.Hour)}, true},
+		{"expired", Token{AccessToken: "a", RefreshToken: "r", ExpiresAt: time.Now().Add(-time.Hour)}, true},
+		{"refresh token expired", Token{
+			AccessToken: "a", RefreshToken: "r",
+			ExpiresAt:        time.Now().Add(time.Hour),
+			RefreshExpiresAt: time.Now().Add(-time.Hour),
+		}, false},
+		{"app token valid", Token{AccessToken: "a", Type: TokenApp, ExpiresAt: time.Now().Add(time.Hour)}, false},
+		{"app token expired", Token{AccessToken: "a", Type: TokenApp, ExpiresAt: time.Now().Add(-time.Minute)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tok.NeedsRefresh(); got != tt.want {
+				t.Errorf("NeedsRefresh() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tokens.json")
+
+	for _, content := range []string{"first", "second"} {
+		if err := writeFileAtomic(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("writeFileAtomic: %v", err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if string(got) != content {
+			t.Errorf("content = %q, want %q", got, content)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("readdir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("dir has %d entries, want only the target file", len(entries))
+	}
+}
@@ -0,0 +1,262 @@
+// encrypted.go implements the encrypted credential store. Credentials for a
+// profile are kept in a single credentials.enc file, sealed with AES-GCM
+// under a key derived from the LCLI_KEY passphrase with PBKDF2-SHA256.
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// keyEnv names the environment variable holding the passphrase for the
+	// encrypted credential store.
+	keyEnv = "LCLI_KEY"
+
+	encryptedFileName   = "credentials.enc"
+	encryptedVersion    = 1
+	encryptedKDF        = "pbkdf2-sha256"
+	encryptedIterations = 600_000
+	encryptedSaltSize   = 16
+	encryptedKeySize    = 32
+)
+
+// PassphrasePrompt, if set, asks for the encrypted store passphrase when
+// LCLI_KEY is unset. lcli sets it when run from a terminal; it is called at
+// most once per process.
+var PassphrasePrompt func() (string, error)
+
+var (
+	promptMu    sync.Mutex
+	prompted    bool
+	promptedKey string
+	promptedErr error
+)
+
+// passphrase returns the encrypted store passphrase from LCLI_KEY, or asks
+// PassphrasePrompt for it.
+func passphrase() (string, error) {
+	if key := os.Getenv(keyEnv); key != "" {
+		return key, nil
+	}
+	promptMu.Lock()
+	defer promptMu.Unlock()
+	if PassphrasePrompt == nil {
+		return "", fmt.Errorf("encrypted credential store requires the %s environment variable", keyEnv)
+	}
+	if !prompted {
+		prompted = true
+		promptedKey, promptedErr = PassphrasePrompt()
+		if promptedErr == nil && promptedKey == "" {
+			promptedErr = errors.New("no passphrase entered")
+		}
+	}
+	if promptedErr != nil {
+		return "", fmt.Errorf("read passphrase: %w", promptedErr)
+	}
+	return promptedKey, nil
+}
+
+// ErrWrongPassphrase is returned when credentials.enc cannot be decrypted
+// with the configured passphrase.
+var ErrWrongPassphrase = errors.New("cannot decrypt credentials: wrong passphrase or corrupted file")
+
+// encryptedFile is the on-disk layout of credentials.enc.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// encryptedStore keeps all credentials of a profile in one encrypted file.
+type encryptedStore struct {
+	passphrase string
+
+	mu   sync.Mutex
+	keys map[string][]byte // derived keys by salt
+}
+
+func newEncryptedStore(passphrase string) *encryptedStore {
+	return &encryptedStore{passphrase: passphrase, keys: make(map[string][]byte)}
+}
+
+var (
+	encryptedStoresMu sync.Mutex
+	encryptedStores   = make(map[string]*encryptedStore)
+)
+
+// openEncryptedStore returns the encrypted store for passphrase, shared by
+// every caller in the process so its derived keys are computed only once.
+func openEncryptedStore(passphrase string) *encryptedStore {
+	encryptedStoresMu.Lock()
+	defer encryptedStoresMu.Unlock()
+	s, ok := encryptedStores[passphrase]
+	if !ok {
+		s = newEncryptedStore(passphrase)
+		encryptedStores[passphrase] = s
+	}
+	return s
+}
+
+func (s *encryptedStore) Name() string { return StoreEncrypted }
+
+func (s *encryptedStore) Get(profile, key string) ([]byte, error) {
+	values, _, err := s.read(profile)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := values[key]
+	if !ok {
+		return nil, ErrCredentialNotFound
+	}
+	return data, nil
+}
+
+func (s *encryptedStore) Set(profile, key string, data []byte) error {
+	values, salt, err := s.read(profile)
+	if err != nil {
+		return err
+	}
+	values[key] = data
+	return s.write(profile, values, salt)
+}
+
+func (s *encryptedStore) Delete(profile, key string) error {
+	values, salt, err := s.read(profile)
+	if err != nil {
+		return err
+	}
+	if _, ok := values[key]; !ok {
+		return nil
+	}
+	delete(values, key)
+	if len(values) == 0 {
+		if err := os.Remove(s.path(profile)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return s.write(profile, values, salt)
+}
+
+func (s *encryptedStore) path(profile string) string {
+	return filepath.Join(ProfileDir(profile), encryptedFileName)
+}
+
+// read decrypts the profile's credentials. A missing file yields an empty
+// map and a nil salt.
+func (s *encryptedStore) read(profile string) (map[string][]byte, []byte, error) {
+	values := make(map[string][]byte)
+
+	raw, err := os.ReadFile(s.path(profile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return values, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	var f encryptedFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", encryptedFileName, err)
+	}
+	if f.Version != encryptedVersion || f.KDF != encryptedKDF {
+		return nil, nil, fmt.Errorf("unsupported %s format (version %d, kdf %q)", encryptedFileName, f.Version, f.KDF)
+	}
+
+	gcm, err := s.cipher(f.Salt, f.Iterations)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(f.Nonce) != gcm.NonceSize() {
+		return nil, nil, ErrWrongPassphrase
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		return nil, nil, ErrWrongPassphrase
+	}
+
+	if err := json.Unmarshal(plain, &values); err != nil {
+		return nil, nil, fmt.Errorf("parse decrypted credentials: %w", err)
+	}
+	return values, f.Salt, nil
+}
+
+// write encrypts values with a fresh nonce and replaces the file atomically.
+// The salt is reused so the derived key stays cached; a nil salt creates a
+// new one.
+func (s *encryptedStore) write(profile string, values map[string][]byte, salt []byte) error {
+	if salt == nil {
+		salt = make([]byte, encryptedSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+	}
+
+	gcm, err := s.cipher(salt, encryptedIterations)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(encryptedFile{
+		Version:    encryptedVersion,
+		KDF:        encryptedKDF,
+		Iterations: encryptedIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plain, nil),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(ProfileDir(profile), 0o700); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(profile), data, 0o600)
+}
+
+// cipher returns an AES-GCM AEAD keyed from the passphrase and salt. Key
+// derivation is deliberately slow, so derived keys are cached.
+func (s *encryptedStore) cipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cacheKey := fmt.Sprintf("%x/%d", salt, iterations)
+	key, ok := s.keys[cacheKey]
+	if !ok {
+		var err error
+		key, err = pbkdf2.Key(sha256.New, s.passphrase, salt, iterations, encryptedKeySize)
+		if err != nil {
+			return nil, fmt.Errorf("derive key: %w", err)
+		}
+		s.keys[cacheKey] = key
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
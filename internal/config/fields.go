@@ -0,0 +1,264 @@
+// fields.go describes every Config setting so that the environment, global
+// flags and the config command can address them by key.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Source identifies the layer an effective configuration value came from.
+type Source string
+
+// Configuration layers, from lowest to highest precedence. SourceStore marks
+// a client secret read from a credential store other than the file store.
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceStore   Source = "credential store"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Field describes one configuration setting.
+type Field struct {
+	// Key is the setting's name in config.json.
+	Key string
+	// Env is the environment variable that overrides the setting.
+	Env string
+	// Usage is a one-line description.
+	Usage string
+	// Secret marks values that must be redacted when displayed.
+	Secret bool
+	// Bool marks settings whose flag takes no value.
+	Bool bool
+
+	get   func(*Config) string
+	set   func(*Config, string) error
+	check func(string) error
+}
+
+// Get returns the field's value in c, formatted as a string.
+func (f Field) Get(c *Config) string {
+	return f.get(c)
+}
+
+// Set parses value and stores it in c.
+func (f Field) Set(c *Config, value string) error {
+	if err := f.set(c, value); err != nil {
+		return fmt.Errorf("%s: %w", f.Key, err)
+	}
+	return nil
+}
+
+// Validate checks that value is acceptable for the field before it is
+// written to config.json. It is stricter than Set, which only parses.
+func (f Field) Validate(value string) error {
+	if err := f.validate(value); err != nil {
+		return fmt.Errorf("%s: %w", f.Key, err)
+	}
+	return nil
+}
+
+// validate is Validate without the key in errors.
+func (f Field) validate(value string) error {
+	if err := f.set(&Config{}, value); err != nil {
+		return err
+	}
+	if f.check != nil && value != "" {
+		return f.check(value)
+	}
+	return nil
+}
+
+// Default returns the field's built-in default value.
+func (f Field) Default() string {
+	return f.get(defaultConfig())
+}
+
+// FlagName returns the global flag that overrides the field, without dashes.
+func (f Field) FlagName() string {
+	return strings.ReplaceAll(f.Key, "_", "-")
+}
+
+// fields lists every Config setting in display order.
+var fields = []Field{
+	{
+		Key: "client_id", Env: "LCLI_CLIENT_ID", Usage: "LinkedIn app client ID",
+		get: func(c *Config) string { return c.ClientID },
+		set: func(c *Config, v string) error { c.ClientID = v; return nil },
+	},
+	{
+		Key: "client_secret", Env: "LCLI_CLIENT_SECRET", Usage: "LinkedIn app client secret", Secret: true,
+		get: func(c *Config) string { return c.ClientSecret },
+		set: func(c *Config, v string) error { c.ClientSecret = v; return nil },
+	},
+	{
+		Key: "redirect_uri", Env: "LCLI_REDIRECT_URI", Usage: "OAuth redirect URI",
+		get:   func(c *Config) string { return c.RedirectURI },
+		set:   func(c *Config, v string) error { c.RedirectURI = v; return nil },
+		check: ValidateRedirectURI,
+	},
+	{
+		Key: "api_version", Env: "LCLI_API_VERSION", Usage: "LinkedIn API version (YYYYMM)",
+		get:   func(c *Config) string { return c.APIVersion },
+		set:   func(c *Config, v string) error { c.APIVersion = v; return nil },
+		check: ValidateAPIVersion,
+	},
+	{
+		Key: "max_retries", Env: "LCLI_MAX_RETRIES", Usage: "Retries for transient API failures",
+		get: func(c *Config) string { return strconv.Itoa(c.MaxRetries) },
+		set: func(c *Config, v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid number %q", v)
+			}
+			if n < 0 {
+				return fmt.Errorf("must not be negative")
+			}
+			c.MaxRetries = n
+			return nil
+		},
+	},
+	{
+		Key: "retry_post", Env: "LCLI_RETRY_POST", Usage: "Also retry POST requests", Bool: true,
+		get: func(c *Config) string { return strconv.FormatBool(c.RetryPOST) },
+		set: boolSetter(func(c *Config, b bool) { c.RetryPOST = b }),
+	},
+	{
+		Key: "pkce", Env: "LCLI_PKCE", Usage: "Log in with PKCE as a native client", Bool: true,
+		get: func(c *Config) string { return strconv.FormatBool(c.PKCE) },
+		set: boolSetter(func(c *Config, b bool) { c.PKCE = b }),
+	},
+	{
+		Key: "scopes", Env: "LCLI_SCOPES", Usage: "Extra OAuth scopes, comma-separated",
+		get: func(c *Config) string { return strings.Join(c.Scopes, ",") },
+		set: func(c *Config, v string) error {
+			c.Scopes = strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' })
+			return nil
+		},
+	},
+	{
+		Key: "oauth_base_url", Env: "LCLI_OAUTH_BASE_URL", Usage: "OAuth endpoint base URL",
+		get:   func(c *Config) string { return c.OAuthBaseURL },
+		set:   func(c *Config, v string) error { c.OAuthBaseURL = v; return nil },
+		check: validateURL,
+	},
+	{
+		Key: "api_base_url", Env: "LCLI_API_BASE_URL", Usage: "REST API base URL",
+		get:   func(c *Config) string { return c.APIBaseURL },
+		set:   func(c *Config, v string) error { c.APIBaseURL = v; return nil },
+		check: validateURL,
+	},
+	{
+		Key: "userinfo_url", Env: "LCLI_USERINFO_URL", Usage: "OpenID Connect userinfo endpoint URL",
+		get:   func(c *Config) string { return c.UserinfoURL },
+		set:   func(c *Config, v string) error { c.UserinfoURL = v; return nil },
+		check: validateURL,
+	},
+	{
+		Key: "auth_url", Env: "LCLI_AUTH_URL", Usage: "OAuth authorization endpoint URL",
+		get:   func(c *Config) string { return c.AuthURL },
+		set:   func(c *Config, v string) error { c.AuthURL = v; return nil },
+		check: validateURL,
+	},
+	{
+		Key: "token_url", Env: "LCLI_TOKEN_URL", Usage: "OAuth token endpoint URL",
+		get:   func(c *Config) string { return c.TokenURL },
+		set:   func(c *Config, v string) error { c.TokenURL = v; return nil },
+		check: validateURL,
+	},
+	{
+		Key: "proxy_url", Env: "LCLI_PROXY_URL", Usage: "HTTP proxy URL (default from HTTPS_PROXY)",
+		get:   func(c *Config) string { return c.ProxyURL },
+		set:   func(c *Config, v string) error { c.ProxyURL = v; return nil },
+		check: validateProxyURL,
+	},
+	{
+		Key: "ca_bundle", Env: "LCLI_CA_BUNDLE", Usage: "PEM file of extra trusted CA certificates",
+		get: func(c *Config) string { return c.CABundle },
+		set: func(c *Config, v string) error { c.CABundle = v; return nil },
+	},
+	{
+		Key: "client_cert", Env: "LCLI_CLIENT_CERT", Usage: "PEM client certificate for mutual TLS",
+		get: func(c *Config) string { return c.ClientCert },
+		set: func(c *Config, v string) error { c.ClientCert = v; return nil },
+	},
+	{
+		Key: "client_key", Env: "LCLI_CLIENT_KEY", Usage: "PEM private key of the client certificate",
+		get: func(c *Config) string { return c.ClientKey },
+		set: func(c *Config, v string) error { c.ClientKey = v; return nil },
+	},
+	{
+		Key: "rate_limits", Env: "LCLI_RATE_LIMITS", Usage: "Client-side rate limits, e.g. posts=100/d,reactions=30/m",
+		get:   func(c *Config) string { return c.RateLimits },
+		set:   func(c *Config, v string) error { c.RateLimits = v; return nil },
+		check: validateRateLimits,
+	},
+	{
+		Key: "credential_store", Env: "LCLI_CREDENTIAL_STORE", Usage: "Credential store (file/encrypted/keyring)",
+		get: func(c *Config) string { return c.CredentialStore },
+		set: func(c *Config, v string) error {
+			if v != "" && !slices.Contains(StoreNames(), v) {
+				return fmt.Errorf("unknown credential store %q (use file, encrypted, or keyring)", v)
+			}
+			c.CredentialStore = v
+			return nil
+		},
+	},
+}
+
+// validateURL checks that v is an absolute http or https URL.
+func validateURL(v string) error {
+	if u, err := url.Parse(v); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q", v)
+	}
+	return nil
+}
+
+// validateProxyURL checks that v is an absolute http, https or socks5 URL.
+func validateProxyURL(v string) error {
+	u, err := url.Parse(v)
+	if err != nil || u.Host == "" || !slices.Contains([]string{"http", "https", "socks5"}, u.Scheme) {
+		return fmt.Errorf("invalid proxy URL %q", v)
+	}
+	return nil
+}
+
+// boolSetter adapts a bool assignment to a Field setter.
+func boolSetter(assign func(*Config, bool)) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", v)
+		}
+		assign(c, b)
+		return nil
+	}
+}
+
+// Fields returns every configuration setting in display order.
+func Fields() []Field {
+	return slices.Clone(fields)
+}
+
+// LookupField returns the setting with the given key.
+func LookupField(key string) (Field, bool) {
+	for _, f := range fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Redact hides a secret value, keeping only whether it is set.
+func Redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "********"
+}
@@ -0,0 +1,126 @@
+// keyring.go implements the keyring credential store on top of the
+// freedesktop Secret Service (GNOME Keyring, KWallet) through the
+// secret-tool command-line client.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	secretTool     = "secret-tool"
+	keyringService = "lcli"
+)
+
+// keyringRunner executes secret-tool with args and stdin and returns its
+// standard output, or a *secretToolError if it exits non-zero. Tests replace
+// it to avoid a real Secret Service.
+type keyringRunner func(stdin []byte, args ...string) ([]byte, error)
+
+// keyringStore keeps credentials in the Secret Service, one item per
+// profile and key.
+type keyringStore struct {
+	run keyringRunner
+}
+
+// newKeyringStore returns a keyring store, or an error if no Secret Service
+// is reachable from this session.
+func newKeyringStore() (CredentialStore, error) {
+	if _, err := exec.LookPath(secretTool); err != nil {
+		return nil, fmt.Errorf("keyring credential store is not available: %s not found (install libsecret-tools)", secretTool)
+	}
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return nil, errors.New("keyring credential store is not available: no D-Bus session bus")
+	}
+	return &keyringStore{run: runSecretTool}, nil
+}
+
+// secretToolError reports a secret-tool run that exited non-zero.
+type secretToolError struct {
+	Op       string // the secret-tool command, such as "lookup"
+	ExitCode int
+	Stdout   []byte
+	Stderr   string
+	Err      error
+}
+
+func (e *secretToolError) Error() string {
+	if e.Stderr != "" {
+		return fmt.Sprintf("%s %s: %s: %v", secretTool, e.Op, e.Stderr, e.Err)
+	}
+	return fmt.Sprintf("%s %s: %v", secretTool, e.Op, e.Err)
+}
+
+func (e *secretToolError) Unwrap() error { return e.Err }
+
+// runSecretTool is the default keyringRunner.
+func runSecretTool(stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command(secretTool, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("%s %s: %w", secretTool, args[0], err)
+		}
+		return nil, &secretToolError{
+			Op:       args[0],
+			ExitCode: exitErr.ExitCode(),
+			Stdout:   out,
+			Stderr:   strings.TrimSpace(stderr.String()),
+			Err:      err,
+		}
+	}
+	return out, nil
+}
+
+func (s *keyringStore) Name() string { return StoreKeyring }
+
+// attrs returns the attributes identifying the item for profile and key.
+func attrs(profile, key string) []string {
+	return []string{"service", keyringService, "profile", profile, "key", key}
+}
+
+func (s *keyringStore) Get(profile, key string) ([]byte, error) {
+	out, err := s.run(nil, append([]string{"lookup"}, attrs(profile, key)...)...)
+	if err != nil {
+		// secret-tool lookup exits 1 without any output when no item
+		// matches. Anything else, such as a locked keyring or a refused
+		// D-Bus call, is a real failure and must not read as logged out.
+		var toolErr *secretToolError
+		if errors.As(err, &toolErr) && toolErr.ExitCode == 1 && len(toolErr.Stdout) == 0 && toolErr.Stderr == "" {
+			return nil, ErrCredentialNotFound
+		}
+		return nil, fmt.Errorf("read %s from keyring: %w", key, err)
+	}
+	if len(out) == 0 {
+		return nil, ErrCredentialNotFound
+	}
+	return out, nil
+}
+
+func (s *keyringStore) Set(profile, key string, data []byte) error {
+	label := fmt.Sprintf("--label=lcli %s (%s)", key, profile)
+	args := append([]string{"store", label}, attrs(profile, key)...)
+	if _, err := s.run(data, args...); err != nil {
+		return fmt.Errorf("write %s to keyring: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes the item. secret-tool clear succeeds when nothing matches,
+// so any failure means the item may still be there.
+func (s *keyringStore) Delete(profile, key string) error {
+	if _, err := s.run(nil, append([]string{"clear"}, attrs(profile, key)...)...); err != nil {
+		return fmt.Errorf("delete %s from keyring: %w", key, err)
+	}
+	return nil
+}
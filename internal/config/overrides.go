@@ -0,0 +1,57 @@
+// overrides.go applies the environment and global flag layers on top of
+// config.json.
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// Sources maps each setting key to the layer its effective value came from.
+type Sources map[string]Source
+
+// flagValues holds the overrides recorded with SetFlag, keyed by Field.Key.
+var flagValues map[string]string
+
+// SetFlag records value as a global flag override for the setting key. Flag
+// overrides take precedence over config.json and the environment, and are
+// validated as strictly as "config set".
+func SetFlag(key, value string) error {
+	f, ok := LookupField(key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	if err := f.Validate(value); err != nil {
+		return err
+	}
+
+	if flagValues == nil {
+		flagValues = make(map[string]string)
+	}
+	flagValues[key] = value
+	return nil
+}
+
+// applyOverrides applies LCLI_* environment variables and then flag
+// overrides to cfg, updating sources. Environment values are validated as
+// strictly as flags.
+func applyOverrides(cfg *Config, sources Sources) error {
+	for _, f := range fields {
+		if v, ok := os.LookupEnv(f.Env); ok && v != "" {
+			if err := f.validate(v); err != nil {
+				return fmt.Errorf("%s: %w", f.Env, err)
+			}
+			f.set(cfg, v)
+			sources[f.Key] = SourceEnv
+		}
+	}
+	for _, f := range fields {
+		if v, ok := flagValues[f.Key]; ok {
+			if err := f.Set(cfg, v); err != nil {
+				return err
+			}
+			sources[f.Key] = SourceFlag
+		}
+	}
+	return nil
+}
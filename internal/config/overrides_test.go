@@ -0,0 +1,138 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// resetFlags clears SetFlag overrides when the test ends.
+func resetFlags(t *testing.T) {
+	t.Helper()
+	flagValues = nil
+	t.Cleanup(func() { flagValues = nil })
+}
+
+func TestLoadLayerPrecedence(t *testing.T) {
+	isolateProfiles(t)
+	resetFlags(t)
+	pinNow(t)
+	for _, f := range fields {
+		t.Setenv(f.Env, "")
+	}
+
+	if err := Save(&Config{ClientID: "file-id", ClientSecret: "file-secret", APIVersion: "202501", MaxRetries: 5}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	t.Setenv("LCLI_CLIENT_SECRET", "env-secret")
+	t.Setenv("LCLI_API_VERSION", "202604")
+	if err := SetFlag("api_version", "202605"); err != nil {
+		t.Fatalf("SetFlag: %v", err)
+	}
+
+	cfg, sources, err := LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources: %v", err)
+	}
+
+	tests := []struct {
+		key, value string
+		source     Source
+	}{
+		{"client_id", "file-id", SourceFile},
+		{"client_secret", "env-secret", SourceEnv},
+		{"api_version", "202605", SourceFlag},
+		{"max_retries", "5", SourceFile},
+		{"pkce", "false", SourceDefault},
+	}
+	for _, tt := range tests {
+		f, _ := LookupField(tt.key)
+		if got := f.Get(cfg); got != tt.value {
+			t.Errorf("%s = %q, want %q", tt.key, got, tt.value)
+		}
+		if sources[tt.key] != tt.source {
+			t.Errorf("%s source = %q, want %q", tt.key, sources[tt.key], tt.source)
+		}
+	}
+
+	// LoadFile ignores overrides so they are never persisted by Save.
+	stored, err := LoadFile()
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if stored.ClientSecret != "file-secret" || stored.APIVersion != "202501" {
+		t.Errorf("LoadFile = secret %q version %q, want file values", stored.ClientSecret, stored.APIVersion)
+	}
+}
+
+func TestLoadInvalidEnvValue(t *testing.T) {
+	isolateProfiles(t)
+	resetFlags(t)
+	t.Setenv("LCLI_MAX_RETRIES", "lots")
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), "LCLI_MAX_RETRIES") {
+		t.Errorf("err = %v, want LCLI_MAX_RETRIES error", err)
+	}
+
+	// Environment values are checked like "config set" values.
+	pinNow(t)
+	t.Setenv("LCLI_MAX_RETRIES", "")
+	t.Setenv("LCLI_API_VERSION", "202401")
+	_, err = Load()
+	if err == nil || !strings.Contains(err.Error(), "LCLI_API_VERSION") || !strings.Contains(err.Error(), "no longer supported") {
+		t.Errorf("err = %v, want unsupported LCLI_API_VERSION error", err)
+	}
+}
+
+func TestSetFlagValidates(t *testing.T) {
+	resetFlags(t)
+
+	if err := SetFlag("nope", "x"); err == nil {
+		t.Error("unknown key should fail")
+	}
+	if err := SetFlag("retry_post", "maybe"); err == nil {
+		t.Error("invalid boolean should fail")
+	}
+	if err := SetFlag("credential_store", "vault"); err == nil {
+		t.Error("unknown credential store should fail")
+	}
+	pinNow(t)
+	if err := SetFlag("api_version", "202612"); err == nil {
+		t.Error("API version in the future should fail")
+	}
+}
+
+func TestConfigDirOverrides(t *testing.T) {
+	isolateProfiles(t)
+	home, _ := configDir()
+
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	if dir, src := configDir(); dir != filepath.Join(xdg, dirName) || src != SourceEnv {
+		t.Errorf("with XDG_CONFIG_HOME: %q (%s)", dir, src)
+	}
+
+	custom := t.TempDir()
+	t.Setenv(configDirEnv, custom)
+	if dir, _ := configDir(); dir != custom {
+		t.Errorf("with LCLI_CONFIG_DIR: %q, want %q", dir, custom)
+	}
+
+	if home == custom {
+		t.Error("LCLI_CONFIG_DIR should change the directory")
+	}
+}
+
+func TestLoadTokenFromEnv(t *testing.T) {
+	isolateProfiles(t)
+	t.Setenv(accessTokenEnv, "env-token")
+
+	tok, err := LoadToken()
+	if err != nil {
+		t.Fatalf("LoadToken: %v", err)
+	}
+	if tok.AccessToken != "env-token" || !tok.Valid() || tok.NeedsRefresh() {
+		t.Errorf("token = %+v, want valid env token with unknown expiry", tok)
+	}
+}
@@ -0,0 +1,148 @@
+// profile.go manages named profiles. Each profile has its own config.json
+// and tokens.json. The "default" profile lives directly in ConfigDir() for
+// compatibility; other profiles live in ConfigDir()/profiles/<name>.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultProfile is the profile used when none is selected.
+	DefaultProfile = "default"
+
+	profilesDirName    = "profiles"
+	currentProfileFile = "current_profile"
+	profileEnv         = "LCLI_PROFILE"
+)
+
+// validProfileName restricts profile names to safe directory names.
+var validProfileName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// selectedProfile is the profile chosen with SelectProfile, if any.
+var selectedProfile string
+
+// ErrUnknownProfile is returned when a named profile does not exist.
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// SelectProfile makes name the active profile for the rest of the process,
+// taking precedence over LCLI_PROFILE and the current profile marker.
+func SelectProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	selectedProfile = name
+	return nil
+}
+
+// ActiveProfile returns the profile in effect. The --profile selection wins,
+// then the LCLI_PROFILE environment variable, then the marker written by
+// SetCurrentProfile, and finally DefaultProfile.
+func ActiveProfile() string {
+	if selectedProfile != "" {
+		return selectedProfile
+	}
+	if name := os.Getenv(profileEnv); name != "" {
+		return name
+	}
+	return CurrentProfile()
+}
+
+// CurrentProfile returns the profile recorded by SetCurrentProfile, or
+// DefaultProfile if none has been recorded.
+func CurrentProfile() string {
+	data, err := os.ReadFile(filepath.Join(ConfigDir(), currentProfileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+	name := strings.TrimSpace(string(data))
+	if ValidateProfileName(name) != nil {
+		return DefaultProfile
+	}
+	return name
+}
+
+// SetCurrentProfile records name as the profile to use when neither
+// --profile nor LCLI_PROFILE is given. The profile must already exist.
+func SetCurrentProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if !ProfileExists(name) {
+		return fmt.Errorf("%w %q", ErrUnknownProfile, name)
+	}
+
+	path := filepath.Join(ConfigDir(), currentProfileFile)
+	if err := writeFileAtomic(path, []byte(name+"\n"), 0o600); err != nil {
+		return fmt.Errorf("write current profile: %w", err)
+	}
+	return nil
+}
+
+// ProfileExists reports whether name is the default profile or has a
+// directory under ConfigDir()/profiles.
+func ProfileExists(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	info, err := os.Stat(ProfileDir(name))
+	return err == nil && info.IsDir()
+}
+
+// ListProfiles returns the names of all profiles, sorted, with
+// DefaultProfile always included.
+func ListProfiles() ([]string, error) {
+	names := []string{DefaultProfile}
+
+	entries, err := os.ReadDir(filepath.Join(ConfigDir(), profilesDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("list profiles: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != DefaultProfile && ValidateProfileName(e.Name()) == nil {
+			names = append(names, e.Name())
+		}
+	}
+
+	slices.Sort(names)
+	return names, nil
+}
+
+// ProfileDir returns the directory holding the files for the named profile.
+func ProfileDir(name string) string {
+	if name == DefaultProfile {
+		return ConfigDir()
+	}
+	return filepath.Join(ConfigDir(), profilesDirName, name)
+}
+
+// ValidateProfileName checks that name can be used as a profile name.
+func ValidateProfileName(name string) error {
+	if !validProfileName.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// activeDir returns the directory of the active profile, creating it if
+// create is true.
+func activeDir(create bool) (string, error) {
+	name := ActiveProfile()
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+
+	dir := ProfileDir(name)
+	if create {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return "", fmt.Errorf("create profile dir: %w", err)
+		}
+	}
+	return dir, nil
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// isolateProfiles points ConfigDir at a temporary home and clears any
+// profile selection for the duration of the test.
+func isolateProfiles(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(profileEnv, "")
+	for _, env := range []string{configDirEnv, "XDG_CONFIG_HOME", accessTokenEnv} {
+		t.Setenv(env, "")
+	}
+	selectedProfile = ""
+	t.Cleanup(func() { selectedProfile = "" })
+}
+
+func TestActiveProfilePrecedence(t *testing.T) {
+	isolateProfiles(t)
+
+	if got := ActiveProfile(); got != DefaultProfile {
+		t.Errorf("ActiveProfile() = %q, want %q", got, DefaultProfile)
+	}
+
+	if err := os.MkdirAll(ProfileDir("work"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := SetCurrentProfile("work"); err != nil {
+		t.Fatalf("SetCurrentProfile: %v", err)
+	}
+	if got := ActiveProfile(); got != "work" {
+		t.Errorf("after switch ActiveProfile() = %q, want work", got)
+	}
+
+	t.Setenv(profileEnv, "test")
+	if got := ActiveProfile(); got != "test" {
+		t.Errorf("with env ActiveProfile() = %q, want test", got)
+	}
+
+	if err := SelectProfile("flag"); err != nil {
+		t.Fatalf("SelectProfile: %v", err)
+	}
+	if got := ActiveProfile(); got != "flag" {
+		t.Errorf("with flag ActiveProfile() = %q, want flag", got)
+	}
+}
+
+func TestSetCurrentProfileUnknown(t *testing.T) {
+	isolateProfiles(t)
+
+	err := SetCurrentProfile("missing")
+	if !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("err = %v, want ErrUnknownProfile", err)
+	}
+}
+
+func TestValidateProfileName(t *testing.T) {
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{"default", true},
+		{"acme-admin", true},
+		{"test_2.old", true},
+		{"", false},
+		{"../escape", false},
+		{"with space", false},
+		{".hidden", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProfileName(tt.name)
+			if tt.ok != (err == nil) {
+				t.Errorf("ValidateProfileName(%q) = %v, want ok=%v", tt.name, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestProfilesKeepSeparateCredentials(t *testing.T) {
+	isolateProfiles(t)
+
+	save := func(profile, clientID, access string) {
+		t.Helper()
+		if err := SelectProfile(profile); err != nil {
+			t.Fatalf("SelectProfile: %v", err)
+		}
+		if err := Save(&Config{ClientID: clientID}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := SaveToken(&Token{AccessToken: access, ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+			t.Fatalf("SaveToken: %v", err)
+		}
+	}
+	save(DefaultProfile, "personal-id", "personal-tok")
+	save("company", "company-id", "company-tok")
+
+	if _, err := os.Stat(filepath.Join(ConfigDir(), profilesDirName, "company", configFileName)); err != nil {
+		t.Errorf("company config not written to profile dir: %v", err)
+	}
+
+	for _, tt := range []struct{ profile, clientID, access string }{
+		{DefaultProfile, "personal-id", "personal-tok"},
+		{"company", "company-id", "company-tok"},
+	} {
+		if err := SelectProfile(tt.profile); err != nil {
+			t.Fatalf("SelectProfile: %v", err)
+		}
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.ClientID != tt.clientID {
+			t.Errorf("%s ClientID = %q, want %q", tt.profile, cfg.ClientID, tt.clientID)
+		}
+		tok, err := LoadToken()
+		if err != nil {
+			t.Fatalf("LoadToken: %v", err)
+		}
+		if tok == nil || tok.AccessToken != tt.access {
+			t.Errorf("%s token = %+v, want %q", tt.profile, tok, tt.access)
+		}
+	}
+
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles: %v", err)
+	}
+	if !slices.Equal(names, []string{"company", DefaultProfile}) {
+		t.Errorf("ListProfiles() = %v", names)
+	}
+}
+
+func TestDeleteToken(t *testing.T) {
+	isolateProfiles(t)
+
+	if removed, err := DeleteToken(); err != nil || removed {
+		t.Errorf("DeleteToken() on empty = %v, %v; want false, nil", removed, err)
+	}
+	if err := SaveToken(&Token{AccessToken: "tok"}); err != nil {
+		t.Fatalf("SaveToken: %v", err)
+	}
+	if removed, err := DeleteToken(); err != nil || !removed {
+		t.Errorf("DeleteToken() = %v, %v; want true, nil", removed, err)
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Softorize/lcli/internal/client"
+	"github.com/Softorize/lcli/internal/model"
+)
+
+// rateLimitFileName is the per-profile file in which lcli processes share
+// their view of the API quota.
+const rateLimitFileName = "ratelimit.json"
+
+// rateLimitUnits maps the unit suffix of a rate limit to its period.
+var rateLimitUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+}
+
+// ParseRateLimits parses a rate_limits value such as
+// "posts=100/d,reactions=30/m" into limits keyed by endpoint family.
+func ParseRateLimits(s string) (map[string]client.Limit, error) {
+	limits := make(map[string]client.Limit)
+	for _, entry := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		family, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q (use FAMILY=N/UNIT, e.g. posts=100/d)", entry)
+		}
+		if !slices.Contains(model.RateLimitFamilies, family) {
+			return nil, fmt.Errorf("unknown endpoint family %q (use %s)", family, strings.Join(model.RateLimitFamilies, ", "))
+		}
+		count, unit, _ := strings.Cut(spec, "/")
+		n, err := strconv.Atoi(count)
+		per, known := rateLimitUnits[unit]
+		if err != nil || n <= 0 || !known {
+			return nil, fmt.Errorf("invalid rate limit %q for %s (use N/s, N/m, N/h or N/d)", spec, family)
+		}
+		limits[family] = client.Limit{Requests: n, Per: per}
+	}
+	return limits, nil
+}
+
+// validateRateLimits checks a rate_limits value.
+func validateRateLimits(s string) error {
+	_, err := ParseRateLimits(s)
+	return err
+}
+
+// RateLimitStatePath returns the file in which the active profile's rate
+// limit state is shared between lcli processes.
+func RateLimitStatePath() string {
+	return filepath.Join(ProfileDir(ActiveProfile()), rateLimitFileName)
+}
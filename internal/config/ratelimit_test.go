@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/client"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits("posts=100/d, reactions=30/m,statistics=2/s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]client.Limit{
+		"posts":      {Requests: 100, Per: 24 * time.Hour},
+		"reactions":  {Requests: 30, Per: time.Minute},
+		"statistics": {Requests: 2, Per: time.Second},
+	}
+	if len(limits) != len(want) {
+		t.Fatalf("limits = %v", limits)
+	}
+	for family, l := range want {
+		if limits[family] != l {
+			t.Errorf("%s = %v, want %v", family, limits[family], l)
+		}
+	}
+
+	for _, bad := range []string{"posts", "videos=1/m", "posts=0/m", "posts=ten/m", "posts=10/w", "posts=10"} {
+		if _, err := ParseRateLimits(bad); err == nil {
+			t.Errorf("ParseRateLimits(%q) should fail", bad)
+		}
+	}
+	if limits, err := ParseRateLimits(""); err != nil || len(limits) != 0 {
+		t.Errorf("empty = %v, %v", limits, err)
+	}
+}
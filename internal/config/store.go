@@ -0,0 +1,210 @@
+// store.go defines the pluggable credential store used for OAuth tokens and
+// the client secret.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Credential store backends.
+const (
+	// StoreFile keeps tokens in plaintext tokens.json and the client secret
+	// in config.json. It is the default.
+	StoreFile = "file"
+	// StoreEncrypted keeps credentials in an AES-GCM encrypted file keyed by
+	// the passphrase in LCLI_KEY, or one entered at PassphrasePrompt.
+	StoreEncrypted = "encrypted"
+	// StoreKeyring keeps credentials in the desktop Secret Service over D-Bus.
+	StoreKeyring = "keyring"
+)
+
+// Credential keys stored per profile.
+const (
+	keyToken        = "token"
+	keyClientSecret = "client_secret"
+)
+
+// ErrCredentialNotFound is returned by a CredentialStore when no value is
+// stored under the requested key.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore persists secret values for a profile.
+type CredentialStore interface {
+	// Name returns the backend identifier, e.g. StoreFile.
+	Name() string
+	// Get returns the value stored under key, or ErrCredentialNotFound.
+	Get(profile, key string) ([]byte, error)
+	// Set stores data under key, replacing any previous value.
+	Set(profile, key string, data []byte) error
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(profile, key string) error
+}
+
+// StoreNames lists the supported credential store backends.
+func StoreNames() []string {
+	return []string{StoreFile, StoreEncrypted, StoreKeyring}
+}
+
+// OpenStore returns the credential store backend with the given name. An
+// empty name selects StoreFile.
+func OpenStore(name string) (CredentialStore, error) {
+	switch name {
+	case "", StoreFile:
+		return fileStore{}, nil
+	case StoreEncrypted:
+		key, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		return openEncryptedStore(key), nil
+	case StoreKeyring:
+		return newKeyringStore()
+	default:
+		return nil, fmt.Errorf("unknown credential store %q (use file, encrypted, or keyring)", name)
+	}
+}
+
+// profileStore opens the credential store configured for profile, taking
+// environment and flag overrides into account.
+func profileStore(profile string) (CredentialStore, error) {
+	name, err := profileStoreName(profile)
+	if err != nil {
+		return nil, err
+	}
+	return OpenStore(name)
+}
+
+// profileStoreName returns the name of the credential store configured for
+// profile, taking environment and flag overrides into account.
+func profileStoreName(profile string) (string, error) {
+	cfg, sources, err := loadFile(profile)
+	if err != nil {
+		return "", err
+	}
+	if err := applyOverrides(cfg, sources); err != nil {
+		return "", err
+	}
+	return cfg.store(), nil
+}
+
+// fileStore keeps each credential in its own file in the profile directory.
+// The token uses tokens.json so existing installations keep working.
+type fileStore struct{}
+
+func (fileStore) Name() string { return StoreFile }
+
+func (fileStore) path(profile, key string) string {
+	name := key
+	if key == keyToken {
+		name = tokensFileName
+	}
+	return filepath.Join(ProfileDir(profile), name)
+}
+
+func (s fileStore) Get(profile, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(profile, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrCredentialNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s fileStore) Set(profile, key string, data []byte) error {
+	if err := os.MkdirAll(ProfileDir(profile), 0o700); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(profile, key), data, 0o600)
+}
+
+func (s fileStore) Delete(profile, key string) error {
+	if err := os.Remove(s.path(profile, key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// MigrateStore moves the active profile's token and client secret into the
+// named backend, records the choice in config.json, and removes the
+// credentials from the previous backend. If the previous backend cannot be
+// opened or read, for example because LCLI_KEY is lost, it fails unless
+// force is set, in which case only the choice is recorded and the
+// credentials are left behind. It reports whether credentials were moved.
+func MigrateStore(to string, force bool) (moved bool, err error) {
+	profile := ActiveProfile()
+
+	cfg, err := LoadFile()
+	if err != nil {
+		return false, err
+	}
+	current, err := profileStoreName(profile)
+	if err != nil {
+		return false, err
+	}
+	if current == to {
+		return false, fmt.Errorf("credentials already use the %s store", to)
+	}
+	dst, err := OpenStore(to)
+	if err != nil {
+		return false, fmt.Errorf("open destination store: %w", err)
+	}
+
+	src, token, err := readMigrationSource(profile)
+	if err == nil && cfg.SecretErr != nil {
+		err = cfg.SecretErr
+	}
+	if err != nil {
+		if !force {
+			return false, fmt.Errorf("%w (use --force to switch stores and leave the credentials behind)", err)
+		}
+		cfg.CredentialStore = to
+		cfg.SecretErr = nil
+		return false, Save(cfg)
+	}
+
+	if token != nil {
+		if err := dst.Set(profile, keyToken, token); err != nil {
+			return false, fmt.Errorf("write token: %w", err)
+		}
+	}
+
+	// Save moves the client secret into the new backend.
+	cfg.CredentialStore = to
+	if err := Save(cfg); err != nil {
+		return false, err
+	}
+
+	if token != nil {
+		if err := src.Delete(profile, keyToken); err != nil {
+			return true, fmt.Errorf("remove token from %s store: %w", src.Name(), err)
+		}
+	}
+	if src.Name() != StoreFile {
+		if err := src.Delete(profile, keyClientSecret); err != nil {
+			return true, fmt.Errorf("remove client secret from %s store: %w", src.Name(), err)
+		}
+	}
+	return true, nil
+}
+
+// readMigrationSource opens the profile's current store and reads its token,
+// which is nil if none is stored.
+func readMigrationSource(profile string) (CredentialStore, []byte, error) {
+	src, err := profileStore(profile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open source store: %w", err)
+	}
+	token, err := src.Get(profile, keyToken)
+	switch {
+	case errors.Is(err, ErrCredentialNotFound):
+		return src, nil, nil
+	case err != nil:
+		return nil, nil, fmt.Errorf("read token: %w", err)
+	}
+	return src, token, nil
+}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncryptedStoreRoundTrip(t *testing.T) {
+	isolateProfiles(t)
+
+	s := newEncryptedStore("correct horse")
+	if _, err := s.Get(DefaultProfile, keyToken); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Get on empty store: err = %v, want ErrCredentialNotFound", err)
+	}
+
+	if err := s.Set(DefaultProfile, keyToken, []byte(`{"access_token":"abc"}`)); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := s.Set(DefaultProfile, keyClientSecret, []byte("shh")); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(ConfigDir(), encryptedFileName))
+	if err != nil {
+		t.Fatalf("read credentials.enc: %v", err)
+	}
+	if strings.Contains(string(raw), "abc") || strings.Contains(string(raw), "shh") {
+		t.Error("credentials.enc contains plaintext secrets")
+	}
+
+	got, err := newEncryptedStore("correct horse").Get(DefaultProfile, keyClientSecret)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(got) != "shh" {
+		t.Errorf("Get = %q, want shh", got)
+	}
+
+	if _, err := newEncryptedStore("wrong").Get(DefaultProfile, keyToken); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("wrong passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+
+	if err := s.Delete(DefaultProfile, keyToken); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(DefaultProfile, keyClientSecret); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(ConfigDir(), encryptedFileName)); !os.IsNotExist(err) {
+		t.Errorf("credentials.enc should be removed once empty, stat err = %v", err)
+	}
+}
+
+func TestKeyringStoreCommands(t *testing.T) {
+	items := map[string]string{}
+	var calls []string
+	s := &keyringStore{run: func(stdin []byte, args ...string) ([]byte, error) {
+		calls = append(calls, strings.Join(args, " "))
+		id := strings.Join(args[len(args)-6:], " ")
+		switch args[0] {
+		case "store":
+			items[id] = string(stdin)
+		case "lookup":
+			v, ok := items[id]
+			if !ok {
+				return nil, &secretToolError{Op: "lookup", ExitCode: 1}
+			}
+			return []byte(v), nil
+		case "clear":
+			delete(items, id)
+		}
+		return nil, nil
+	}}
+
+	if _, err := s.Get("work", keyToken); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Get missing: err = %v, want ErrCredentialNotFound", err)
+	}
+	if err := s.Set("work", keyToken, []byte("tok")); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, err := s.Get("work", keyToken)
+	if err != nil || string(got) != "tok" {
+		t.Fatalf("Get = %q, %v; want tok", got, err)
+	}
+	if err := s.Delete("work", keyToken); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	want := "store --label=lcli token (work) service lcli profile work key token"
+	if calls[1] != want {
+		t.Errorf("store call = %q, want %q", calls[1], want)
+	}
+	if len(items) != 0 {
+		t.Errorf("items left after Delete: %v", items)
+	}
+}
+
+func TestKeyringStoreFailures(t *testing.T) {
+	locked := &secretToolError{Op: "lookup", ExitCode: 1, Stderr: "Cannot unlock the keyring"}
+	s := &keyringStore{run: func([]byte, ...string) ([]byte, error) { return nil, locked }}
+
+	if _, err := s.Get("work", keyToken); err == nil || errors.Is(err, ErrCredentialNotFound) ||
+		!strings.Contains(err.Error(), "Cannot unlock the keyring") {
+		t.Errorf("Get on locked keyring: err = %v, want the secret-tool error", err)
+	}
+	if err := s.Delete("work", keyToken); !errors.As(err, &locked) {
+		t.Errorf("Delete on locked keyring: err = %v, want the secret-tool error", err)
+	}
+
+	s.run = func([]byte, ...string) ([]byte, error) { return nil, &secretToolError{Op: "lookup", ExitCode: 2} }
+	if _, err := s.Get("work", keyToken); err == nil || errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Get exiting 2: err = %v, want a failure", err)
+	}
+}
+
+func TestOpenStoreEncryptedIsShared(t *testing.T) {
+	t.Setenv(keyEnv, "correct horse")
+	a, err := OpenStore(StoreEncrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := OpenStore(StoreEncrypted)
+	if a != b {
+		t.Error("OpenStore(encrypted) returned a new store for the same passphrase; derived keys would not be reused")
+	}
+
+	t.Setenv(keyEnv, "battery staple")
+	if c, _ := OpenStore(StoreEncrypted); c == a {
+		t.Error("OpenStore(encrypted) shared a store across passphrases")
+	}
+}
+
+func TestOpenStoreEncryptedRequiresKey(t *testing.T) {
+	t.Setenv(keyEnv, "")
+	if _, err := OpenStore(StoreEncrypted); err == nil || !strings.Contains(err.Error(), keyEnv) {
+		t.Errorf("OpenStore(encrypted) err = %v, want mention of %s", err, keyEnv)
+	}
+	if _, err := OpenStore("vault"); err == nil {
+		t.Error("OpenStore(vault) should fail")
+	}
+}
+
+func TestMigrateStoreFileToEncrypted(t *testing.T) {
+	isolateProfiles(t)
+	t.Setenv(keyEnv, "passphrase")
+
+	if err := Save(&Config{ClientID: "id", ClientSecret: "secret", APIVersion: "202401"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := SaveToken(&Token{AccessToken: "abc", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("SaveToken: %v", err)
+	}
+
+	if moved, err := MigrateStore(StoreEncrypted, false); err != nil || !moved {
+		t.Fatalf("MigrateStore = %v, %v", moved, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(ConfigDir(), tokensFileName)); !os.IsNotExist(err) {
+		t.Errorf("tokens.json should be removed, stat err = %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(ConfigDir(), configFileName))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if strings.Contains(string(raw), `"client_secret": "secret"`) {
+		t.Errorf("config.json still holds the client secret: %s", raw)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.ClientSecret != "secret" || cfg.CredentialStore != StoreEncrypted {
+		t.Errorf("Load = secret %q store %q", cfg.ClientSecret, cfg.CredentialStore)
+	}
+	tok, err := LoadToken()
+	if err != nil || tok == nil || tok.AccessToken != "abc" {
+		t.Fatalf("LoadToken = %+v, %v", tok, err)
+	}
+
+	if _, err := MigrateStore(StoreEncrypted, false); err == nil {
+		t.Error("migrating to the current store should fail")
+	}
+
+	if _, err := MigrateStore(StoreFile, false); err != nil {
+		t.Fatalf("MigrateStore back: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(ConfigDir(), encryptedFileName)); !os.IsNotExist(err) {
+		t.Errorf("credentials.enc should be removed, stat err = %v", err)
+	}
+	if tok, err := LoadToken(); err != nil || tok == nil || tok.AccessToken != "abc" {
+		t.Errorf("LoadToken after migrating back = %+v, %v", tok, err)
+	}
+}
+
+func TestLoadWithoutEncryptionKey(t *testing.T) {
+	isolateProfiles(t)
+	t.Setenv(keyEnv, "passphrase")
+	if err := Save(&Config{ClientID: "id", ClientSecret: "secret", CredentialStore: StoreEncrypted}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := SaveToken(&Token{AccessToken: "abc"}); err != nil {
+		t.Fatalf("SaveToken: %v", err)
+	}
+
+	t.Setenv(keyEnv, "")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load without %s: %v", keyEnv, err)
+	}
+	if cfg.ClientID != "id" || cfg.ClientSecret != "" || cfg.SecretErr == nil || !strings.Contains(cfg.SecretErr.Error(), keyEnv) {
+		t.Errorf("Load = %+v, want the settings and SecretErr naming %s", cfg, keyEnv)
+	}
+
+	// Saving an unrelated change must not drop the unreadable secret.
+	cfg.APIVersion = "202602"
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if _, err := MigrateStore(StoreFile, false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("MigrateStore without --force: err = %v, want a hint at --force", err)
+	}
+	moved, err := MigrateStore(StoreFile, true)
+	if err != nil || moved {
+		t.Fatalf("MigrateStore --force = %v, %v; want switched without moving", moved, err)
+	}
+	if cfg, err := Load(); err != nil || cfg.CredentialStore != StoreFile || cfg.SecretErr != nil || cfg.APIVersion != "202602" {
+		t.Errorf("Load after --force = %+v, %v", cfg, err)
+	}
+
+	t.Setenv(keyEnv, "passphrase")
+	s, _ := OpenStore(StoreEncrypted)
+	if secret, err := s.Get(DefaultProfile, keyClientSecret); err != nil || string(secret) != "secret" {
+		t.Errorf("secret left behind = %q, %v; want it kept", secret, err)
+	}
+}
+
+func TestEncryptedStorePromptsForPassphrase(t *testing.T) {
+	isolateProfiles(t)
+	t.Setenv(keyEnv, "passphrase")
+	if err := Save(&Config{ClientID: "id", ClientSecret: "secret", CredentialStore: StoreEncrypted}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	t.Setenv(keyEnv, "")
+	calls := 0
+	PassphrasePrompt = func() (string, error) {
+		calls++
+		return "passphrase", nil
+	}
+	t.Cleanup(func() {
+		PassphrasePrompt = nil
+		prompted, promptedKey, promptedErr = false, "", nil
+	})
+
+	for range 2 {
+		cfg, err := Load()
+		if err != nil || cfg.ClientSecret != "secret" || cfg.SecretErr != nil {
+			t.Fatalf("Load with a prompted passphrase = %+v, %v", cfg, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("prompted %d times, want once", calls)
+	}
+}
@@ -0,0 +1,175 @@
+// validate.go checks configuration values written with lcli config set and
+// lcli config edit.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// supportedVersionMonths is how long LinkedIn serves a versioned API
+// release after it ships.
+const supportedVersionMonths = 12
+
+// now returns the current time; tests replace it.
+var now = time.Now
+
+// ValidateAPIVersion checks that v is a YYYYMM version that LinkedIn still
+// supports: not later than the current month and not more than a year old.
+func ValidateAPIVersion(v string) error {
+	t, err := time.Parse("200601", v)
+	if err != nil || len(v) != 6 {
+		return fmt.Errorf("invalid API version %q: use YYYYMM, e.g. %s", v, now().Format("200601"))
+	}
+
+	current := now()
+	latest := time.Date(current.Year(), current.Month(), 1, 0, 0, 0, 0, time.UTC)
+	oldest := latest.AddDate(0, -(supportedVersionMonths - 1), 0)
+
+	switch {
+	case t.After(latest):
+		return fmt.Errorf("API version %s is in the future (latest is %s)", v, latest.Format("200601"))
+	case t.Before(oldest):
+		return fmt.Errorf("API version %s is no longer supported by LinkedIn (oldest is %s)", v, oldest.Format("200601"))
+	}
+	return nil
+}
+
+// ValidateRedirectURI checks that uri is an absolute http(s) URL. A loopback
+// URI must name its port and use the /callback path served by auth login.
+func ValidateRedirectURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid redirect URI %q: use an absolute http or https URL", uri)
+	}
+	if !isLoopback(u.Hostname()) {
+		return nil
+	}
+
+	if _, err := RedirectPort(uri); err != nil {
+		return err
+	}
+	if u.Path != "/callback" {
+		return fmt.Errorf("invalid redirect URI %q: the local callback server only serves /callback", uri)
+	}
+	return nil
+}
+
+// RedirectPort returns the explicit port of a redirect URI.
+func RedirectPort(uri string) (int, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return 0, fmt.Errorf("invalid redirect URI %q: %w", uri, err)
+	}
+	p := u.Port()
+	if p == "" {
+		return 0, fmt.Errorf("redirect URI %q has no port; auth login needs one, e.g. http://localhost:8484/callback", uri)
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("redirect URI %q has an invalid port", uri)
+	}
+	return port, nil
+}
+
+// isLoopback reports whether host refers to the local machine.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ErrStoreChange is returned when credential_store is edited directly; the
+// stored credentials would be left behind in the old store.
+var ErrStoreChange = errors.New("credential_store cannot be changed directly — use 'lcli auth migrate-store'")
+
+// Edit writes the active profile's stored configuration to a temporary file,
+// calls edit with its path, and saves the result if every changed setting
+// validates. On a validation error the edited file is kept and its path is
+// included in the error so the changes are not lost.
+func Edit(edit func(path string) error) error {
+	stored, err := LoadFile()
+	if err != nil {
+		return err
+	}
+
+	// Secrets kept in a credential store stay out of the plaintext copy;
+	// an empty client secret after editing keeps the stored one, even if
+	// it could not be read.
+	before := *stored
+	if stored.store() != StoreFile {
+		before.ClientSecret = ""
+	}
+	data, err := json.MarshalIndent(&before, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+
+	dir, err := activeDir(true)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "config-*.json")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	path := tmp.Name()
+	_, err = tmp.Write(append(data, '\n'))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := edit(path); err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	after, err := validateEdited(path, &before)
+	if err != nil {
+		return fmt.Errorf("%w (your edits are in %s)", err, path)
+	}
+	os.Remove(path)
+
+	if stored.store() != StoreFile && after.ClientSecret == "" {
+		after.ClientSecret = stored.ClientSecret
+	}
+	// A secret that could not be read is left in the store as it is.
+	after.SecretErr = stored.SecretErr
+	return Save(after)
+}
+
+// validateEdited parses the edited file and validates the settings that
+// differ from before.
+func validateEdited(path string, before *Config) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read edited config: %w", err)
+	}
+	after := defaultConfig()
+	if err := json.Unmarshal(data, after); err != nil {
+		return nil, fmt.Errorf("parse edited config: %w", err)
+	}
+	if after.store() != before.store() {
+		return nil, ErrStoreChange
+	}
+	for _, f := range fields {
+		if v := f.Get(after); v != f.Get(before) {
+			if err := f.Validate(v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return after, nil
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pinNow fixes the current time for API version checks.
+func pinNow(t *testing.T) {
+	t.Helper()
+	now = func() time.Time { return time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestValidateAPIVersion(t *testing.T) {
+	pinNow(t)
+
+	tests := []struct {
+		version string
+		wantErr string
+	}{
+		{"202610", ""},
+		{"202511", ""},
+		{"202510", "no longer supported"},
+		{"202611", "in the future"},
+		{"2026-10", "YYYYMM"},
+		{"202613", "YYYYMM"},
+		{"20261", "YYYYMM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			err := ValidateAPIVersion(tt.version)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateProxyURL(t *testing.T) {
+	f, _ := LookupField("proxy_url")
+	for _, v := range []string{"http://proxy.corp:3128", "https://proxy.corp", "socks5://127.0.0.1:1080"} {
+		if err := f.Validate(v); err != nil {
+			t.Errorf("Validate(%q): %v", v, err)
+		}
+	}
+	for _, v := range []string{"ftp://proxy:21", "proxy.corp:3128", "http://"} {
+		if err := f.Validate(v); err == nil {
+			t.Errorf("Validate(%q) should fail", v)
+		}
+	}
+}
+
+func TestValidateRedirectURI(t *testing.T) {
+	tests := []struct {
+		uri   string
+		valid bool
+	}{
+		{"http://localhost:8484/callback", true},
+		{"http://127.0.0.1:9090/callback", true},
+		{"https://example.com/oauth", true},
+		{"http://localhost/callback", false},
+		{"http://localhost:8484/cb", false},
+		{"http://localhost:99999/callback", false},
+		{"localhost:8484/callback", false},
+		{"ftp://example.com/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			if err := ValidateRedirectURI(tt.uri); (err == nil) != tt.valid {
+				t.Errorf("ValidateRedirectURI(%q) = %v, want valid=%v", tt.uri, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestEditValidatesChanges(t *testing.T) {
+	isolateProfiles(t)
+
+	if err := Save(&Config{ClientID: "id", APIVersion: defaultVersion, RedirectURI: defaultRedirect}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	var kept string
+	err := Edit(func(path string) error {
+		kept = path
+		return rewrite(path, `"client_id": "id"`, `"client_id": "new-id"`, `"redirect_uri": "`+defaultRedirect+`"`, `"redirect_uri": "not a url"`)
+	})
+	if err == nil || !strings.Contains(err.Error(), "redirect_uri") {
+		t.Fatalf("err = %v, want redirect_uri validation error", err)
+	}
+	if _, statErr := os.Stat(kept); statErr != nil {
+		t.Errorf("edited file should be kept after a validation error: %v", statErr)
+	}
+
+	if err := Edit(func(path string) error {
+		return rewrite(path, `"client_id": "id"`, `"client_id": "new-id"`)
+	}); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+	cfg, err := LoadFile()
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if cfg.ClientID != "new-id" {
+		t.Errorf("ClientID = %q, want new-id", cfg.ClientID)
+	}
+}
+
+func TestEditKeepsUnreadableSecret(t *testing.T) {
+	isolateProfiles(t)
+	t.Setenv(keyEnv, "passphrase")
+	if err := Save(&Config{ClientID: "id", ClientSecret: "secret", APIVersion: defaultVersion, RedirectURI: defaultRedirect, CredentialStore: StoreEncrypted}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	t.Setenv(keyEnv, "")
+	if err := Edit(func(path string) error {
+		return rewrite(path, `"client_id": "id"`, `"client_id": "new-id"`)
+	}); err != nil {
+		t.Fatalf("Edit without %s: %v", keyEnv, err)
+	}
+
+	t.Setenv(keyEnv, "passphrase")
+	cfg, err := LoadFile()
+	if err != nil || cfg.ClientID != "new-id" || cfg.ClientSecret != "secret" {
+		t.Errorf("LoadFile after Edit = %+v, %v; want new-id with the secret kept", cfg, err)
+	}
+}
+
+// rewrite applies old/new replacement pairs to the file at path.
+func rewrite(path string, pairs ...string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	s := string(data)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		s = strings.ReplaceAll(s, pairs[i], pairs[i+1])
+	}
+	return os.WriteFile(path, []byte(s), 0o600)
+}
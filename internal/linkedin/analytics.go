@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
+
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 // AnalyticsService provides access to LinkedIn analytics endpoints.
@@ -21,10 +22,8 @@ func NewAnalyticsService(d Doer) *AnalyticsService {
 // The returned map contains keys like "impressionCount", "clickCount",
 // "likeCount", "commentCount", "shareCount", and "engagementRate".
 func (s *AnalyticsService) PostAnalytics(ctx context.Context, postURN string) (map[string]any, error) {
-	path := fmt.Sprintf(
-		"/organizationalEntityShareStatistics?q=organizationalEntity&shares[0]=%s",
-		url.QueryEscape(postURN),
-	)
+	path := restli.URL(restli.Path("organizationalEntityShareStatistics"),
+		restli.Finder("organizationalEntity").Set("shares", restli.List{postURN}))
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -51,10 +50,14 @@ func (s *AnalyticsService) PostAnalytics(ctx context.Context, postURN string) (m
 	return raw.Elements[0].TotalShareStatistics, nil
 }
 
+// profileViewsPath asks for the size of the member's network.
+var profileViewsPath = restli.URL(restli.Path("networkSizes", "me"),
+	restli.NewQuery().Set("edgeType", "CompanyFollowedByMember"))
+
 // ProfileViews retrieves the number of profile views for the
 // authenticated user.
 func (s *AnalyticsService) ProfileViews(ctx context.Context) (int, error) {
-	resp, err := s.doer.Do(ctx, http.MethodGet, "/networkSizes/me?edgeType=CompanyFollowedByMember", nil)
+	resp, err := s.doer.Do(ctx, http.MethodGet, profileViewsPath, nil)
 	if err != nil {
 		return 0, fmt.Errorf("profile views: %w", err)
 	}
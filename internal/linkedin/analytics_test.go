@@ -34,6 +34,10 @@ func TestPostAnalyticsSuccess(t *testing.T) {
 	if stats["clickCount"] != float64(50) {
 		t.Errorf("clickCount = %v", stats["clickCount"])
 	}
+	want := "/organizationalEntityShareStatistics?q=organizationalEntity&shares=List(urn%3Ali%3Ashare%3A123)"
+	if doer.calls[0].path != want {
+		t.Errorf("path = %q, want %q", doer.calls[0].path, want)
+	}
 }
 
 func TestPostAnalyticsEmpty(t *testing.T) {
@@ -0,0 +1,98 @@
+package linkedin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
+)
+
+// maxBatchSize is the most keys LinkedIn accepts in one BATCH_GET request.
+// Longer key lists are fetched in several requests.
+const maxBatchSize = 100
+
+// batchResponse is the body of a Rest.li BATCH_GET response. Both maps are
+// keyed by the string form of the requested keys.
+type batchResponse[R any] struct {
+	Results map[string]R              `json:"results"`
+	Errors  map[string]model.APIError `json:"errors"`
+}
+
+// batchGet fetches the entities of resource with the given keys using
+// ids=List(...), maxBatchSize keys per request. restliKey returns the
+// Rest.li key of a key and convert turns a raw result into the entity.
+// Keys that LinkedIn reports as failed, or leaves out of the response,
+// are returned in the batch's Errors. Duplicate keys are fetched once.
+func batchGet[K comparable, R, T any](ctx context.Context, d Doer, resource string, keys []K,
+	restliKey func(K) any, convert func(*R) T) (*model.Batch[K, T], error) {
+	batch := &model.Batch[K, T]{Results: make(map[K]T), Errors: make(map[K]error)}
+
+	var unique []K
+	seen := make(map[K]bool)
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, k)
+		}
+	}
+
+	for start := 0; start < len(unique); start += maxBatchSize {
+		chunk := unique[start:min(start+maxBatchSize, len(unique))]
+		ids := make([]any, len(chunk))
+		byName := make(map[string]K, len(chunk))
+		for i, k := range chunk {
+			ids[i] = restliKey(k)
+			// LinkedIn keys the response by the decoded key, but accept
+			// the encoded form too.
+			enc := restli.Encode(ids[i])
+			byName[enc] = k
+			if dec, err := url.PathUnescape(enc); err == nil {
+				byName[dec] = k
+			}
+		}
+		path := restli.URL(restli.Path(resource), restli.BatchGet(ids...))
+
+		resp, err := d.Do(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkError(resp); err != nil {
+			return nil, err
+		}
+		var raw batchResponse[R]
+		if err := decodeJSON(resp, &raw); err != nil {
+			return nil, err
+		}
+		// Like other API errors, key errors name the path without the
+		// query, which would list every key of the chunk.
+		errPath, _, _ := strings.Cut(path, "?")
+		if resp.Request != nil && resp.Request.URL != nil {
+			errPath = resp.Request.URL.Path
+		}
+
+		for name, r := range raw.Results {
+			if k, ok := byName[name]; ok {
+				batch.Results[k] = convert(&r)
+			}
+		}
+		for name, apiErr := range raw.Errors {
+			if k, ok := byName[name]; ok {
+				if _, found := batch.Results[k]; !found {
+					apiErr.Method, apiErr.Path = http.MethodGet, errPath
+					batch.Errors[k] = &apiErr
+				}
+			}
+		}
+		for _, k := range chunk {
+			_, found := batch.Results[k]
+			if _, failed := batch.Errors[k]; !found && !failed {
+				batch.Errors[k] = fmt.Errorf("missing from batch response: %w", model.ErrNotFound)
+			}
+		}
+	}
+	return batch, nil
+}
@@ -0,0 +1,77 @@
+package linkedin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Softorize/lcli/internal/model"
+)
+
+func TestBatchGetChunksAndReportsErrors(t *testing.T) {
+	ids := make([]int64, 0, maxBatchSize+2)
+	for i := range maxBatchSize + 1 {
+		ids = append(ids, int64(i+1))
+	}
+	ids = append(ids, 1) // duplicates are fetched once
+
+	first := map[string]any{}
+	for i := 1; i <= maxBatchSize; i++ {
+		first[fmt.Sprint(i)] = map[string]any{"id": i, "localizedName": fmt.Sprintf("Org %d", i)}
+	}
+	delete(first, "2")
+	delete(first, "3")
+	doer := &mockDoer{responses: []mockResponse{
+		{status: 200, body: map[string]any{
+			"results": first,
+			"errors":  map[string]any{"2": map[string]any{"status": 403, "message": "not an admin"}},
+		}},
+		{status: 200, body: map[string]any{"results": map[string]any{
+			fmt.Sprint(maxBatchSize + 1): map[string]any{"id": maxBatchSize + 1},
+		}}},
+	}}
+
+	batch, err := NewOrgService(doer).GetMany(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+	if len(doer.calls) != 2 {
+		t.Fatalf("calls = %d, want 2", len(doer.calls))
+	}
+	if n := strings.Count(doer.calls[0].path, ","); n != maxBatchSize-1 {
+		t.Errorf("first request has %d keys", n+1)
+	}
+	if !strings.HasSuffix(doer.calls[1].path, fmt.Sprintf("ids=List(%d)", maxBatchSize+1)) {
+		t.Errorf("second path = %q", doer.calls[1].path)
+	}
+
+	if len(batch.Results) != maxBatchSize-1 || batch.Results[1].Name != "Org 1" {
+		t.Errorf("got %d results, 1 = %+v", len(batch.Results), batch.Results[1])
+	}
+	if len(batch.Errors) != 2 {
+		t.Fatalf("errors = %v", batch.Errors)
+	}
+	if !errors.Is(batch.Errors[2], model.ErrForbidden) {
+		t.Errorf("error for 2 = %v, want forbidden", batch.Errors[2])
+	}
+	var apiErr *model.APIError
+	if !errors.As(batch.Errors[2], &apiErr) || apiErr.Path != "/organizations" {
+		t.Errorf("error for 2 = %+v, want path /organizations", apiErr)
+	}
+	if !errors.Is(batch.Errors[3], model.ErrNotFound) {
+		t.Errorf("error for 3 = %v, want not found", batch.Errors[3])
+	}
+}
+
+func TestBatchGetRequestError(t *testing.T) {
+	doer := &mockDoer{responses: []mockResponse{
+		{status: 400, body: map[string]any{"status": 400, "message": "bad request"}},
+	}}
+
+	_, err := NewPostService(doer).GetMany(context.Background(), []string{"urn:li:share:1"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
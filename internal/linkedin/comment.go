@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
 	"time"
 
 	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
+	"github.com/Softorize/lcli/internal/urn"
 )
 
 // CommentService provides access to LinkedIn comment endpoints.
@@ -58,7 +58,7 @@ func (r *commentResponse) toComment() *model.Comment {
 
 // Create adds a new comment to a post.
 func (s *CommentService) Create(ctx context.Context, req *model.CreateCommentRequest) (*model.Comment, error) {
-	path := fmt.Sprintf("/socialActions/%s/comments", url.PathEscape(req.PostURN))
+	path := restli.Path("socialActions", req.PostURN, "comments")
 
 	body := commentBody{Actor: "me"}
 	body.Message.Text = req.Text
@@ -82,11 +82,7 @@ func (s *CommentService) Create(ctx context.Context, req *model.CreateCommentReq
 
 // List retrieves comments for a post with pagination.
 func (s *CommentService) List(ctx context.Context, postURN string, start, count int) (*model.CommentList, error) {
-	path := fmt.Sprintf("/socialActions/%s/comments?start=%s&count=%s",
-		url.PathEscape(postURN),
-		strconv.Itoa(start),
-		strconv.Itoa(count),
-	)
+	path := restli.URL(restli.Path("socialActions", postURN, "comments"), restli.NewQuery().Page(start, count))
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -113,12 +109,17 @@ func (s *CommentService) List(ctx context.Context, postURN string, start, count
 	return list, nil
 }
 
-// Delete removes a comment by its URN.
+// Delete removes a comment by its URN, urn:li:comment:(TARGET,ID), where
+// TARGET is the post the comment is on.
 func (s *CommentService) Delete(ctx context.Context, commentURN string) error {
-	path := fmt.Sprintf("/socialActions/%s/comments/%s",
-		url.PathEscape(commentURN),
-		url.PathEscape(commentURN),
-	)
+	c, err := urn.Parse(commentURN)
+	if err == nil && (c.Type != urn.Comment || c.Parent == nil) {
+		err = fmt.Errorf("want urn:li:comment:(TARGET,ID)")
+	}
+	if err != nil {
+		return fmt.Errorf("delete comment %s: %w", commentURN, err)
+	}
+	path := restli.Path("socialActions", c.Parent.String(), "comments", c.ID)
 
 	resp, err := s.doer.Do(ctx, http.MethodDelete, path, nil)
 	if err != nil {
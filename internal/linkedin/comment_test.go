@@ -86,9 +86,12 @@ func TestCommentDeleteSuccess(t *testing.T) {
 	}}
 
 	svc := NewCommentService(doer)
-	if err := svc.Delete(context.Background(), "urn:li:comment:456"); err != nil {
+	if err := svc.Delete(context.Background(), "urn:li:comment:(urn:li:activity:123,456)"); err != nil {
 		t.Fatalf("Delete: %v", err)
 	}
+	if want := "/socialActions/urn%3Ali%3Aactivity%3A123/comments/456"; doer.calls[0].path != want {
+		t.Errorf("path = %q, want %q", doer.calls[0].path, want)
+	}
 }
 
 func TestCommentDeleteError(t *testing.T) {
@@ -8,7 +8,7 @@ import (
 	"io"
 	"net/http"
 
-	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/client"
 )
 
 // Doer executes HTTP requests against the LinkedIn API.
@@ -32,29 +32,10 @@ func decodeJSON(resp *http.Response, dst any) error {
 	return nil
 }
 
-// checkError inspects the HTTP status code and returns a structured
-// APIError when the response indicates failure.
+// checkError returns a *model.APIError describing the response when its
+// status indicates failure, and nil otherwise.
 func checkError(resp *http.Response) error {
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		return nil
-	}
-
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return &model.APIError{
-			StatusCode: resp.StatusCode,
-			Message:    "failed to read error response",
-		}
-	}
-
-	apiErr := &model.APIError{StatusCode: resp.StatusCode}
-	if err := json.Unmarshal(data, apiErr); err != nil {
-		apiErr.Message = string(data)
-	}
-
-	return apiErr
+	return client.CheckResponse(resp)
 }
 
 // drainBody reads and closes the response body to allow connection reuse.
@@ -7,16 +7,23 @@ import (
 	"net/http"
 
 	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 // MediaService provides access to LinkedIn media upload endpoints.
 type MediaService struct {
 	doer Doer
+	http *http.Client
 }
 
-// NewMediaService creates a MediaService backed by the given Doer.
-func NewMediaService(d Doer) *MediaService {
-	return &MediaService{doer: d}
+// NewMediaService creates a MediaService backed by the given Doer. Uploads,
+// which go to a pre-signed URL rather than the API, are sent with hc, or
+// http.DefaultClient if hc is nil.
+func NewMediaService(d Doer, hc *http.Client) *MediaService {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &MediaService{doer: d, http: hc}
 }
 
 // initUploadRequest is the request body for initializing a media upload.
@@ -43,17 +50,18 @@ type initUploadResponse struct {
 // InitUpload initializes a media upload and returns the upload details.
 // mediaType must be "IMAGE", "VIDEO", or "DOCUMENT".
 func (s *MediaService) InitUpload(ctx context.Context, owner string, mediaType string) (*model.MediaUpload, error) {
-	var path string
+	var resource string
 	switch mediaType {
 	case "IMAGE":
-		path = "/images?action=initializeUpload"
+		resource = "images"
 	case "VIDEO":
-		path = "/videos?action=initializeUpload"
+		resource = "videos"
 	case "DOCUMENT":
-		path = "/documents?action=initializeUpload"
+		resource = "documents"
 	default:
 		return nil, fmt.Errorf("init upload: unsupported media type %q", mediaType)
 	}
+	path := restli.URL(restli.Path(resource), restli.Action("initializeUpload"))
 
 	body := initUploadRequest{
 		InitializeUploadRequest: initUploadOwner{Owner: owner},
@@ -95,23 +103,22 @@ func (s *MediaService) Upload(ctx context.Context, uploadURL string, data io.Rea
 		return fmt.Errorf("build upload request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.http.Do(req)
 	if err != nil {
 		return fmt.Errorf("upload media: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("upload media: status %d: %s", resp.StatusCode, body)
+	if err := checkError(resp); err != nil {
+		return fmt.Errorf("upload media: %w", err)
 	}
+	drainBody(resp)
 
 	return nil
 }
 
 // GetStatus retrieves the processing status of an uploaded media asset.
 func (s *MediaService) GetStatus(ctx context.Context, mediaURN string) (*model.MediaStatus, error) {
-	path := fmt.Sprintf("/assets/%s", mediaURN)
+	path := restli.Path("assets", mediaURN)
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
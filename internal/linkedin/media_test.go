@@ -2,7 +2,14 @@ package linkedin
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/Softorize/lcli/internal/model"
 )
 
 func TestInitUploadImage(t *testing.T) {
@@ -15,7 +22,7 @@ func TestInitUploadImage(t *testing.T) {
 		}},
 	}}
 
-	svc := NewMediaService(doer)
+	svc := NewMediaService(doer, nil)
 	upload, err := svc.InitUpload(context.Background(), "me", "IMAGE")
 	if err != nil {
 		t.Fatalf("InitUpload: %v", err)
@@ -40,7 +47,7 @@ func TestInitUploadVideo(t *testing.T) {
 		}},
 	}}
 
-	svc := NewMediaService(doer)
+	svc := NewMediaService(doer, nil)
 	upload, err := svc.InitUpload(context.Background(), "me", "VIDEO")
 	if err != nil {
 		t.Fatalf("InitUpload: %v", err)
@@ -57,7 +64,7 @@ func TestInitUploadVideo(t *testing.T) {
 func TestInitUploadUnsupportedType(t *testing.T) {
 	doer := &mockDoer{}
 
-	svc := NewMediaService(doer)
+	svc := NewMediaService(doer, nil)
 	_, err := svc.InitUpload(context.Background(), "me", "AUDIO")
 	if err == nil {
 		t.Fatal("expected error for unsupported type")
@@ -69,7 +76,7 @@ func TestInitUploadAPIError(t *testing.T) {
 		{status: 403, body: map[string]any{"status": 403, "message": "forbidden"}},
 	}}
 
-	svc := NewMediaService(doer)
+	svc := NewMediaService(doer, nil)
 	_, err := svc.InitUpload(context.Background(), "me", "IMAGE")
 	if err == nil {
 		t.Fatal("expected error")
@@ -84,7 +91,7 @@ func TestGetStatusSuccess(t *testing.T) {
 		}},
 	}}
 
-	svc := NewMediaService(doer)
+	svc := NewMediaService(doer, nil)
 	status, err := svc.GetStatus(context.Background(), "urn:li:image:abc")
 	if err != nil {
 		t.Fatalf("GetStatus: %v", err)
@@ -99,9 +106,71 @@ func TestGetStatusError(t *testing.T) {
 		{status: 404, body: map[string]any{"status": 404, "message": "not found"}},
 	}}
 
-	svc := NewMediaService(doer)
+	svc := NewMediaService(doer, nil)
 	_, err := svc.GetStatus(context.Background(), "urn")
 	if err == nil {
 		t.Fatal("expected error")
 	}
 }
+
+func TestUploadAbortsOnCancel(t *testing.T) {
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		_, _ = io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	err := NewMediaService(&mockDoer{}, nil).Upload(ctx, srv.URL, strings.NewReader("video bytes"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestUploadErrorIsAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"status":403,"code":"ACCESS_DENIED","message":"Upload URL expired"}`))
+	}))
+	defer srv.Close()
+
+	err := NewMediaService(&mockDoer{}, nil).Upload(context.Background(), srv.URL+"/upload/1", strings.NewReader("bytes"))
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %T %v, want *model.APIError", err, err)
+	}
+	if !errors.Is(err, model.ErrForbidden) || apiErr.Method != http.MethodPut || apiErr.Message != "Upload URL expired" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
+
+// countingTransport counts the requests it sends.
+type countingTransport struct{ n int }
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.n++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestUploadUsesGivenClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	transport := &countingTransport{}
+	svc := NewMediaService(&mockDoer{}, &http.Client{Transport: transport})
+	if err := svc.Upload(context.Background(), srv.URL, strings.NewReader("bytes")); err != nil {
+		t.Fatal(err)
+	}
+	if transport.n != 1 {
+		t.Errorf("requests through the client = %d, want 1", transport.n)
+	}
+}
@@ -4,10 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
 
 	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 // OrgService provides access to LinkedIn organization endpoints.
@@ -46,7 +45,7 @@ func (r *orgResponse) toOrg() *model.Organization {
 
 // Get retrieves an organization by its numeric ID.
 func (s *OrgService) Get(ctx context.Context, id int64) (*model.Organization, error) {
-	path := fmt.Sprintf("/organizations/%s", strconv.FormatInt(id, 10))
+	path := restli.Path("organizations", id)
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -65,9 +64,21 @@ func (s *OrgService) Get(ctx context.Context, id int64) (*model.Organization, er
 	return raw.toOrg(), nil
 }
 
+// GetMany retrieves many organizations by numeric ID with BATCH_GET
+// requests. Organizations that could not be fetched are reported per ID in
+// the batch's Errors.
+func (s *OrgService) GetMany(ctx context.Context, ids []int64) (*model.Batch[int64, *model.Organization], error) {
+	batch, err := batchGet(ctx, s.doer, "organizations", ids,
+		func(id int64) any { return id }, (*orgResponse).toOrg)
+	if err != nil {
+		return nil, fmt.Errorf("get %d orgs: %w", len(ids), err)
+	}
+	return batch, nil
+}
+
 // GetByVanity retrieves an organization by its vanity name (URL slug).
 func (s *OrgService) GetByVanity(ctx context.Context, vanityName string) (*model.Organization, error) {
-	path := fmt.Sprintf("/organizations?q=vanityName&vanityName=%s", url.QueryEscape(vanityName))
+	path := restli.URL(restli.Path("organizations"), restli.Finder("vanityName").Set("vanityName", vanityName))
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -4,17 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 // FollowerStats retrieves follower statistics for an organization.
 func (s *OrgService) FollowerStats(ctx context.Context, orgURN string) (*model.OrgFollowerStats, error) {
-	path := fmt.Sprintf(
-		"/organizationalEntityFollowerStatistics?q=organizationalEntity&organizationalEntity=%s",
-		url.QueryEscape(orgURN),
-	)
+	path := restli.URL(restli.Path("organizationalEntityFollowerStatistics"),
+		restli.Finder("organizationalEntity").Set("organizationalEntity", orgURN))
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -75,10 +73,8 @@ func (r *followerStatsResponse) toStats() *model.OrgFollowerStats {
 
 // PageStats retrieves page view statistics for an organization.
 func (s *OrgService) PageStats(ctx context.Context, orgURN string) (*model.OrgPageStats, error) {
-	path := fmt.Sprintf(
-		"/organizationPageStatistics?q=organization&organization=%s",
-		url.QueryEscape(orgURN),
-	)
+	path := restli.URL(restli.Path("organizationPageStatistics"),
+		restli.Finder("organization").Set("organization", orgURN))
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -4,11 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
 	"time"
 
 	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 // PostService provides access to LinkedIn post (UGC) endpoints.
@@ -29,6 +28,7 @@ type postBody struct {
 	Distribution   postDistribution `json:"distribution"`
 	LifecycleState string           `json:"lifecycleState"`
 	Content        *postContent     `json:"content,omitempty"`
+	ReshareContext *reshareContext  `json:"reshareContext,omitempty"`
 }
 
 // postDistribution controls the feed distribution of a post.
@@ -47,6 +47,13 @@ type postMedia struct {
 	Title string `json:"title,omitempty"`
 }
 
+// reshareContext links a reshare to the post it reshares (parent) and the
+// original post of the chain (root). Only parent is sent on create.
+type reshareContext struct {
+	Parent string `json:"parent"`
+	Root   string `json:"root,omitempty"`
+}
+
 // postResponse is the raw API response for a single post.
 type postResponse struct {
 	ID             string `json:"id"`
@@ -63,6 +70,7 @@ type postResponse struct {
 			ID string `json:"id"`
 		} `json:"media"`
 	} `json:"content"`
+	ReshareContext *reshareContext `json:"reshareContext"`
 }
 
 // toPost converts a raw API response into a domain Post.
@@ -84,10 +92,16 @@ func (r *postResponse) toPost() *model.Post {
 		p.MediaCategory = "IMAGE"
 	}
 
+	if r.ReshareContext != nil {
+		p.ReshareParent = r.ReshareContext.Parent
+		p.ReshareRoot = r.ReshareContext.Root
+	}
+
 	return p
 }
 
-// Create publishes a new post on LinkedIn.
+// Create publishes a new post on LinkedIn. With req.ReshareParent set it
+// reshares that post, with req.Text as optional commentary.
 func (s *PostService) Create(ctx context.Context, req *model.CreatePostRequest) (*model.Post, error) {
 	author := req.AuthorURN
 	if author == "" {
@@ -110,7 +124,11 @@ func (s *PostService) Create(ctx context.Context, req *model.CreatePostRequest)
 		}
 	}
 
-	resp, err := s.doer.Do(ctx, http.MethodPost, "/posts", body)
+	if req.ReshareParent != "" {
+		body.ReshareContext = &reshareContext{Parent: req.ReshareParent}
+	}
+
+	resp, err := s.doer.Do(ctx, http.MethodPost, restli.Path("posts"), body)
 	if err != nil {
 		return nil, fmt.Errorf("create post: %w", err)
 	}
@@ -134,6 +152,7 @@ func (s *PostService) Create(ctx context.Context, req *model.CreatePostRequest)
 			Text:           req.Text,
 			Visibility:     req.Visibility,
 			LifecycleState: "PUBLISHED",
+			ReshareParent:  req.ReshareParent,
 		}, nil
 	}
 
@@ -147,7 +166,7 @@ func (s *PostService) Create(ctx context.Context, req *model.CreatePostRequest)
 
 // Get retrieves a single post by its URN.
 func (s *PostService) Get(ctx context.Context, urn string) (*model.Post, error) {
-	path := fmt.Sprintf("/posts/%s", url.PathEscape(urn))
+	path := restli.Path("posts", urn)
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -166,9 +185,61 @@ func (s *PostService) Get(ctx context.Context, urn string) (*model.Post, error)
 	return raw.toPost(), nil
 }
 
+// GetMany retrieves many posts by URN with BATCH_GET requests. Posts that
+// could not be fetched are reported per URN in the batch's Errors.
+func (s *PostService) GetMany(ctx context.Context, urns []string) (*model.Batch[string, *model.Post], error) {
+	batch, err := batchGet(ctx, s.doer, "posts", urns,
+		func(urn string) any { return urn }, (*postResponse).toPost)
+	if err != nil {
+		return nil, fmt.Errorf("get %d posts: %w", len(urns), err)
+	}
+	return batch, nil
+}
+
+// Update changes the fields of a post set in req with a PARTIAL_UPDATE.
+// LinkedIn allows the commentary, the content call-to-action and the
+// lifecycle state of a published post to be changed. An empty
+// call-to-action label or landing page is removed with $delete.
+func (s *PostService) Update(ctx context.Context, urn string, req *model.UpdatePostRequest) error {
+	patch := restli.Patch{Set: make(map[string]any)}
+	for _, f := range []struct {
+		field     string
+		value     *string
+		removable bool
+	}{
+		{"commentary", req.Text, false},
+		{"contentCallToActionLabel", req.CallToActionLabel, true},
+		{"contentLandingPage", req.LandingPage, true},
+		{"lifecycleState", req.LifecycleState, false},
+	} {
+		switch {
+		case f.value == nil:
+		case *f.value == "" && f.removable:
+			patch.Delete = append(patch.Delete, f.field)
+		default:
+			patch.Set[f.field] = *f.value
+		}
+	}
+	if patch.Empty() {
+		return fmt.Errorf("update post %s: no fields to change", urn)
+	}
+
+	resp, err := s.doer.Do(ctx, http.MethodPost, restli.Path("posts", urn), patch)
+	if err != nil {
+		return fmt.Errorf("update post %s: %w", urn, err)
+	}
+
+	if err := checkError(resp); err != nil {
+		return fmt.Errorf("update post %s: %w", urn, err)
+	}
+
+	drainBody(resp)
+	return nil
+}
+
 // Delete removes a post by its URN.
 func (s *PostService) Delete(ctx context.Context, urn string) error {
-	path := fmt.Sprintf("/posts/%s", url.PathEscape(urn))
+	path := restli.Path("posts", urn)
 
 	resp, err := s.doer.Do(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -185,11 +256,7 @@ func (s *PostService) Delete(ctx context.Context, urn string) error {
 
 // ListByAuthor returns posts authored by the given URN with pagination.
 func (s *PostService) ListByAuthor(ctx context.Context, authorURN string, start, count int) (*model.PostList, error) {
-	path := fmt.Sprintf("/posts?author=%s&q=author&start=%s&count=%s",
-		url.QueryEscape(authorURN),
-		strconv.Itoa(start),
-		strconv.Itoa(count),
-	)
+	path := restli.URL(restli.Path("posts"), restli.Finder("author").Set("author", authorURN).Page(start, count))
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -2,9 +2,11 @@ package linkedin
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 func TestPostCreateSuccess(t *testing.T) {
@@ -58,6 +60,34 @@ func TestPostCreateWithMedia(t *testing.T) {
 	}
 }
 
+func TestPostCreateReshare(t *testing.T) {
+	doer := &mockDoer{responses: []mockResponse{
+		{status: 200, body: map[string]any{
+			"id":             "urn:li:share:2",
+			"commentary":     "Worth a read",
+			"reshareContext": map[string]any{"parent": "urn:li:share:1", "root": "urn:li:ugcPost:0"},
+		}},
+	}}
+
+	svc := NewPostService(doer)
+	post, err := svc.Create(context.Background(), &model.CreatePostRequest{
+		Text:          "Worth a read",
+		Visibility:    "PUBLIC",
+		ReshareParent: "urn:li:share:1",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	body := doer.calls[0].body.(postBody)
+	if body.ReshareContext == nil || body.ReshareContext.Parent != "urn:li:share:1" || body.ReshareContext.Root != "" {
+		t.Errorf("reshareContext = %+v", body.ReshareContext)
+	}
+	if post.ReshareParent != "urn:li:share:1" || post.ReshareRoot != "urn:li:ugcPost:0" {
+		t.Errorf("post = %+v", post)
+	}
+}
+
 func TestPostCreateError(t *testing.T) {
 	doer := &mockDoer{responses: []mockResponse{
 		{status: 403, body: map[string]any{"status": 403, "message": "forbidden"}},
@@ -101,6 +131,91 @@ func TestPostGetError(t *testing.T) {
 	}
 }
 
+func TestPostGetMany(t *testing.T) {
+	doer := &mockDoer{responses: []mockResponse{
+		{status: 200, body: map[string]any{
+			"results": map[string]any{
+				"urn:li:share:1": map[string]any{"id": "urn:li:share:1", "commentary": "One"},
+			},
+			"errors": map[string]any{
+				"urn:li:ugcPost:2": map[string]any{"status": 404, "message": "not found"},
+			},
+		}},
+	}}
+
+	svc := NewPostService(doer)
+	batch, err := svc.GetMany(context.Background(), []string{"urn:li:share:1", "urn:li:ugcPost:2"})
+	if err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+	if want := "/posts?ids=List(urn%3Ali%3Ashare%3A1,urn%3Ali%3AugcPost%3A2)"; doer.calls[0].path != want {
+		t.Errorf("path = %q, want %q", doer.calls[0].path, want)
+	}
+	if p := batch.Results["urn:li:share:1"]; p == nil || p.Text != "One" {
+		t.Errorf("result = %+v", p)
+	}
+	if batch.Errors["urn:li:ugcPost:2"] == nil {
+		t.Errorf("errors = %v", batch.Errors)
+	}
+}
+
+func TestPostUpdate(t *testing.T) {
+	doer := &mockDoer{responses: []mockResponse{{status: 204}}}
+
+	text, label := "Fixed typo", "LEARN_MORE"
+	svc := NewPostService(doer)
+	err := svc.Update(context.Background(), "urn:li:share:123", &model.UpdatePostRequest{
+		Text:              &text,
+		CallToActionLabel: &label,
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	call := doer.calls[0]
+	if call.method != "POST" || call.path != "/posts/urn%3Ali%3Ashare%3A123" {
+		t.Errorf("request = %s %s", call.method, call.path)
+	}
+	patch, ok := call.body.(restli.Patch)
+	if !ok {
+		t.Fatalf("body = %T, want restli.Patch", call.body)
+	}
+	if len(patch.Set) != 2 || patch.Set["commentary"] != text || patch.Set["contentCallToActionLabel"] != label {
+		t.Errorf("patch = %+v", patch.Set)
+	}
+}
+
+func TestPostUpdateDeletesEmptyFields(t *testing.T) {
+	doer := &mockDoer{responses: []mockResponse{{status: 204}}}
+
+	label, page := "", ""
+	err := NewPostService(doer).Update(context.Background(), "urn:li:share:123", &model.UpdatePostRequest{
+		CallToActionLabel: &label,
+		LandingPage:       &page,
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	data, err := json.Marshal(doer.calls[0].body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"patch":{"$delete":["contentCallToActionLabel","contentLandingPage"]}}`
+	if string(data) != want {
+		t.Errorf("body = %s, want %s", data, want)
+	}
+}
+
+func TestPostUpdateNothing(t *testing.T) {
+	doer := &mockDoer{}
+
+	err := NewPostService(doer).Update(context.Background(), "urn:li:share:123", &model.UpdatePostRequest{})
+	if err == nil || len(doer.calls) != 0 {
+		t.Errorf("err = %v, calls = %d", err, len(doer.calls))
+	}
+}
+
 func TestPostDeleteSuccess(t *testing.T) {
 	doer := &mockDoer{responses: []mockResponse{
 		{status: 204, body: nil},
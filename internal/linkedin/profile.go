@@ -2,36 +2,30 @@ package linkedin
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 const userinfoURL = "https://api.linkedin.com/v2/userinfo"
 
-// userinfoURLOverride allows tests to point at a local httptest server.
-var userinfoURLOverride string
-
-func getUserinfoURL() string {
-	if userinfoURLOverride != "" {
-		return userinfoURLOverride
-	}
-	return userinfoURL
-}
-
 // ProfileService provides access to LinkedIn profile endpoints.
 type ProfileService struct {
 	doer        Doer
-	accessToken string
+	userinfoURL string
 }
 
 // NewProfileService creates a ProfileService backed by the given Doer.
-// The accessToken is used for the OpenID Connect /userinfo endpoint.
-func NewProfileService(d Doer, accessToken string) *ProfileService {
-	return &ProfileService{doer: d, accessToken: accessToken}
+func NewProfileService(d Doer) *ProfileService {
+	return &ProfileService{doer: d, userinfoURL: userinfoURL}
+}
+
+// UseUserinfoURL makes Me query the userinfo endpoint at u instead of
+// LinkedIn's.
+func (s *ProfileService) UseUserinfoURL(u string) {
+	s.userinfoURL = u
 }
 
 // userInfoResponse maps the OpenID Connect /userinfo endpoint response.
@@ -46,31 +40,21 @@ type userInfoResponse struct {
 }
 
 // Me returns the authenticated user's profile via OpenID Connect /userinfo.
+// The request goes through the Doer, so it is sent with the current access
+// token and retried, rate limited and cached like any other API call.
 func (s *ProfileService) Me(ctx context.Context) (*model.Profile, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getUserinfoURL(), nil)
+	resp, err := s.doer.Do(ctx, http.MethodGet, s.userinfoURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("get my profile: %w", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+s.accessToken)
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
+	if err := checkError(resp); err != nil {
 		return nil, fmt.Errorf("get my profile: %w", err)
 	}
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("get my profile: read body: %w", err)
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("get my profile: linkedin api %d: %s", resp.StatusCode, data)
-	}
 
 	var info userInfoResponse
-	if err := json.Unmarshal(data, &info); err != nil {
-		return nil, fmt.Errorf("get my profile: decode: %w", err)
+	if err := decodeJSON(resp, &info); err != nil {
+		return nil, fmt.Errorf("get my profile: %w", err)
 	}
 
 	profile := &model.Profile{
@@ -87,7 +71,7 @@ func (s *ProfileService) Me(ctx context.Context) (*model.Profile, error) {
 
 // GetByID returns a profile for the given person ID.
 func (s *ProfileService) GetByID(ctx context.Context, id string) (*model.Profile, error) {
-	path := fmt.Sprintf("/people/(id:%s)", id)
+	path := restli.Path("people", restli.Record{"id": id})
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -105,3 +89,15 @@ func (s *ProfileService) GetByID(ctx context.Context, id string) (*model.Profile
 
 	return raw.ToProfile(), nil
 }
+
+// GetMany returns the profiles of many person IDs with BATCH_GET requests.
+// Profiles that could not be fetched are reported per ID in the batch's
+// Errors.
+func (s *ProfileService) GetMany(ctx context.Context, ids []string) (*model.Batch[string, *model.Profile], error) {
+	batch, err := batchGet(ctx, s.doer, "people", ids,
+		func(id string) any { return restli.Record{"id": id} }, (*model.ProfileResponse).ToProfile)
+	if err != nil {
+		return nil, fmt.Errorf("get %d profiles: %w", len(ids), err)
+	}
+	return batch, nil
+}
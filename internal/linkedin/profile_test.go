@@ -3,9 +3,13 @@ package linkedin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/Softorize/lcli/internal/client"
+	"github.com/Softorize/lcli/internal/model"
 )
 
 func TestMeSuccess(t *testing.T) {
@@ -26,14 +30,8 @@ func TestMeSuccess(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	// Override the userinfo URL for testing.
-	origURL := userinfoURL
-	defer func() { userinfoURLOverride = "" }()
-	userinfoURLOverride = srv.URL
-	_ = origURL
-
-	doer := &mockDoer{}
-	svc := NewProfileService(doer, "test-token")
+	svc := NewProfileService(testClient("test-token"))
+	svc.UseUserinfoURL(srv.URL)
 	profile, err := svc.Me(context.Background())
 	if err != nil {
 		t.Fatalf("Me: %v", err)
@@ -65,15 +63,61 @@ func TestMeNon200(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	defer func() { userinfoURLOverride = "" }()
-	userinfoURLOverride = srv.URL
-
-	doer := &mockDoer{}
-	svc := NewProfileService(doer, "bad-token")
+	svc := NewProfileService(testClient("bad-token"))
+	svc.UseUserinfoURL(srv.URL)
 	_, err := svc.Me(context.Background())
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("err = %v, want model.ErrForbidden", err)
+	}
+}
+
+func TestMeUnauthorizedIsAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Li-Uuid", "req-1")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status":401,"serviceErrorCode":65600,"code":"INVALID_ACCESS_TOKEN","message":"Invalid access token"}`))
+	}))
+	defer srv.Close()
+
+	svc := NewProfileService(testClient("bad-token"))
+	svc.UseUserinfoURL(srv.URL + "/v2/userinfo")
+	_, err := svc.Me(context.Background())
+
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %T %v, want *model.APIError", err, err)
+	}
+	if !errors.Is(err, model.ErrUnauthorized) {
+		t.Error("errors.Is(err, model.ErrUnauthorized) = false")
+	}
+	if apiErr.ServiceErrorCode != 65600 || apiErr.RequestID != "req-1" || apiErr.Method != http.MethodGet || apiErr.Path != "/v2/userinfo" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
+
+func TestMeUsesRefreshedToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"sub": "abc123"})
+	}))
+	defer srv.Close()
+
+	cli := client.New("stale", "202601",
+		client.WithRetryPolicy(client.RetryPolicy{}),
+		client.WithTokenRefresher(func(context.Context) (string, error) { return "fresh", nil }),
+	)
+	svc := NewProfileService(cli)
+	svc.UseUserinfoURL(srv.URL)
+	profile, err := svc.Me(context.Background())
+	if err != nil || profile.ID != "abc123" {
+		t.Fatalf("Me = %+v, %v", profile, err)
+	}
 }
 
 func TestGetByIDSuccess(t *testing.T) {
@@ -87,7 +131,7 @@ func TestGetByIDSuccess(t *testing.T) {
 		}},
 	}}
 
-	svc := NewProfileService(doer, "")
+	svc := NewProfileService(doer)
 	profile, err := svc.GetByID(context.Background(), "person123")
 	if err != nil {
 		t.Fatalf("GetByID: %v", err)
@@ -105,9 +149,34 @@ func TestGetByIDError(t *testing.T) {
 		{status: 404, body: map[string]any{"status": 404, "message": "not found"}},
 	}}
 
-	svc := NewProfileService(doer, "")
+	svc := NewProfileService(doer)
 	_, err := svc.GetByID(context.Background(), "nonexistent")
 	if err == nil {
 		t.Fatal("expected error")
 	}
 }
+
+func TestGetManyProfiles(t *testing.T) {
+	doer := &mockDoer{responses: []mockResponse{
+		{status: 200, body: map[string]any{
+			"results": map[string]any{
+				"(id:person123)": map[string]any{"id": "person123", "localizedFirstName": "Alice"},
+			},
+		}},
+	}}
+
+	svc := NewProfileService(doer)
+	batch, err := svc.GetMany(context.Background(), []string{"person123", "gone"})
+	if err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+	if want := "/people?ids=List((id:person123),(id:gone))"; doer.calls[0].path != want {
+		t.Errorf("path = %q, want %q", doer.calls[0].path, want)
+	}
+	if p := batch.Results["person123"]; p == nil || p.FirstName != "Alice" {
+		t.Errorf("result = %+v", p)
+	}
+	if !errors.Is(batch.Errors["gone"], model.ErrNotFound) {
+		t.Errorf("errors = %v", batch.Errors)
+	}
+}
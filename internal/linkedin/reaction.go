@@ -4,11 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
 	"time"
 
 	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
 )
 
 // ReactionService provides access to LinkedIn reaction endpoints.
@@ -57,7 +56,7 @@ func (s *ReactionService) React(ctx context.Context, actorURN, entityURN string,
 		Actor: actorURN,
 	}
 
-	resp, err := s.doer.Do(ctx, http.MethodPost, "/reactions", body)
+	resp, err := s.doer.Do(ctx, http.MethodPost, restli.Path("reactions"), body)
 	if err != nil {
 		return fmt.Errorf("react on %s: %w", entityURN, err)
 	}
@@ -72,10 +71,7 @@ func (s *ReactionService) React(ctx context.Context, actorURN, entityURN string,
 
 // Unreact removes the actor's reaction from a LinkedIn entity.
 func (s *ReactionService) Unreact(ctx context.Context, actorURN, entityURN string) error {
-	path := fmt.Sprintf("/reactions/(actor:%s,entity:%s)",
-		url.PathEscape(actorURN),
-		url.PathEscape(entityURN),
-	)
+	path := restli.Path("reactions", restli.Record{"actor": actorURN, "entity": entityURN})
 
 	resp, err := s.doer.Do(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -92,11 +88,8 @@ func (s *ReactionService) Unreact(ctx context.Context, actorURN, entityURN strin
 
 // List retrieves reactions for an entity with pagination.
 func (s *ReactionService) List(ctx context.Context, entityURN string, start, count int) (*model.ReactionList, error) {
-	path := fmt.Sprintf("/reactions/(entity:%s)?start=%s&count=%s",
-		url.PathEscape(entityURN),
-		strconv.Itoa(start),
-		strconv.Itoa(count),
-	)
+	path := restli.URL(restli.Path("reactions", restli.Record{"entity": entityURN}),
+		restli.Finder("entity").Page(start, count))
 
 	resp, err := s.doer.Do(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -52,6 +52,9 @@ func TestUnreactSuccess(t *testing.T) {
 	if doer.calls[0].method != "DELETE" {
 		t.Errorf("method = %q, want DELETE", doer.calls[0].method)
 	}
+	if want := "/reactions/(actor:me,entity:urn%3Ali%3Ashare%3A123)"; doer.calls[0].path != want {
+		t.Errorf("path = %q, want %q", doer.calls[0].path, want)
+	}
 }
 
 func TestUnreactError(t *testing.T) {
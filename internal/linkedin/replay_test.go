@@ -0,0 +1,31 @@
+package linkedin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Softorize/lcli/internal/client"
+)
+
+// replayClient returns a client that answers from the cassette in
+// testdata/cassettes/name, recorded with LCLI_RECORD.
+func replayClient(t *testing.T, name string) *client.Client {
+	t.Helper()
+	rep, err := client.NewReplayer("testdata/cassettes/" + name)
+	if err != nil {
+		t.Fatalf("NewReplayer: %v", err)
+	}
+	return client.New("replay", "202601", client.WithTransport(rep))
+}
+
+func TestPostGetReplay(t *testing.T) {
+	svc := NewPostService(replayClient(t, "post-get"))
+
+	post, err := svc.Get(context.Background(), "urn:li:share:7000")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if post.Text != "Recorded post" || post.Author != "urn:li:person:abc123" || post.LifecycleState != "PUBLISHED" {
+		t.Errorf("post = %+v", post)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/Softorize/lcli/internal/client"
 )
 
 // mockDoer implements Doer for testing. It returns responses in order.
@@ -42,3 +44,9 @@ func (m *mockDoer) Do(_ context.Context, method, path string, body any) (*http.R
 		Header:     http.Header{},
 	}, nil
 }
+
+// testClient returns a client that sends token and does not retry, for
+// requests to absolute URLs such as a test userinfo server.
+func testClient(token string) *client.Client {
+	return client.New(token, "202601", client.WithRetryPolicy(client.RetryPolicy{}))
+}
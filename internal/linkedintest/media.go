@@ -0,0 +1,158 @@
+package linkedintest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// mediaKinds maps the media collections to the URN type they create.
+var mediaKinds = map[string]string{
+	"images":    "image",
+	"videos":    "video",
+	"documents": "document",
+}
+
+// Media statuses.
+const (
+	statusWaiting   = "WAITING_UPLOAD"
+	statusAvailable = "AVAILABLE"
+)
+
+// mediaJSON is a media asset as the API returns it.
+type mediaJSON struct {
+	ID     string `json:"id"`
+	Owner  string `json:"owner"`
+	Status string `json:"status"`
+}
+
+// serveMedia handles /images, /videos and /documents: the initializeUpload
+// and finalizeUpload actions and GET by URN.
+func (f *Fake) serveMedia(w http.ResponseWriter, r *http.Request, tok *token, collection string, segs []string) {
+	if len(segs) == 1 {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r)
+			return
+		}
+		urn := unescape(segs[0])
+		m := f.media[urn]
+		if m == nil || !strings.HasPrefix(urn, "urn:li:"+mediaKinds[collection]+":") {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "Media "+urn+" not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, mediaJSON{ID: m.URN, Owner: m.Owner, Status: m.Status})
+		return
+	}
+	if len(segs) > 1 || r.Method != http.MethodPost {
+		methodNotAllowed(w, r)
+		return
+	}
+
+	switch action := r.URL.Query().Get("action"); action {
+	case "initializeUpload":
+		f.initializeUpload(w, r, tok, mediaKinds[collection])
+	case "finalizeUpload":
+		if collection != "videos" {
+			writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "finalizeUpload is only supported for videos")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", fmt.Sprintf("Unsupported action %q", action))
+	}
+}
+
+// initializeUpload registers a media asset and returns the URL to upload it
+// to. Videos also get uploadInstructions and an uploadToken, as LinkedIn
+// splits them into parts.
+func (f *Fake) initializeUpload(w http.ResponseWriter, r *http.Request, tok *token, kind string) {
+	var body struct {
+		InitializeUploadRequest struct {
+			Owner string `json:"owner"`
+		} `json:"initializeUploadRequest"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	owner := resolve(tok, body.InitializeUploadRequest.Owner)
+	if owner == "" {
+		writeInputError(w, "initializeUploadRequest/owner", "MISSING_FIELD", "field is required but not found and has no default value")
+		return
+	}
+	if !f.canWriteAs(w, r, tok, owner) {
+		return
+	}
+
+	m := &media{
+		URN:    fmt.Sprintf("urn:li:%s:D4E10AQFake%06d", kind, f.nextID()),
+		Owner:  owner,
+		Status: statusWaiting,
+	}
+	f.media[m.URN] = m
+	uploadID := randomID()
+	f.uploads[uploadID] = m.URN
+
+	uploadURL := baseURL(r) + uploadPath + uploadID
+	value := map[string]any{
+		"uploadUrl":          uploadURL,
+		kind:                 m.URN,
+		"uploadUrlExpiresAt": time.Now().Add(time.Hour).UnixMilli(),
+	}
+	if kind == "video" {
+		value["uploadToken"] = ""
+		value["uploadInstructions"] = []map[string]any{{"uploadUrl": uploadURL, "firstByte": 0, "lastByte": -1}}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"value": value})
+}
+
+// serveUpload accepts the bytes of a media asset at an upload URL. Like
+// LinkedIn's upload URLs it needs no access token. The asset is available
+// as soon as the upload completes.
+func (f *Fake) serveUpload(w http.ResponseWriter, r *http.Request, uploadID string) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		methodNotAllowed(w, r)
+		return
+	}
+	n, err := io.Copy(io.Discard, r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "read upload: "+err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	urn, ok := f.uploads[uploadID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown or expired upload URL")
+		return
+	}
+	if n == 0 {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Empty upload")
+		return
+	}
+	delete(f.uploads, uploadID)
+	m := f.media[urn]
+	m.Size, m.Status = int(n), statusAvailable
+
+	w.Header().Set("ETag", `"`+randomID()+`"`)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// serveAsset handles GET /assets/{urn}, reporting the status of any image,
+// video or document.
+func (f *Fake) serveAsset(w http.ResponseWriter, r *http.Request, segs []string) {
+	if len(segs) != 1 || r.Method != http.MethodGet {
+		methodNotAllowed(w, r)
+		return
+	}
+	urn := unescape(segs[0])
+	m := f.media[urn]
+	if m == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Asset "+urn+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, mediaJSON{ID: m.URN, Owner: m.Owner, Status: m.Status})
+}
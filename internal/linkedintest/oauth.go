@@ -0,0 +1,261 @@
+package linkedintest
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Token lifetimes reported by the fake token endpoint, matching LinkedIn's
+// defaults for member tokens.
+const (
+	accessTokenLifetime  = 60 * 24 * time.Hour
+	refreshTokenLifetime = 365 * 24 * time.Hour
+)
+
+// issued is a newly issued access token and its refresh token.
+type issued struct {
+	access, refresh string
+	tok             *token
+}
+
+// issue creates an access token for member, with a refresh token unless it
+// is an application token. The caller must hold f.mu.
+func (f *Fake) issue(member string, scopes []string, clientID string) issued {
+	now := time.Now()
+	access := "fake-access-" + randomID()
+	tok := &token{
+		member: member, scopes: scopes, clientID: clientID,
+		createdAt: now, expiresAt: now.Add(accessTokenLifetime),
+	}
+	if member != "" {
+		tok.refresh = "fake-refresh-" + randomID()
+	}
+	f.tokens[access] = tok
+	return issued{access: access, refresh: tok.refresh, tok: tok}
+}
+
+// serveOAuth handles the endpoints below OAuthPath.
+func (f *Fake) serveOAuth(w http.ResponseWriter, r *http.Request, path string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch path {
+	case "/authorization":
+		f.authorize(w, r)
+	case "/accessToken":
+		f.accessToken(w, r)
+	case "/introspectToken":
+		f.introspect(w, r)
+	case "/revoke":
+		f.revoke(w, r)
+	default:
+		writeOAuthError(w, http.StatusNotFound, "not_found", "No OAuth endpoint "+path)
+	}
+}
+
+// authorize approves every authorization request at once, redirecting to
+// redirect_uri with a code and the caller's state as a member would after
+// signing in.
+func (f *Fake) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirect.Scheme == "" {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "missing or invalid redirect_uri")
+		return
+	}
+	if q.Get("response_type") != "code" || q.Get("client_id") == "" {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "response_type=code and client_id are required")
+		return
+	}
+
+	code := "fake-code-" + randomID()
+	f.codes[code] = strings.Fields(q.Get("scope"))
+
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", q.Get("state"))
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// tokenResponse is the body of a successful token request.
+type tokenResponse struct {
+	AccessToken           string `json:"access_token"`
+	ExpiresIn             int64  `json:"expires_in"`
+	RefreshToken          string `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresIn int64  `json:"refresh_token_expires_in,omitempty"`
+	Scope                 string `json:"scope"`
+}
+
+// accessToken implements the authorization_code, refresh_token and
+// client_credentials grants.
+func (f *Fake) accessToken(w http.ResponseWriter, r *http.Request) {
+	if !oauthForm(w, r) {
+		return
+	}
+	clientID := r.PostForm.Get("client_id")
+	if clientID == "" {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "client_id is required")
+		return
+	}
+
+	var out issued
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code := r.PostForm.Get("code")
+		scopes, ok := f.codes[code]
+		if !ok {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_request", "Unable to retrieve access token: authorization code not found")
+			return
+		}
+		delete(f.codes, code)
+		out = f.issue(MemberID, scopes, clientID)
+	case "refresh_token":
+		old := f.refreshed(r.PostForm.Get("refresh_token"))
+		if old == nil {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_request", "The provided refresh token is invalid")
+			return
+		}
+		out = f.issue(old.member, old.scopes, clientID)
+	case "client_credentials":
+		if r.PostForm.Get("client_secret") == "" {
+			writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "client_secret is required")
+			return
+		}
+		out = f.issue("", nil, clientID)
+	default:
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "unsupported grant_type")
+		return
+	}
+
+	resp := tokenResponse{
+		AccessToken: out.access,
+		ExpiresIn:   int64(accessTokenLifetime.Seconds()),
+		Scope:       strings.Join(out.tok.scopes, ","),
+	}
+	if out.refresh != "" {
+		resp.RefreshToken = out.refresh
+		resp.RefreshTokenExpiresIn = int64(refreshTokenLifetime.Seconds())
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// refreshed returns the token a refresh token belongs to, or nil. The caller
+// must hold f.mu.
+func (f *Fake) refreshed(refresh string) *token {
+	if refresh == "" {
+		return nil
+	}
+	for _, tok := range f.tokens {
+		if tok.refresh == refresh {
+			return tok
+		}
+	}
+	return nil
+}
+
+// introspectResponse is the body of a token introspection response.
+type introspectResponse struct {
+	Active       bool   `json:"active"`
+	Status       string `json:"status"`
+	ClientID     string `json:"client_id,omitempty"`
+	AuthType     string `json:"auth_type,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	AuthorizedAt int64  `json:"authorized_at,omitempty"`
+	CreatedAt    int64  `json:"created_at,omitempty"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+}
+
+// introspect reports whether a token is active.
+func (f *Fake) introspect(w http.ResponseWriter, r *http.Request) {
+	if !oauthForm(w, r) {
+		return
+	}
+	tok := f.tokens[r.PostForm.Get("token")]
+	if tok == nil {
+		writeJSON(w, http.StatusOK, introspectResponse{Active: false, Status: "revoked"})
+		return
+	}
+
+	resp := introspectResponse{
+		Active:       time.Now().Before(tok.expiresAt),
+		Status:       "active",
+		ClientID:     tok.clientID,
+		AuthType:     "3L",
+		Scope:        strings.Join(tok.scopes, ","),
+		AuthorizedAt: tok.createdAt.Unix(),
+		CreatedAt:    tok.createdAt.Unix(),
+		ExpiresAt:    tok.expiresAt.Unix(),
+	}
+	if !resp.Active {
+		resp.Status = "expired"
+	}
+	if tok.member == "" {
+		resp.AuthType = "2L"
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// revoke invalidates a token.
+func (f *Fake) revoke(w http.ResponseWriter, r *http.Request) {
+	if !oauthForm(w, r) {
+		return
+	}
+	delete(f.tokens, r.PostForm.Get("token"))
+	w.WriteHeader(http.StatusOK)
+}
+
+// userinfoResponse is the OpenID Connect userinfo body.
+type userinfoResponse struct {
+	Sub           string `json:"sub"`
+	Name          string `json:"name"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+}
+
+// serveUserinfo returns the authenticated member's OpenID claims.
+func (f *Fake) serveUserinfo(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tok := f.bearer(w, r)
+	if tok == nil {
+		return
+	}
+	if tok.member == "" {
+		writeError(w, http.StatusForbidden, "ACCESS_DENIED", "Application tokens have no member")
+		return
+	}
+	if !requireScope(w, r, tok, "openid") {
+		return
+	}
+	p := f.people[tok.member]
+	writeJSON(w, http.StatusOK, userinfoResponse{
+		Sub: p.ID, Name: p.FirstName + " " + p.LastName,
+		GivenName: p.FirstName, FamilyName: p.LastName,
+		Email: p.Email, EmailVerified: p.Email != "",
+	})
+}
+
+// oauthForm parses a form-encoded POST, answering with an OAuth error if the
+// request is not one.
+func oauthForm(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		writeOAuthError(w, http.StatusMethodNotAllowed, "invalid_request", "POST required")
+		return false
+	}
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return false
+	}
+	return true
+}
+
+// writeOAuthError writes an OAuth 2.0 error body.
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{"error": code, "error_description": description})
+}
@@ -0,0 +1,332 @@
+package linkedintest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// personJSON is a member profile as GET /people returns it.
+type personJSON struct {
+	ID                 string `json:"id"`
+	LocalizedFirstName string `json:"localizedFirstName"`
+	LocalizedLastName  string `json:"localizedLastName"`
+	LocalizedHeadline  string `json:"localizedHeadline"`
+	VanityName         string `json:"vanityName,omitempty"`
+}
+
+// servePeople handles GET /people/(id:{id}) and BATCH_GET with
+// ids=List((id:{id}),...).
+func (f *Fake) servePeople(w http.ResponseWriter, r *http.Request, segs []string) {
+	if len(segs) > 1 || r.Method != http.MethodGet {
+		methodNotAllowed(w, r)
+		return
+	}
+	if len(segs) == 0 {
+		raw, ok := rawQuery(r.URL.RawQuery)["ids"]
+		if !ok {
+			methodNotAllowed(w, r)
+			return
+		}
+		f.batchGetPeople(w, raw)
+		return
+	}
+	key, err := parseKey(segs[0])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", err.Error())
+		return
+	}
+	p := f.people[key["id"]]
+	if p == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Member "+key["id"]+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, p.json())
+}
+
+// json returns p as the API represents it.
+func (p *person) json() personJSON {
+	return personJSON{
+		ID: p.ID, LocalizedFirstName: p.FirstName, LocalizedLastName: p.LastName,
+		LocalizedHeadline: p.Headline, VanityName: p.Vanity,
+	}
+}
+
+// batchGetPeople answers a BATCH_GET. Results and errors are keyed by the
+// complex key, (id:{id}).
+func (f *Fake) batchGetPeople(w http.ResponseWriter, raw string) {
+	keys, err := parseList(raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", err.Error())
+		return
+	}
+	results := map[string]personJSON{}
+	statuses := map[string]int{}
+	errs := map[string]apiError{}
+	for _, k := range keys {
+		key, err := parseKey(k)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", err.Error())
+			return
+		}
+		if p := f.people[key["id"]]; p != nil {
+			results[k] = p.json()
+			statuses[k] = http.StatusOK
+			continue
+		}
+		statuses[k] = http.StatusNotFound
+		errs[k] = apiError{Status: http.StatusNotFound, Code: "NOT_FOUND", Message: "Member " + key["id"] + " not found"}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": results, "statuses": statuses, "errors": errs})
+}
+
+// orgJSON is an organization as the API returns it.
+type orgJSON struct {
+	ID                   int64  `json:"id"`
+	LocalizedName        string `json:"localizedName"`
+	VanityName           string `json:"vanityName"`
+	LocalizedDescription string `json:"localizedDescription,omitempty"`
+	LocalizedWebsite     string `json:"localizedWebsite,omitempty"`
+}
+
+// json returns o as the API represents it.
+func (o *org) json() orgJSON {
+	return orgJSON{
+		ID: o.ID, LocalizedName: o.Name, VanityName: o.Vanity,
+		LocalizedDescription: o.Description, LocalizedWebsite: o.Website,
+	}
+}
+
+// serveOrganizations handles GET /organizations/{id}, the vanityName finder
+// and BATCH_GET with ids=List(...).
+func (f *Fake) serveOrganizations(w http.ResponseWriter, r *http.Request, segs []string) {
+	if r.Method != http.MethodGet || len(segs) > 1 {
+		methodNotAllowed(w, r)
+		return
+	}
+
+	if len(segs) == 1 {
+		id, err := strconv.ParseInt(unescape(segs[0]), 10, 64)
+		o := f.orgs[id]
+		if err != nil || o == nil {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "Organization "+unescape(segs[0])+" not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, o.json())
+		return
+	}
+
+	q := r.URL.Query()
+	if raw, ok := rawQuery(r.URL.RawQuery)["ids"]; ok {
+		f.batchGetOrgs(w, raw)
+		return
+	}
+	if q.Get("q") != "vanityName" {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Unsupported finder "+strconv.Quote(q.Get("q")))
+		return
+	}
+	elements := []orgJSON{}
+	for _, o := range f.orgs {
+		if strings.EqualFold(o.Vanity, q.Get("vanityName")) {
+			elements = append(elements, o.json())
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"elements": elements})
+}
+
+// batchGetOrgs answers a BATCH_GET. Unknown IDs are reported in errors,
+// keyed like results by ID.
+func (f *Fake) batchGetOrgs(w http.ResponseWriter, raw string) {
+	ids, err := parseList(raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", err.Error())
+		return
+	}
+	results := map[string]orgJSON{}
+	statuses := map[string]int{}
+	errs := map[string]apiError{}
+	for _, id := range ids {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if o := f.orgs[n]; err == nil && o != nil {
+			results[id] = o.json()
+			statuses[id] = http.StatusOK
+			continue
+		}
+		statuses[id] = http.StatusNotFound
+		errs[id] = apiError{Status: http.StatusNotFound, Code: "NOT_FOUND", Message: "Organization " + id + " not found"}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": results, "statuses": statuses, "errors": errs})
+}
+
+// statsOrg returns the organization named by the query parameter param of
+// an organization statistics finder, answering with an error if the finder
+// or organization is wrong or the token is not an administrator's.
+func (f *Fake) statsOrg(w http.ResponseWriter, r *http.Request, tok *token, finder, param string) *org {
+	q := r.URL.Query()
+	if q.Get("q") != finder {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Unsupported finder "+strconv.Quote(q.Get("q")))
+		return nil
+	}
+	urn := q.Get(param)
+	if urn == "" {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Query parameter '"+param+"' is required")
+		return nil
+	}
+	o := f.orgFromURN(urn)
+	if o == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Organization "+urn+" not found")
+		return nil
+	}
+	if !requireScope(w, r, tok, "rw_organization_admin") {
+		return nil
+	}
+	return o
+}
+
+// followerSegment is one segment of follower counts.
+type followerSegment struct {
+	Segment        string `json:"segment"`
+	FollowerCounts int    `json:"followerCounts"`
+}
+
+// serveFollowerStats handles the organizationalEntity finder of
+// /organizationalEntityFollowerStatistics.
+func (f *Fake) serveFollowerStats(w http.ResponseWriter, r *http.Request, tok *token) {
+	o := f.statsOrg(w, r, tok, "organizationalEntity", "organizationalEntity")
+	if o == nil {
+		return
+	}
+	byFunction := []followerSegment{}
+	for seg, n := range o.FollowersByFunction {
+		byFunction = append(byFunction, followerSegment{Segment: seg, FollowerCounts: n})
+	}
+	slices.SortFunc(byFunction, func(a, b followerSegment) int { return strings.Compare(a.Segment, b.Segment) })
+	writeJSON(w, http.StatusOK, map[string]any{"elements": []map[string]any{{
+		"organizationalEntity":      orgURN(o.ID),
+		"organicFollowerCount":      o.OrganicFollowers,
+		"paidFollowerCount":         o.PaidFollowers,
+		"followerCountsByFunction":  byFunction,
+		"followerCountsBySeniority": []followerSegment{},
+	}}})
+}
+
+// servePageStats handles the organization finder of
+// /organizationPageStatistics.
+func (f *Fake) servePageStats(w http.ResponseWriter, r *http.Request, tok *token) {
+	o := f.statsOrg(w, r, tok, "organization", "organization")
+	if o == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"elements": []map[string]any{{
+		"organization":   orgURN(o.ID),
+		"views":          o.PageViews,
+		"uniqueVisitors": o.UniqueVisitors,
+		"clicks":         o.Clicks,
+	}}})
+}
+
+// shareStats are the totalShareStatistics of one or more posts.
+type shareStats struct {
+	ImpressionCount       int     `json:"impressionCount"`
+	UniqueImpressionCount int     `json:"uniqueImpressionsCount"`
+	ClickCount            int     `json:"clickCount"`
+	LikeCount             int     `json:"likeCount"`
+	CommentCount          int     `json:"commentCount"`
+	ShareCount            int     `json:"shareCount"`
+	Engagement            float64 `json:"engagement"`
+}
+
+// add adds the statistics of post urn, derived from its reactions and
+// comments, to s.
+func (s *shareStats) add(f *Fake, urn string) {
+	likes := len(f.reactions[urn])
+	comments := 0
+	for _, c := range f.comments {
+		if c.Post == urn {
+			comments++
+		}
+	}
+	impressions := 100 * (1 + likes + comments)
+
+	s.ImpressionCount += impressions
+	s.UniqueImpressionCount += impressions * 4 / 5
+	s.ClickCount += likes + comments
+	s.LikeCount += likes
+	s.CommentCount += comments
+	if s.ImpressionCount > 0 {
+		s.Engagement = float64(s.ClickCount+s.LikeCount+s.CommentCount+s.ShareCount) / float64(s.ImpressionCount)
+	}
+}
+
+// serveShareStats handles the organizationalEntity finder of
+// /organizationalEntityShareStatistics. With shares=List(...) it returns
+// one element per post; without, the totals of the organization's posts.
+func (f *Fake) serveShareStats(w http.ResponseWriter, r *http.Request, tok *token) {
+	o := f.statsOrg(w, r, tok, "organizationalEntity", "organizationalEntity")
+	if o == nil {
+		return
+	}
+	entity := orgURN(o.ID)
+
+	raw, ok := rawQuery(r.URL.RawQuery)["shares"]
+	if !ok {
+		var total shareStats
+		for _, p := range f.posts {
+			if p.Author == entity {
+				total.add(f, p.ID)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"elements": []map[string]any{{
+			"organizationalEntity": entity,
+			"totalShareStatistics": total,
+		}}})
+		return
+	}
+
+	shares, err := parseList(raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "shares: "+err.Error())
+		return
+	}
+	elements := []map[string]any{}
+	for _, urn := range shares {
+		if f.posts[urn] == nil {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "Share "+urn+" not found")
+			return
+		}
+		var stats shareStats
+		stats.add(f, urn)
+		elements = append(elements, map[string]any{
+			"organizationalEntity": entity,
+			"share":                urn,
+			"totalShareStatistics": stats,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"elements": elements})
+}
+
+// serveNetworkSize handles GET /networkSizes/{urn}: the follower count of
+// an organization, or the connection count of a member.
+func (f *Fake) serveNetworkSize(w http.ResponseWriter, r *http.Request, tok *token, segs []string) {
+	if len(segs) != 1 || r.Method != http.MethodGet {
+		methodNotAllowed(w, r)
+		return
+	}
+	if r.URL.Query().Get("edgeType") == "" {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Query parameter 'edgeType' is required")
+		return
+	}
+
+	urn := resolve(tok, unescape(segs[0]))
+	size := 0
+	if o := f.orgFromURN(urn); o != nil {
+		size = o.OrganicFollowers + o.PaidFollowers
+	} else if p := f.people[strings.TrimPrefix(urn, "urn:li:person:")]; p != nil {
+		size = p.Connections
+	} else {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Entity "+urn+" not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"firstDegreeSize": size})
+}
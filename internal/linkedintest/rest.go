@@ -0,0 +1,464 @@
+package linkedintest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Softorize/lcli/internal/restli"
+)
+
+// versionPattern matches a LinkedIn-Version header value, YYYYMM.
+var versionPattern = regexp.MustCompile(`^(\d{4})(0[1-9]|1[0-2])$`)
+
+// serveREST handles the versioned API below RESTPath.
+func (f *Fake) serveREST(w http.ResponseWriter, r *http.Request, path string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !checkRestliHeaders(w, r) {
+		return
+	}
+	tok := f.bearer(w, r)
+	if tok == nil || f.rateLimited(w) {
+		return
+	}
+
+	segs := splitPath(path)
+	switch segs[0] {
+	case "posts":
+		f.servePosts(w, r, tok, segs[1:])
+	case "socialActions":
+		f.serveComments(w, r, tok, segs[1:])
+	case "reactions":
+		f.serveReactions(w, r, tok, segs[1:])
+	case "images", "videos", "documents":
+		f.serveMedia(w, r, tok, segs[0], segs[1:])
+	case "assets":
+		f.serveAsset(w, r, segs[1:])
+	case "people":
+		f.servePeople(w, r, segs[1:])
+	case "organizations":
+		f.serveOrganizations(w, r, segs[1:])
+	case "organizationalEntityFollowerStatistics":
+		f.serveFollowerStats(w, r, tok)
+	case "organizationPageStatistics":
+		f.servePageStats(w, r, tok)
+	case "organizationalEntityShareStatistics":
+		f.serveShareStats(w, r, tok)
+	case "networkSizes":
+		f.serveNetworkSize(w, r, tok, segs[1:])
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "No virtual resource found")
+	}
+}
+
+// checkRestliHeaders answers with LinkedIn's errors for a missing or
+// invalid LinkedIn-Version header or a Rest.li protocol other than 2.0.0.
+func checkRestliHeaders(w http.ResponseWriter, r *http.Request) bool {
+	version := r.Header.Get("LinkedIn-Version")
+	if version == "" {
+		writeError(w, http.StatusBadRequest, "VERSION_MISSING",
+			"A version must be present. Please specify a version by adding the LinkedIn-Version header.")
+		return false
+	}
+	m := versionPattern.FindStringSubmatch(version)
+	if m == nil || version > time.Now().Format("200601") {
+		writeError(w, http.StatusUpgradeRequired, "NONEXISTENT_VERSION",
+			fmt.Sprintf("Requested version %s is not active", version))
+		return false
+	}
+	if p := r.Header.Get("X-Restli-Protocol-Version"); p != "2.0.0" {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT",
+			fmt.Sprintf("Unsupported Rest.li protocol version %q; send X-Restli-Protocol-Version: 2.0.0", p))
+		return false
+	}
+	return true
+}
+
+// methodNotAllowed answers 405 for a method a resource does not support.
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+		fmt.Sprintf("%s is not supported on %s", r.Method, r.URL.Path))
+}
+
+// personURN returns the URN of a member.
+func personURN(id string) string {
+	return "urn:li:person:" + id
+}
+
+// orgURN returns the URN of an organization.
+func orgURN(id int64) string {
+	return "urn:li:organization:" + strconv.FormatInt(id, 10)
+}
+
+// resolve maps "me" to the URN of the token's member.
+func resolve(tok *token, urn string) string {
+	if urn == "me" && tok.member != "" {
+		return personURN(tok.member)
+	}
+	return urn
+}
+
+// orgFromURN returns the organization a URN names, or nil.
+func (f *Fake) orgFromURN(urn string) *org {
+	id, ok := strings.CutPrefix(urn, "urn:li:organization:")
+	if !ok {
+		return nil
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return f.orgs[n]
+}
+
+// canWriteAs checks that tok may act as author, answering 403 if not.
+// Members act as themselves and, with w_organization_social, as the
+// organizations they administer.
+func (f *Fake) canWriteAs(w http.ResponseWriter, r *http.Request, tok *token, author string) bool {
+	switch {
+	case strings.HasPrefix(author, "urn:li:organization:"):
+		if f.orgFromURN(author) == nil {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "Organization "+author+" not found")
+			return false
+		}
+		return requireScope(w, r, tok, "w_organization_social")
+	case author == personURN(tok.member) && tok.member != "":
+		return requireScope(w, r, tok, "w_member_social")
+	default:
+		writeError(w, http.StatusForbidden, "ACCESS_DENIED",
+			fmt.Sprintf("Member is not permitted to act as %s", author))
+		return false
+	}
+}
+
+// postJSON is a post as the API returns it.
+type postJSON struct {
+	ID             string          `json:"id"`
+	Author         string          `json:"author"`
+	Commentary     string          `json:"commentary"`
+	Visibility     string          `json:"visibility"`
+	Distribution   postDistrib     `json:"distribution"`
+	Content        *postContentRaw `json:"content,omitempty"`
+	LifecycleState string          `json:"lifecycleState"`
+	ReshareContext *reshareJSON    `json:"reshareContext,omitempty"`
+	CallToAction   string          `json:"contentCallToActionLabel,omitempty"`
+	LandingPage    string          `json:"contentLandingPage,omitempty"`
+	CreatedAt      int64           `json:"createdAt"`
+	LastModifiedAt int64           `json:"lastModifiedAt"`
+	PublishedAt    int64           `json:"publishedAt"`
+}
+
+// reshareJSON links a reshare to the post it reshares and to the original
+// post of the chain.
+type reshareJSON struct {
+	Parent string `json:"parent"`
+	Root   string `json:"root,omitempty"`
+}
+
+// postDistrib is a post's distribution.
+type postDistrib struct {
+	FeedDistribution string `json:"feedDistribution"`
+}
+
+// postContentRaw is a post's media content.
+type postContentRaw struct {
+	Media *postMedia `json:"media,omitempty"`
+}
+
+// postMedia references an uploaded image, video or document.
+type postMedia struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// json returns p as the API represents it.
+func (p *post) json() postJSON {
+	ms := p.CreatedAt.UnixMilli()
+	out := postJSON{
+		ID: p.ID, Author: p.Author, Commentary: p.Commentary, Visibility: p.Visibility,
+		Distribution:   postDistrib{FeedDistribution: "MAIN_FEED"},
+		LifecycleState: p.LifecycleState,
+		CallToAction:   p.CallToActionLabel, LandingPage: p.LandingPage,
+		CreatedAt: ms, LastModifiedAt: ms, PublishedAt: ms,
+	}
+	if !p.ModifiedAt.IsZero() {
+		out.LastModifiedAt = p.ModifiedAt.UnixMilli()
+	}
+	if p.ReshareParent != "" {
+		out.ReshareContext = &reshareJSON{Parent: p.ReshareParent, Root: p.ReshareRoot}
+	}
+	if p.MediaID != "" {
+		out.Content = &postContentRaw{Media: &postMedia{ID: p.MediaID, Title: p.MediaTitle}}
+	}
+	return out
+}
+
+// visibilities are the accepted values of a post's visibility.
+var visibilities = []string{"PUBLIC", "CONNECTIONS", "LOGGED_IN", "CONTAINER"}
+
+// servePosts handles /posts.
+func (f *Fake) servePosts(w http.ResponseWriter, r *http.Request, tok *token, segs []string) {
+	if len(segs) == 0 {
+		switch r.Method {
+		case http.MethodPost:
+			f.createPost(w, r, tok)
+		case http.MethodGet:
+			f.findPosts(w, r, tok)
+		default:
+			methodNotAllowed(w, r)
+		}
+		return
+	}
+
+	urn := unescape(segs[0])
+	p := f.posts[urn]
+	if p == nil || len(segs) > 1 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Post "+urn+" not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, p.json())
+	case http.MethodPost:
+		if !f.canWriteAs(w, r, tok, p.Author) {
+			return
+		}
+		updatePost(w, r, p)
+	case http.MethodDelete:
+		if !f.canWriteAs(w, r, tok, p.Author) {
+			return
+		}
+		f.deletePost(urn)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// createPost publishes a post. LinkedIn answers 201 with the new URN in
+// X-Restli-Id and an empty body.
+func (f *Fake) createPost(w http.ResponseWriter, r *http.Request, tok *token) {
+	var body postJSON
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	author := resolve(tok, body.Author)
+	switch {
+	case author == "":
+		writeInputError(w, "author", "MISSING_FIELD", "field is required but not found and has no default value")
+		return
+	case !slices.Contains(visibilities, body.Visibility):
+		writeInputError(w, "visibility", "INVALID_VALUE", fmt.Sprintf("%q is not an enum symbol", body.Visibility))
+		return
+	case body.LifecycleState != "PUBLISHED":
+		writeInputError(w, "lifecycleState", "INVALID_VALUE", "only PUBLISHED posts can be created")
+		return
+	case len([]rune(body.Commentary)) > 3000:
+		writeInputError(w, "commentary", "INVALID_VALUE", "length must not exceed 3000 characters")
+		return
+	}
+	if !f.canWriteAs(w, r, tok, author) {
+		return
+	}
+
+	p := &post{
+		ID:             fmt.Sprintf("urn:li:share:%d", 7000000000000000000+int64(f.nextID())),
+		Author:         author,
+		Commentary:     body.Commentary,
+		Visibility:     body.Visibility,
+		LifecycleState: body.LifecycleState,
+		CreatedAt:      time.Now(),
+	}
+	if body.Content != nil && body.Content.Media != nil {
+		m := f.media[body.Content.Media.ID]
+		if m == nil || m.Status != "AVAILABLE" {
+			writeInputError(w, "content/media/id", "INVALID_VALUE",
+				fmt.Sprintf("media %s does not exist or has not finished uploading", body.Content.Media.ID))
+			return
+		}
+		p.MediaID, p.MediaTitle = body.Content.Media.ID, body.Content.Media.Title
+	}
+	if body.ReshareContext != nil {
+		parent := f.posts[body.ReshareContext.Parent]
+		if parent == nil {
+			writeInputError(w, "reshareContext/parent", "INVALID_VALUE",
+				fmt.Sprintf("post %s does not exist", body.ReshareContext.Parent))
+			return
+		}
+		// The root is the original post, however long the chain.
+		p.ReshareParent, p.ReshareRoot = parent.ID, parent.ReshareRoot
+		if p.ReshareRoot == "" {
+			p.ReshareRoot = parent.ID
+		}
+	}
+
+	f.posts[p.ID] = p
+	f.postOrder = append(f.postOrder, p.ID)
+	w.Header().Set("X-Restli-Id", p.ID)
+	w.Header().Set("Location", "/posts/"+p.ID)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// callToActionLabels are the accepted values of contentCallToActionLabel.
+var callToActionLabels = []string{
+	"APPLY", "DOWNLOAD", "VIEW_QUOTE", "LEARN_MORE", "SIGN_UP", "SUBSCRIBE",
+	"REGISTER", "JOIN", "ATTEND", "REQUEST_DEMO", "SEE_MORE", "BUY_NOW", "SHOP_NOW",
+}
+
+// lifecycleStates are the accepted values of lifecycleState.
+var lifecycleStates = []string{"DRAFT", "PUBLISHED", "PUBLISH_REQUESTED", "PUBLISH_FAILED"}
+
+// updatePost applies a PARTIAL_UPDATE. Like LinkedIn, it only lets the
+// commentary, the call-to-action and the lifecycle state be set, and only
+// the call-to-action be deleted.
+func updatePost(w http.ResponseWriter, r *http.Request, p *post) {
+	if m := r.Header.Get(restli.MethodHeader); m != "PARTIAL_UPDATE" {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT",
+			fmt.Sprintf("Unsupported Rest.li method %q on an entity; want PARTIAL_UPDATE", m))
+		return
+	}
+	var body struct {
+		Patch struct {
+			Set    map[string]any `json:"$set"`
+			Delete []string       `json:"$delete"`
+		} `json:"patch"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.Patch.Set) == 0 && len(body.Patch.Delete) == 0 {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Patch has no $set or $delete fields")
+		return
+	}
+
+	updated := *p
+	for _, field := range body.Patch.Delete {
+		switch field {
+		case "contentCallToActionLabel":
+			updated.CallToActionLabel = ""
+		case "contentLandingPage":
+			updated.LandingPage = ""
+		default:
+			writeInputError(w, field, "UNPERMITTED_FIELD", "field cannot be deleted")
+			return
+		}
+	}
+	for field, v := range body.Patch.Set {
+		s, ok := v.(string)
+		if !ok {
+			writeInputError(w, field, "INVALID_VALUE", "must be a string")
+			return
+		}
+		switch field {
+		case "commentary":
+			if len([]rune(s)) > 3000 {
+				writeInputError(w, field, "INVALID_VALUE", "length must not exceed 3000 characters")
+				return
+			}
+			updated.Commentary = s
+		case "contentCallToActionLabel":
+			if !slices.Contains(callToActionLabels, s) {
+				writeInputError(w, field, "INVALID_VALUE", fmt.Sprintf("%q is not an enum symbol", s))
+				return
+			}
+			updated.CallToActionLabel = s
+		case "contentLandingPage":
+			updated.LandingPage = s
+		case "lifecycleState":
+			if !slices.Contains(lifecycleStates, s) {
+				writeInputError(w, field, "INVALID_VALUE", fmt.Sprintf("%q is not an enum symbol", s))
+				return
+			}
+			updated.LifecycleState = s
+		default:
+			writeInputError(w, field, "UNPERMITTED_FIELD", "field cannot be updated")
+			return
+		}
+	}
+	updated.ModifiedAt = time.Now()
+	*p = updated
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deletePost removes a post with its comments and reactions.
+func (f *Fake) deletePost(urn string) {
+	delete(f.posts, urn)
+	f.postOrder = slices.DeleteFunc(f.postOrder, func(id string) bool { return id == urn })
+	delete(f.reactions, urn)
+	for id, c := range f.comments {
+		if c.Post == urn {
+			delete(f.comments, id)
+			delete(f.reactions, id)
+		}
+	}
+}
+
+// findPosts implements the q=author finder, newest first, and BATCH_GET
+// with ids=List(...).
+func (f *Fake) findPosts(w http.ResponseWriter, r *http.Request, tok *token) {
+	if raw, ok := rawQuery(r.URL.RawQuery)["ids"]; ok {
+		f.batchGetPosts(w, raw)
+		return
+	}
+	q := r.URL.Query()
+	if q.Get("q") != "author" {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Unsupported finder "+strconv.Quote(q.Get("q")))
+		return
+	}
+	author := resolve(tok, q.Get("author"))
+	if author == "" {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Query parameter 'author' is required")
+		return
+	}
+
+	var all []postJSON
+	for _, id := range slices.Backward(f.postOrder) {
+		if p := f.posts[id]; p.Author == author {
+			all = append(all, p.json())
+		}
+	}
+	start, count := pageParams(q)
+	lo, hi := page(len(all), start, count)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"elements": nonNil(all[lo:hi]),
+		"paging":   paging{Start: start, Count: count, Total: len(all)},
+	})
+}
+
+// batchGetPosts answers a BATCH_GET. Unknown URNs are reported in errors,
+// keyed like results by URN.
+func (f *Fake) batchGetPosts(w http.ResponseWriter, raw string) {
+	urns, err := parseList(raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", err.Error())
+		return
+	}
+	results := map[string]postJSON{}
+	statuses := map[string]int{}
+	errs := map[string]apiError{}
+	for _, urn := range urns {
+		if p := f.posts[urn]; p != nil {
+			results[urn] = p.json()
+			statuses[urn] = http.StatusOK
+			continue
+		}
+		statuses[urn] = http.StatusNotFound
+		errs[urn] = apiError{Status: http.StatusNotFound, Code: "NOT_FOUND", Message: "Post " + urn + " not found"}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": results, "statuses": statuses, "errors": errs})
+}
+
+// nonNil returns s, or an empty slice if s is nil, so that empty
+// collections encode as [] rather than null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
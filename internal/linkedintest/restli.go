@@ -0,0 +1,106 @@
+package linkedintest
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Rest.li 2.0 encodes complex keys as (field:value,...) and lists as
+// List(a,b,...), with the reserved characters inside values percent-encoded.
+// The parsers below work on the raw, still-encoded text so that commas and
+// parentheses inside values, such as comment URNs, are not mistaken for
+// structure.
+
+// splitPath splits an escaped URL path into its raw segments, dropping the
+// leading slash.
+func splitPath(escaped string) []string {
+	return strings.Split(strings.TrimPrefix(escaped, "/"), "/")
+}
+
+// unescape decodes a percent-encoded path segment or query value. Invalid
+// escapes are returned as they are.
+func unescape(raw string) string {
+	s, err := url.PathUnescape(raw)
+	if err != nil {
+		return raw
+	}
+	return s
+}
+
+// splitTop splits s on sep, ignoring separators nested in parentheses.
+func splitTop(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseKey decodes a complex key such as (actor:urn%3Ali%3Aperson%3A1,
+// entity:urn%3Ali%3Ashare%3A2) into its fields.
+func parseKey(raw string) (map[string]string, error) {
+	inner, ok := strings.CutPrefix(raw, "(")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, ")")
+	}
+	if !ok || inner == "" {
+		return nil, fmt.Errorf("invalid complex key %q", unescape(raw))
+	}
+
+	key := make(map[string]string)
+	for _, part := range splitTop(inner, ',') {
+		name, value, ok := strings.Cut(part, ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid complex key %q", unescape(raw))
+		}
+		key[unescape(name)] = unescape(value)
+	}
+	return key, nil
+}
+
+// parseList decodes List(a,b,...) into its elements.
+func parseList(raw string) ([]string, error) {
+	inner, ok := strings.CutPrefix(raw, "List(")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, ")")
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid list %q: want List(...)", unescape(raw))
+	}
+	if inner == "" {
+		return nil, nil
+	}
+
+	var elems []string
+	for _, e := range splitTop(inner, ',') {
+		elems = append(elems, unescape(e))
+	}
+	return elems, nil
+}
+
+// rawQuery splits a query string into its parameters without decoding the
+// values, which Rest.li 2.0 structures must be parsed from. Later values of
+// a repeated parameter win.
+func rawQuery(raw string) map[string]string {
+	q := make(map[string]string)
+	for _, pair := range strings.Split(raw, "&") {
+		if pair == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(pair, "=")
+		q[unescape(k)] = v
+	}
+	return q
+}
@@ -0,0 +1,464 @@
+// Package linkedintest provides an in-process fake of the LinkedIn REST API,
+// OAuth endpoints and OpenID Connect userinfo endpoint for integration
+// tests. It keeps posts, comments, reactions, media uploads, organizations
+// and statistics in memory, enforces the Rest.li protocol headers and
+// answers with LinkedIn's error envelopes and rate-limit headers.
+//
+// The fake accepts "me" wherever lcli passes it in place of the
+// authenticated member's person URN.
+package linkedintest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default fixtures of a new Fake.
+const (
+	// Token is a member access token every Fake accepts, with all scopes.
+	Token = "fake-token"
+	// MemberID is the person ID of the member behind Token.
+	MemberID = "fake-member"
+	// OrgID is the ID of the organization Token administers.
+	OrgID = 1001
+	// OrgVanity is the vanity name of organization OrgID.
+	OrgVanity = "fakecorp"
+)
+
+// allScopes are the scopes granted to Token.
+var allScopes = []string{
+	"openid", "profile", "email", "w_member_social",
+	"w_organization_social", "r_organization_social", "rw_organization_admin",
+}
+
+// Path prefixes served by a Fake.
+const (
+	RESTPath     = "/rest"
+	OAuthPath    = "/oauth/v2"
+	UserinfoPath = "/v2/userinfo"
+	uploadPath   = "/upload/"
+)
+
+// Fake is an http.Handler that emulates LinkedIn. Its zero value is not
+// usable; create one with New.
+type Fake struct {
+	mu sync.Mutex
+
+	seq       int
+	tokens    map[string]*token
+	codes     map[string][]string // authorization code -> scopes
+	people    map[string]*person
+	orgs      map[int64]*org
+	posts     map[string]*post
+	postOrder []string
+	comments  map[string]*comment
+	reactions map[string]map[string]*reaction // entity -> actor -> reaction
+	media     map[string]*media
+	uploads   map[string]string // upload ID -> media URN
+
+	rateLimit  int
+	rateWindow time.Duration
+	rateReset  time.Time
+	rateUsed   int
+
+	failures []failure
+}
+
+// token is an access token issued by the fake.
+type token struct {
+	member    string // person ID; empty for application tokens
+	scopes    []string
+	clientID  string
+	refresh   string
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+// person is a member profile.
+type person struct {
+	ID, FirstName, LastName, Headline, Vanity, Email string
+	Connections                                      int
+}
+
+// org is an organization page with its statistics.
+type org struct {
+	ID                  int64
+	Name, Vanity        string
+	Description         string
+	Website             string
+	OrganicFollowers    int
+	PaidFollowers       int
+	FollowersByFunction map[string]int
+	PageViews           int
+	UniqueVisitors      int
+	Clicks              int
+}
+
+// post is a published post.
+type post struct {
+	ID, Author, Commentary, Visibility, MediaID, MediaTitle string
+	CallToActionLabel, LandingPage, LifecycleState          string
+	ReshareParent, ReshareRoot                              string
+	CreatedAt, ModifiedAt                                   time.Time
+}
+
+// comment is a comment on a post, or a reply to another comment.
+type comment struct {
+	Seq                            int
+	URN, Post, Parent, Actor, Text string
+	CreatedAt                      time.Time
+}
+
+// reaction is a member's reaction to a post or comment.
+type reaction struct {
+	Type      string
+	CreatedAt time.Time
+}
+
+// media is an image, video or document and its upload state.
+type media struct {
+	URN, Owner, Status string
+	Size               int
+}
+
+// failure is an injected error response.
+type failure struct {
+	status    int
+	remaining int
+}
+
+// New returns a Fake seeded with the member behind Token and organization
+// OrgID.
+func New() *Fake {
+	now := time.Now()
+	f := &Fake{
+		tokens:    make(map[string]*token),
+		codes:     make(map[string][]string),
+		people:    make(map[string]*person),
+		orgs:      make(map[int64]*org),
+		posts:     make(map[string]*post),
+		comments:  make(map[string]*comment),
+		reactions: make(map[string]map[string]*reaction),
+		media:     make(map[string]*media),
+		uploads:   make(map[string]string),
+	}
+	f.tokens[Token] = &token{
+		member: MemberID, scopes: allScopes, clientID: "fake-client",
+		createdAt: now, expiresAt: now.Add(60 * 24 * time.Hour),
+	}
+	f.people[MemberID] = &person{
+		ID: MemberID, FirstName: "Ada", LastName: "Lovelace",
+		Headline: "Analyst at Fake Corp", Vanity: "ada", Email: "ada@example.com",
+		Connections: 500,
+	}
+	f.orgs[OrgID] = &org{
+		ID: OrgID, Name: "Fake Corp", Vanity: OrgVanity,
+		Description: "A company that exists only in tests", Website: "https://fakecorp.example.com",
+		OrganicFollowers: 1200, PaidFollowers: 34,
+		FollowersByFunction: map[string]int{"urn:li:function:4": 300, "urn:li:function:8": 150},
+		PageViews:           5400, UniqueVisitors: 2100, Clicks: 320,
+	}
+	return f
+}
+
+// Server is a Fake listening on a local httptest.Server.
+type Server struct {
+	*Fake
+	*httptest.Server
+}
+
+// NewServer starts a Fake on a local port. Callers must Close it.
+func NewServer() *Server {
+	f := New()
+	return &Server{Fake: f, Server: httptest.NewServer(f)}
+}
+
+// APIBaseURL returns the base URL of the REST API, for api_base_url.
+func (s *Server) APIBaseURL() string { return s.URL + RESTPath }
+
+// OAuthBaseURL returns the OAuth endpoint base URL, for oauth_base_url.
+func (s *Server) OAuthBaseURL() string { return s.URL + OAuthPath }
+
+// UserinfoURL returns the userinfo endpoint URL, for userinfo_url.
+func (s *Server) UserinfoURL() string { return s.URL + UserinfoPath }
+
+// SetRateLimit limits the REST API to n calls per window across all tokens.
+// Every REST response then carries X-RateLimit-Limit, X-RateLimit-Remaining
+// and X-RateLimit-Reset, and calls over the limit get 429 with Retry-After.
+// Zero removes the limit.
+func (f *Fake) SetRateLimit(n int, window time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rateLimit, f.rateWindow = n, window
+	f.rateUsed, f.rateReset = 0, time.Now().Add(window)
+}
+
+// FailNext makes the next n REST calls fail with status, for testing
+// retries. Statuses 429 and 503 include a Retry-After of one second.
+func (f *Fake) FailNext(n, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, failure{status: status, remaining: n})
+}
+
+// AddPerson adds a member profile that GET /people can return.
+func (f *Fake) AddPerson(id, firstName, lastName, headline string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.people[id] = &person{ID: id, FirstName: firstName, LastName: lastName, Headline: headline}
+}
+
+// AddOrganization adds an organization page with no statistics.
+func (f *Fake) AddOrganization(id int64, name, vanity string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.orgs[id] = &org{ID: id, Name: name, Vanity: vanity, FollowersByFunction: map[string]int{}}
+}
+
+// IssueToken creates a member access token with the given scopes.
+func (f *Fake) IssueToken(scopes ...string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.issue(MemberID, scopes, "fake-client").access
+}
+
+// PostCount returns the number of posts currently stored.
+func (f *Fake) PostCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.posts)
+}
+
+// ServeHTTP routes a request to the REST, OAuth, userinfo or upload
+// handlers.
+func (f *Fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Li-Uuid", randomID())
+
+	path := r.URL.EscapedPath()
+	switch {
+	case strings.HasPrefix(path, RESTPath+"/"):
+		f.serveREST(w, r, strings.TrimPrefix(path, RESTPath))
+	case strings.HasPrefix(path, OAuthPath+"/"):
+		f.serveOAuth(w, r, strings.TrimPrefix(path, OAuthPath))
+	case path == UserinfoPath:
+		f.serveUserinfo(w, r)
+	case strings.HasPrefix(path, uploadPath):
+		f.serveUpload(w, r, strings.TrimPrefix(path, uploadPath))
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "No route for "+r.URL.Path)
+	}
+}
+
+// nextID returns a new sequential ID.
+func (f *Fake) nextID() int {
+	f.seq++
+	return f.seq
+}
+
+// apiError is LinkedIn's error envelope. Validation failures add the
+// offending fields in errorDetails.
+type apiError struct {
+	Status           int           `json:"status"`
+	ServiceErrorCode int           `json:"serviceErrorCode"`
+	Code             string        `json:"code"`
+	Message          string        `json:"message"`
+	ErrorDetailType  string        `json:"errorDetailType,omitempty"`
+	ErrorDetails     *errorDetails `json:"errorDetails,omitempty"`
+}
+
+// errorDetails lists the invalid inputs of a rejected request.
+type errorDetails struct {
+	InputErrors []inputError `json:"inputErrors"`
+}
+
+// inputError describes one invalid field.
+type inputError struct {
+	Description string `json:"description"`
+	Input       struct {
+		InputPath struct {
+			FieldPath string `json:"fieldPath"`
+		} `json:"inputPath"`
+	} `json:"input"`
+	Code string `json:"code"`
+}
+
+// serviceErrorCodes are the serviceErrorCode values LinkedIn sends with
+// error codes; other codes send zero.
+var serviceErrorCodes = map[string]int{
+	"ACCESS_DENIED":        100,
+	"TOO_MANY_REQUESTS":    101,
+	"INVALID_ACCESS_TOKEN": 65600,
+	"EXPIRED_ACCESS_TOKEN": 65601,
+}
+
+// writeError writes an error envelope.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, apiError{
+		Status: status, ServiceErrorCode: serviceErrorCodes[code], Code: code, Message: message,
+	})
+}
+
+// writeInputError answers 422 for an invalid request field.
+func writeInputError(w http.ResponseWriter, field, code, description string) {
+	ie := inputError{Description: description, Code: code}
+	ie.Input.InputPath.FieldPath = field
+	writeJSON(w, http.StatusUnprocessableEntity, apiError{
+		Status:          http.StatusUnprocessableEntity,
+		Code:            "UNPROCESSABLE_ENTITY",
+		Message:         fmt.Sprintf("/%s :: %s", field, description),
+		ErrorDetailType: "com.linkedin.common.error.BadRequest",
+		ErrorDetails:    &errorDetails{InputErrors: []inputError{ie}},
+	})
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// decodeBody reads a JSON request body into dst, answering 400 on failure.
+func decodeBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// bearer returns the token sent in the Authorization header, or nil after
+// answering 401.
+func (f *Fake) bearer(w http.ResponseWriter, r *http.Request) *token {
+	raw, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	tok := f.tokens[raw]
+	if !ok || tok == nil {
+		writeError(w, http.StatusUnauthorized, "INVALID_ACCESS_TOKEN", "Invalid access token")
+		return nil
+	}
+	if time.Now().After(tok.expiresAt) {
+		writeError(w, http.StatusUnauthorized, "EXPIRED_ACCESS_TOKEN", "The token used in the request has expired")
+		return nil
+	}
+	return tok
+}
+
+// requireScope answers 403 and returns false unless tok has scope.
+func requireScope(w http.ResponseWriter, r *http.Request, tok *token, scope string) bool {
+	for _, s := range tok.scopes {
+		if s == scope {
+			return true
+		}
+	}
+	writeError(w, http.StatusForbidden, "ACCESS_DENIED",
+		fmt.Sprintf("Not enough permissions to access: %s %s", r.Method, r.URL.Path))
+	return false
+}
+
+// rateLimited applies the rate limit and injected failures. It writes the
+// rate-limit headers and returns true after answering with an error.
+func (f *Fake) rateLimited(w http.ResponseWriter) bool {
+	if len(f.failures) > 0 {
+		fail := &f.failures[0]
+		fail.remaining--
+		if fail.remaining <= 0 {
+			f.failures = f.failures[1:]
+		}
+		if fail.status == http.StatusTooManyRequests || fail.status == http.StatusServiceUnavailable {
+			w.Header().Set("Retry-After", "1")
+		}
+		writeError(w, fail.status, errorCode(fail.status), http.StatusText(fail.status))
+		return true
+	}
+
+	if f.rateLimit <= 0 {
+		return false
+	}
+	now := time.Now()
+	if now.After(f.rateReset) {
+		f.rateUsed, f.rateReset = 0, now.Add(f.rateWindow)
+	}
+	f.rateUsed++
+	remaining := max(f.rateLimit-f.rateUsed, 0)
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(f.rateLimit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(f.rateReset.Unix(), 10))
+	if f.rateUsed > f.rateLimit {
+		retry := int(time.Until(f.rateReset).Seconds()) + 1
+		w.Header().Set("Retry-After", strconv.Itoa(retry))
+		writeError(w, http.StatusTooManyRequests, "TOO_MANY_REQUESTS",
+			"Resource level throttle APPLICATION DAY limit for calls to this resource is reached.")
+		return true
+	}
+	return false
+}
+
+// errorCode returns LinkedIn's code string for an HTTP status.
+func errorCode(status int) string {
+	switch status {
+	case http.StatusUnauthorized:
+		return "INVALID_ACCESS_TOKEN"
+	case http.StatusForbidden:
+		return "ACCESS_DENIED"
+	case http.StatusNotFound:
+		return "NOT_FOUND"
+	case http.StatusTooManyRequests:
+		return "TOO_MANY_REQUESTS"
+	case http.StatusUnprocessableEntity:
+		return "UNPROCESSABLE_ENTITY"
+	default:
+		if status >= 500 {
+			return "INTERNAL_SERVER_ERROR"
+		}
+		return "ILLEGAL_ARGUMENT"
+	}
+}
+
+// baseURL returns the scheme and host the request was sent to.
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// pageParams reads the start and count query parameters.
+func pageParams(q url.Values) (start, count int) {
+	start, _ = strconv.Atoi(q.Get("start"))
+	count, err := strconv.Atoi(q.Get("count"))
+	if err != nil || count <= 0 {
+		count = 10
+	}
+	return max(start, 0), count
+}
+
+// page returns the [start, start+count) window of n elements.
+func page(n, start, count int) (lo, hi int) {
+	lo = min(start, n)
+	hi = min(lo+count, n)
+	return lo, hi
+}
+
+// paging is the Rest.li paging block of collection responses.
+type paging struct {
+	Start int `json:"start"`
+	Count int `json:"count"`
+	Total int `json:"total"`
+}
+
+// randomID returns a random hex string.
+func randomID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
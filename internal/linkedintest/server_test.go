@@ -0,0 +1,606 @@
+package linkedintest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Softorize/lcli/internal/auth"
+	"github.com/Softorize/lcli/internal/client"
+	"github.com/Softorize/lcli/internal/config"
+	"github.com/Softorize/lcli/internal/linkedin"
+	"github.com/Softorize/lcli/internal/model"
+	"github.com/Softorize/lcli/internal/restli"
+)
+
+// apiVersion is the LinkedIn-Version the tests send.
+const apiVersion = "202601"
+
+// newClient starts a fake and returns it with a client authenticated as
+// the seeded member.
+func newClient(t *testing.T) (*Server, *client.Client) {
+	t.Helper()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	cli := client.New(Token, apiVersion,
+		client.WithBaseURL(srv.APIBaseURL()),
+		client.WithRetryPolicy(client.RetryPolicy{}),
+	)
+	return srv, cli
+}
+
+func TestPostCommentReactionLifecycle(t *testing.T) {
+	srv, cli := newClient(t)
+	ctx := context.Background()
+	me := "urn:li:person:" + MemberID
+
+	posts := linkedin.NewPostService(cli)
+	created, err := posts.Create(ctx, &model.CreatePostRequest{Text: "Hello fake", Visibility: "PUBLIC", AuthorURN: me})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !strings.HasPrefix(created.ID, "urn:li:share:") {
+		t.Fatalf("post ID = %q", created.ID)
+	}
+
+	got, err := posts.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Text != "Hello fake" || got.Author != me || got.CreatedAt.IsZero() {
+		t.Errorf("Get = %+v", got)
+	}
+
+	list, err := posts.ListByAuthor(ctx, "me", 0, 10)
+	if err != nil {
+		t.Fatalf("ListByAuthor: %v", err)
+	}
+	if len(list.Elements) != 1 || list.Paging == nil || list.Paging.Total != 1 {
+		t.Errorf("ListByAuthor = %+v", list)
+	}
+
+	comments := linkedin.NewCommentService(cli)
+	c, err := comments.Create(ctx, &model.CreateCommentRequest{PostURN: created.ID, Text: "First"})
+	if err != nil {
+		t.Fatalf("comment Create: %v", err)
+	}
+	if c.Author != me || c.Text != "First" {
+		t.Errorf("comment = %+v", c)
+	}
+	clist, err := comments.List(ctx, created.ID, 0, 10)
+	if err != nil || len(clist.Elements) != 1 || clist.Elements[0].ID != c.ID {
+		t.Errorf("comment List = %+v, %v", clist, err)
+	}
+
+	reactions := linkedin.NewReactionService(cli)
+	if err := reactions.React(ctx, "me", created.ID, model.ReactionLike); err != nil {
+		t.Fatalf("React: %v", err)
+	}
+	rlist, err := reactions.List(ctx, created.ID, 0, 10)
+	if err != nil || len(rlist.Elements) != 1 || rlist.Elements[0].Type != model.ReactionLike {
+		t.Errorf("reaction List = %+v, %v", rlist, err)
+	}
+
+	if err := posts.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := posts.Get(ctx, created.ID); !errors.Is(err, model.ErrNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+	if n := srv.PostCount(); n != 0 {
+		t.Errorf("PostCount = %d, want 0", n)
+	}
+}
+
+func TestPostValidation(t *testing.T) {
+	srv, cli := newClient(t)
+	posts := linkedin.NewPostService(cli)
+
+	_, err := posts.Create(context.Background(), &model.CreatePostRequest{Text: "x", Visibility: "EVERYONE"})
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("err = %v, want 422", err)
+	}
+
+	req := restRequest(t, srv, "/posts")
+	req.Method = http.MethodPost
+	req.Body = io.NopCloser(strings.NewReader(`{"author":"me","commentary":"x","visibility":"EVERYONE","lifecycleState":"PUBLISHED"}`))
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var envelope apiError
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		t.Fatal(err)
+	}
+	if envelope.ErrorDetails == nil || len(envelope.ErrorDetails.InputErrors) != 1 ||
+		envelope.ErrorDetails.InputErrors[0].Input.InputPath.FieldPath != "visibility" {
+		t.Errorf("envelope = %+v, want an input error for visibility", envelope)
+	}
+
+	_, err = posts.Create(context.Background(), &model.CreatePostRequest{
+		Text: "x", Visibility: "PUBLIC", AuthorURN: "urn:li:person:someone-else",
+	})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("posting as another member: err = %v, want ErrForbidden", err)
+	}
+}
+
+func TestPostPartialUpdate(t *testing.T) {
+	_, cli := newClient(t)
+	ctx := context.Background()
+	posts := linkedin.NewPostService(cli)
+
+	created, err := posts.Create(ctx, &model.CreatePostRequest{Text: "Helo", Visibility: "PUBLIC", AuthorURN: "me"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	text, label := "Hello", "LEARN_MORE"
+	if err := posts.Update(ctx, created.ID, &model.UpdatePostRequest{Text: &text, CallToActionLabel: &label}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got, err := posts.Get(ctx, created.ID); err != nil || got.Text != "Hello" {
+		t.Errorf("Get after Update = %+v, %v", got, err)
+	}
+
+	page := "https://example.com"
+	if err := posts.Update(ctx, created.ID, &model.UpdatePostRequest{LandingPage: &page}); err != nil {
+		t.Fatalf("Update landing page: %v", err)
+	}
+	label, page = "", ""
+	if err := posts.Update(ctx, created.ID, &model.UpdatePostRequest{CallToActionLabel: &label, LandingPage: &page}); err != nil {
+		t.Fatalf("Update clearing the call-to-action: %v", err)
+	}
+	resp, err := cli.Get(ctx, "/posts/"+url.PathEscape(created.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if strings.Contains(string(raw), "contentLandingPage") || strings.Contains(string(raw), "contentCallToActionLabel") {
+		t.Errorf("post after clearing the call-to-action = %s", raw)
+	}
+
+	bad := "CLICK_HERE"
+	err = posts.Update(ctx, created.ID, &model.UpdatePostRequest{CallToActionLabel: &bad})
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("invalid call-to-action: err = %v, want 422", err)
+	}
+
+	// Without X-RestLi-Method the POST is not a partial update.
+	resp, err = cli.Post(ctx, "/posts/"+url.PathEscape(created.ID), map[string]any{"patch": map[string]any{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("plain POST status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestPostPartialUpdateLifecycleState(t *testing.T) {
+	fake := New()
+	var patches []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(restli.MethodHeader) == "PARTIAL_UPDATE" {
+			raw, _ := io.ReadAll(r.Body)
+			patches = append(patches, string(raw))
+			r.Body = io.NopCloser(bytes.NewReader(raw))
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	cli := client.New(Token, apiVersion,
+		client.WithBaseURL(srv.URL+RESTPath),
+		client.WithRetryPolicy(client.RetryPolicy{}),
+	)
+	ctx := context.Background()
+	posts := linkedin.NewPostService(cli)
+
+	created, err := posts.Create(ctx, &model.CreatePostRequest{Text: "Hello", Visibility: "PUBLIC", AuthorURN: "me"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	state := "DRAFT"
+	if err := posts.Update(ctx, created.ID, &model.UpdatePostRequest{LifecycleState: &state}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if want := `{"patch":{"$set":{"lifecycleState":"DRAFT"}}}`; len(patches) != 1 || patches[0] != want {
+		t.Errorf("patches = %q, want %q", patches, want)
+	}
+	if got, err := posts.Get(ctx, created.ID); err != nil || got.LifecycleState != "DRAFT" {
+		t.Errorf("Get after Update = %+v, %v", got, err)
+	}
+
+	bad := "ARCHIVED"
+	err = posts.Update(ctx, created.ID, &model.UpdatePostRequest{LifecycleState: &bad})
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("invalid lifecycle state: err = %v, want 422", err)
+	}
+}
+
+func TestReshare(t *testing.T) {
+	_, cli := newClient(t)
+	ctx := context.Background()
+	posts := linkedin.NewPostService(cli)
+
+	original, err := posts.Create(ctx, &model.CreatePostRequest{Text: "Original", Visibility: "PUBLIC", AuthorURN: "me"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	first, err := posts.Create(ctx, &model.CreatePostRequest{Visibility: "PUBLIC", AuthorURN: "me", ReshareParent: original.ID})
+	if err != nil {
+		t.Fatalf("reshare: %v", err)
+	}
+	second, err := posts.Create(ctx, &model.CreatePostRequest{
+		Text: "Still relevant", Visibility: "PUBLIC", AuthorURN: "urn:li:organization:1001", ReshareParent: first.ID,
+	})
+	if err != nil {
+		t.Fatalf("reshare of reshare: %v", err)
+	}
+
+	got, err := posts.Get(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.ReshareParent != first.ID || got.ReshareRoot != original.ID || got.Text != "Still relevant" {
+		t.Errorf("Get = %+v", got)
+	}
+
+	_, err = posts.Create(ctx, &model.CreatePostRequest{Visibility: "PUBLIC", AuthorURN: "me", ReshareParent: "urn:li:share:1"})
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("reshare of unknown post: err = %v, want 422", err)
+	}
+}
+
+func TestMediaUploadFlow(t *testing.T) {
+	_, cli := newClient(t)
+	ctx := context.Background()
+	media := linkedin.NewMediaService(cli, nil)
+
+	up, err := media.InitUpload(ctx, "me", "IMAGE")
+	if err != nil {
+		t.Fatalf("InitUpload: %v", err)
+	}
+	if !strings.HasPrefix(up.MediaURN, "urn:li:image:") {
+		t.Fatalf("media URN = %q", up.MediaURN)
+	}
+
+	// Posting media that has not been uploaded is rejected.
+	posts := linkedin.NewPostService(cli)
+	req := &model.CreatePostRequest{Text: "pic", Visibility: "PUBLIC", MediaURN: up.MediaURN, AuthorURN: "urn:li:person:" + MemberID}
+	if _, err := posts.Create(ctx, req); err == nil {
+		t.Fatal("Create with pending media succeeded")
+	}
+
+	if err := media.Upload(ctx, up.UploadURL, strings.NewReader("PNG...")); err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	st, err := media.GetStatus(ctx, up.MediaURN)
+	if err != nil || st.Status != "AVAILABLE" {
+		t.Fatalf("GetStatus = %+v, %v", st, err)
+	}
+	if _, err := posts.Create(ctx, req); err != nil {
+		t.Fatalf("Create with media: %v", err)
+	}
+}
+
+func TestOrganizationsAndStats(t *testing.T) {
+	_, cli := newClient(t)
+	ctx := context.Background()
+	orgs := linkedin.NewOrgService(cli)
+
+	o, err := orgs.Get(ctx, OrgID)
+	if err != nil || o.VanityName != OrgVanity {
+		t.Fatalf("Get = %+v, %v", o, err)
+	}
+	if o, err := orgs.GetByVanity(ctx, OrgVanity); err != nil || o.ID != OrgID {
+		t.Errorf("GetByVanity = %+v, %v", o, err)
+	}
+	if _, err := orgs.Get(ctx, 42); !errors.Is(err, model.ErrNotFound) {
+		t.Errorf("Get unknown: err = %v, want ErrNotFound", err)
+	}
+
+	urn := "urn:li:organization:1001"
+	followers, err := orgs.FollowerStats(ctx, urn)
+	if err != nil || followers.TotalCount != 1234 || followers.ByFunction["urn:li:function:4"] != 300 {
+		t.Errorf("FollowerStats = %+v, %v", followers, err)
+	}
+	pages, err := orgs.PageStats(ctx, urn)
+	if err != nil || pages.Views != 5400 {
+		t.Errorf("PageStats = %+v, %v", pages, err)
+	}
+}
+
+func TestBatchGet(t *testing.T) {
+	_, cli := newClient(t)
+	ctx := context.Background()
+
+	posts := linkedin.NewPostService(cli)
+	created, err := posts.Create(ctx, &model.CreatePostRequest{Text: "Batch", Visibility: "PUBLIC", AuthorURN: "me"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	pb, err := posts.GetMany(ctx, []string{created.ID, "urn:li:share:1"})
+	if err != nil {
+		t.Fatalf("posts GetMany: %v", err)
+	}
+	if p := pb.Results[created.ID]; p == nil || p.Text != "Batch" {
+		t.Errorf("post result = %+v", p)
+	}
+	if !errors.Is(pb.Errors["urn:li:share:1"], model.ErrNotFound) {
+		t.Errorf("post errors = %v", pb.Errors)
+	}
+
+	ob, err := linkedin.NewOrgService(cli).GetMany(ctx, []int64{OrgID, 42})
+	if err != nil {
+		t.Fatalf("orgs GetMany: %v", err)
+	}
+	if o := ob.Results[OrgID]; o == nil || o.VanityName != OrgVanity {
+		t.Errorf("org result = %+v", o)
+	}
+	if !errors.Is(ob.Errors[42], model.ErrNotFound) {
+		t.Errorf("org errors = %v", ob.Errors)
+	}
+
+	prb, err := linkedin.NewProfileService(cli).GetMany(ctx, []string{MemberID, "nobody"})
+	if err != nil {
+		t.Fatalf("people GetMany: %v", err)
+	}
+	if p := prb.Results[MemberID]; p == nil || p.FirstName != "Ada" {
+		t.Errorf("profile result = %+v", p)
+	}
+	if !errors.Is(prb.Errors["nobody"], model.ErrNotFound) {
+		t.Errorf("profile errors = %v", prb.Errors)
+	}
+}
+
+func TestShareStatistics(t *testing.T) {
+	srv, cli := newClient(t)
+	ctx := context.Background()
+
+	post, err := linkedin.NewPostService(cli).Create(ctx, &model.CreatePostRequest{
+		Text: "Company news", Visibility: "PUBLIC", AuthorURN: "urn:li:organization:1001",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := linkedin.NewReactionService(cli).React(ctx, "me", post.ID, model.ReactionLike); err != nil {
+		t.Fatalf("React: %v", err)
+	}
+
+	path := "/organizationalEntityShareStatistics?q=organizationalEntity&organizationalEntity=" +
+		url.QueryEscape("urn:li:organization:1001") + "&shares=List(" + url.QueryEscape(post.ID) + ")"
+	resp, err := cli.Get(ctx, path)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	var body struct {
+		Elements []struct {
+			Share string     `json:"share"`
+			Stats shareStats `json:"totalShareStatistics"`
+		} `json:"elements"`
+	}
+	if err := client.DecodeResponse(resp, &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Elements) != 1 || body.Elements[0].Share != post.ID || body.Elements[0].Stats.LikeCount != 1 {
+		t.Errorf("stats = %+v", body)
+	}
+
+	// The Rest.li 1.0 shares[0] syntax names no organization.
+	resp, err = srv.Client().Do(restRequest(t, srv, "/organizationalEntityShareStatistics?q=organizationalEntity&shares[0]="+url.QueryEscape(post.ID)))
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("shares[0] status = %d, want 400", resp.StatusCode)
+	}
+}
+
+// restRequest builds an authenticated GET below the REST base with the
+// Rest.li headers set.
+func restRequest(t *testing.T, srv *Server, path string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, srv.APIBaseURL()+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+Token)
+	req.Header.Set("LinkedIn-Version", apiVersion)
+	req.Header.Set("X-Restli-Protocol-Version", "2.0.0")
+	return req
+}
+
+func TestRestliHeadersEnforced(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		status int
+		code   string
+	}{
+		{"no version", "LinkedIn-Version", "", http.StatusBadRequest, "VERSION_MISSING"},
+		{"bad version", "LinkedIn-Version", "2026", http.StatusUpgradeRequired, "NONEXISTENT_VERSION"},
+		{"future version", "LinkedIn-Version", "209912", http.StatusUpgradeRequired, "NONEXISTENT_VERSION"},
+		{"protocol 1.0", "X-Restli-Protocol-Version", "1.0.0", http.StatusBadRequest, "ILLEGAL_ARGUMENT"},
+		{"no token", "Authorization", "", http.StatusUnauthorized, "INVALID_ACCESS_TOKEN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := restRequest(t, srv, "/organizations/1001")
+			req.Header.Set(tt.header, tt.value)
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var envelope apiError
+			if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status || envelope.Status != tt.status || envelope.Code != tt.code {
+				t.Errorf("got %d %+v, want %d %s", resp.StatusCode, envelope, tt.status, tt.code)
+			}
+			if resp.Header.Get("X-Li-Uuid") == "" {
+				t.Error("missing x-li-uuid header")
+			}
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	srv, cli := newClient(t)
+	srv.SetRateLimit(2, time.Minute)
+	orgs := linkedin.NewOrgService(cli)
+
+	for range 2 {
+		if _, err := orgs.Get(context.Background(), OrgID); err != nil {
+			t.Fatalf("Get within limit: %v", err)
+		}
+	}
+	resp, err := srv.Client().Do(restRequest(t, srv, "/organizations/1001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", resp.StatusCode)
+	}
+	for _, h := range []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Reset"} {
+		if resp.Header.Get(h) == "" {
+			t.Errorf("missing %s header", h)
+		}
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 0", got)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	srv, cli := newClient(t)
+	srv.FailNext(1, http.StatusServiceUnavailable)
+	orgs := linkedin.NewOrgService(cli)
+
+	if _, err := orgs.Get(context.Background(), OrgID); !errors.Is(err, model.ErrServer) {
+		t.Fatalf("first Get: err = %v, want ErrServer", err)
+	}
+	if _, err := orgs.Get(context.Background(), OrgID); err != nil {
+		t.Fatalf("second Get: %v", err)
+	}
+}
+
+func TestOAuthFlow(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	cfg := &config.Config{
+		ClientID: "app", ClientSecret: "secret",
+		RedirectURI:  "http://localhost:8484/callback",
+		OAuthBaseURL: srv.OAuthBaseURL(),
+	}
+	a := auth.NewAuthenticator(cfg, nil)
+
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := noRedirect.Get(a.AuthorizationURL("xyz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	code, err := auth.ParseRedirect(resp.Header.Get("Location"), "xyz")
+	if err != nil {
+		t.Fatalf("ParseRedirect(%q): %v", resp.Header.Get("Location"), err)
+	}
+
+	tok, err := a.Exchange(ctx, code)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if tok.RefreshToken == "" || !tok.Valid() {
+		t.Errorf("token = %+v", tok)
+	}
+
+	profiles := linkedin.NewProfileService(client.New(tok.AccessToken, apiVersion, client.WithBaseURL(srv.APIBaseURL())))
+	profiles.UseUserinfoURL(srv.UserinfoURL())
+	me, err := profiles.Me(ctx)
+	if err != nil || me.ID != MemberID || me.FirstName != "Ada" {
+		t.Fatalf("Me = %+v, %v", me, err)
+	}
+
+	fresh, err := a.Refresh(ctx, tok.RefreshToken)
+	if err != nil || fresh.AccessToken == tok.AccessToken {
+		t.Fatalf("Refresh = %+v, %v", fresh, err)
+	}
+
+	info, err := a.Introspect(ctx, fresh.AccessToken)
+	if err != nil || !info.Active || info.ClientID != "app" {
+		t.Errorf("Introspect = %+v, %v", info, err)
+	}
+	if err := a.Revoke(ctx, fresh.AccessToken); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if info, err := a.Introspect(ctx, fresh.AccessToken); err != nil || info.Active {
+		t.Errorf("Introspect after Revoke = %+v, %v", info, err)
+	}
+
+	app, err := a.ClientCredentials(ctx)
+	if err != nil || app.RefreshToken != "" {
+		t.Errorf("ClientCredentials = %+v, %v", app, err)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := parseKey("(actor:urn%3Ali%3Aperson%3A1,entity:urn%3Ali%3Acomment%3A%28urn%3Ali%3Ashare%3A2%2C3%29)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key["actor"] != "urn:li:person:1" || key["entity"] != "urn:li:comment:(urn:li:share:2,3)" {
+		t.Errorf("key = %v", key)
+	}
+
+	// Unencoded URNs split on the first colon only.
+	key, err = parseKey("(entity:urn:li:share:2)")
+	if err != nil || key["entity"] != "urn:li:share:2" {
+		t.Errorf("key = %v, %v", key, err)
+	}
+
+	for _, bad := range []string{"", "actor:x", "(actor)", "()"} {
+		if _, err := parseKey(bad); err == nil {
+			t.Errorf("parseKey(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestParseList(t *testing.T) {
+	got, err := parseList("List(urn%3Ali%3Ashare%3A1,urn:li:comment:(urn:li:share:1,2))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != "urn:li:share:1" || got[1] != "urn:li:comment:(urn:li:share:1,2)" {
+		t.Errorf("parseList = %q", got)
+	}
+	if got, err := parseList("List()"); err != nil || len(got) != 0 {
+		t.Errorf("empty list = %q, %v", got, err)
+	}
+	if _, err := parseList("urn:li:share:1"); err == nil {
+		t.Error("parseList accepted a bare value")
+	}
+}
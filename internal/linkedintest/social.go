@@ -0,0 +1,305 @@
+package linkedintest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commentJSON is a comment as the API returns it.
+type commentJSON struct {
+	URN           string `json:"$URN"`
+	ID            string `json:"id"`
+	CommentURN    string `json:"commentUrn"`
+	Actor         string `json:"actor"`
+	Object        string `json:"object"`
+	Message       text   `json:"message"`
+	Created       int64  `json:"created"`
+	ParentComment string `json:"parentComment,omitempty"`
+}
+
+// text is an attributed text field such as a comment's message.
+type text struct {
+	Text string `json:"text"`
+}
+
+// json returns c as the API represents it.
+func (c *comment) json() commentJSON {
+	return commentJSON{
+		URN: c.URN, ID: strconv.Itoa(c.Seq), CommentURN: c.URN,
+		Actor: c.Actor, Object: c.Post, Message: text{c.Text},
+		Created: c.CreatedAt.UnixMilli(), ParentComment: c.Parent,
+	}
+}
+
+// entityExists reports whether urn names a post or comment that can be
+// commented on or reacted to.
+func (f *Fake) entityExists(urn string) bool {
+	return f.posts[urn] != nil || f.comments[urn] != nil
+}
+
+// serveComments handles /socialActions/{entity}/comments[/{commentId}].
+// The entity is a post, or a comment for replies.
+func (f *Fake) serveComments(w http.ResponseWriter, r *http.Request, tok *token, segs []string) {
+	if len(segs) < 2 || len(segs) > 3 || segs[1] != "comments" {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "No virtual resource found")
+		return
+	}
+	target := unescape(segs[0])
+	if !f.entityExists(target) {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Entity "+target+" not found")
+		return
+	}
+
+	if len(segs) == 2 {
+		switch r.Method {
+		case http.MethodPost:
+			f.createComment(w, r, tok, target)
+		case http.MethodGet:
+			f.listComments(w, r, target)
+		default:
+			methodNotAllowed(w, r)
+		}
+		return
+	}
+
+	c := f.findComment(target, unescape(segs[2]))
+	if c == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND",
+			fmt.Sprintf("Comment %s not found on %s", unescape(segs[2]), target))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, c.json())
+	case http.MethodDelete:
+		if !f.canWriteAs(w, r, tok, c.Actor) {
+			return
+		}
+		delete(f.comments, c.URN)
+		delete(f.reactions, c.URN)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// findComment returns the comment on target whose ID or URN is key.
+func (f *Fake) findComment(target, key string) *comment {
+	for _, c := range f.comments {
+		if c.URN != key && strconv.Itoa(c.Seq) != key {
+			continue
+		}
+		if c.Parent == target || (c.Parent == "" && c.Post == target) {
+			return c
+		}
+	}
+	return nil
+}
+
+// commentsOn returns the comments on target in creation order.
+func (f *Fake) commentsOn(target string) []*comment {
+	var out []*comment
+	for _, c := range f.comments {
+		if c.Parent == target || (c.Parent == "" && c.Post == target) {
+			out = append(out, c)
+		}
+	}
+	slices.SortFunc(out, func(a, b *comment) int { return a.Seq - b.Seq })
+	return out
+}
+
+// createComment adds a comment to target.
+func (f *Fake) createComment(w http.ResponseWriter, r *http.Request, tok *token, target string) {
+	var body struct {
+		Actor   string `json:"actor"`
+		Message text   `json:"message"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	actor := resolve(tok, body.Actor)
+	if strings.TrimSpace(body.Message.Text) == "" {
+		writeInputError(w, "message/text", "MISSING_FIELD", "field is required but not found and has no default value")
+		return
+	}
+	if !f.canWriteAs(w, r, tok, actor) {
+		return
+	}
+
+	c := &comment{Seq: f.nextID(), Actor: actor, Text: body.Message.Text, CreatedAt: time.Now()}
+	if parent := f.comments[target]; parent != nil {
+		c.Post, c.Parent = parent.Post, target
+	} else {
+		c.Post = target
+	}
+	c.URN = fmt.Sprintf("urn:li:comment:(%s,%d)", c.Post, c.Seq)
+	f.comments[c.URN] = c
+
+	w.Header().Set("X-Restli-Id", c.URN)
+	writeJSON(w, http.StatusCreated, c.json())
+}
+
+// listComments returns a page of the comments on target.
+func (f *Fake) listComments(w http.ResponseWriter, r *http.Request, target string) {
+	all := f.commentsOn(target)
+	start, count := pageParams(r.URL.Query())
+	lo, hi := page(len(all), start, count)
+	elements := []commentJSON{}
+	for _, c := range all[lo:hi] {
+		elements = append(elements, c.json())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"elements": elements,
+		"paging":   paging{Start: start, Count: count, Total: len(all)},
+	})
+}
+
+// reactionTypes are the accepted reaction types: the API names and the
+// names shown in LinkedIn's interface.
+var reactionTypes = []string{
+	"LIKE", "PRAISE", "EMPATHY", "INTEREST", "APPRECIATION", "ENTERTAINMENT",
+	"CELEBRATE", "LOVE", "INSIGHTFUL", "SUPPORT", "FUNNY",
+}
+
+// reactionJSON is a reaction as the API returns it.
+type reactionJSON struct {
+	ID           string `json:"id"`
+	Actor        string `json:"actor"`
+	Root         string `json:"root"`
+	ReactionType string `json:"reactionType"`
+	Created      int64  `json:"created"`
+}
+
+// newReactionJSON returns the API representation of actor's reaction to
+// entity.
+func newReactionJSON(actor, entity string, rx *reaction) reactionJSON {
+	return reactionJSON{
+		ID:    fmt.Sprintf("urn:li:reaction:(%s,%s)", actor, entity),
+		Actor: actor, Root: entity, ReactionType: rx.Type, Created: rx.CreatedAt.UnixMilli(),
+	}
+}
+
+// serveReactions handles /reactions and /reactions/{key}, where the key is
+// (actor:...,entity:...) for one reaction or (entity:...) for the finder.
+func (f *Fake) serveReactions(w http.ResponseWriter, r *http.Request, tok *token, segs []string) {
+	if len(segs) == 0 {
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r)
+			return
+		}
+		f.createReaction(w, r, tok)
+		return
+	}
+
+	if len(segs) > 1 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "No virtual resource found")
+		return
+	}
+	key, err := parseKey(segs[0])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ILLEGAL_ARGUMENT", err.Error())
+		return
+	}
+	entity := key["entity"]
+	if !f.entityExists(entity) {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Entity "+entity+" not found")
+		return
+	}
+
+	actor, single := key["actor"]
+	if !single {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r)
+			return
+		}
+		f.listReactions(w, r, entity)
+		return
+	}
+
+	actor = resolve(tok, actor)
+	rx := f.reactions[entity][actor]
+	if rx == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND",
+			fmt.Sprintf("No reaction by %s on %s", actor, entity))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, newReactionJSON(actor, entity, rx))
+	case http.MethodDelete:
+		if !f.canWriteAs(w, r, tok, actor) {
+			return
+		}
+		delete(f.reactions[entity], actor)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// createReaction records a reaction, replacing any earlier one by the same
+// actor. The actor comes from the actor query parameter, as LinkedIn
+// expects, or else from the body.
+func (f *Fake) createReaction(w http.ResponseWriter, r *http.Request, tok *token) {
+	var body struct {
+		Root         string `json:"root"`
+		ReactionType string `json:"reactionType"`
+		Actor        string `json:"actor"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	actor := r.URL.Query().Get("actor")
+	if actor == "" {
+		actor = body.Actor
+	}
+	actor = resolve(tok, actor)
+
+	if !slices.Contains(reactionTypes, body.ReactionType) {
+		writeInputError(w, "reactionType", "INVALID_VALUE", fmt.Sprintf("%q is not an enum symbol", body.ReactionType))
+		return
+	}
+	if !f.entityExists(body.Root) {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Entity "+body.Root+" not found")
+		return
+	}
+	if !f.canWriteAs(w, r, tok, actor) {
+		return
+	}
+
+	if f.reactions[body.Root] == nil {
+		f.reactions[body.Root] = make(map[string]*reaction)
+	}
+	rx := &reaction{Type: body.ReactionType, CreatedAt: time.Now()}
+	f.reactions[body.Root][actor] = rx
+
+	out := newReactionJSON(actor, body.Root, rx)
+	w.Header().Set("X-Restli-Id", out.ID)
+	writeJSON(w, http.StatusCreated, out)
+}
+
+// listReactions returns a page of the reactions to entity, ordered by
+// actor.
+func (f *Fake) listReactions(w http.ResponseWriter, r *http.Request, entity string) {
+	byActor := f.reactions[entity]
+	actors := make([]string, 0, len(byActor))
+	for a := range byActor {
+		actors = append(actors, a)
+	}
+	slices.Sort(actors)
+
+	start, count := pageParams(r.URL.Query())
+	lo, hi := page(len(actors), start, count)
+	elements := []reactionJSON{}
+	for _, a := range actors[lo:hi] {
+		elements = append(elements, newReactionJSON(a, entity, byActor[a]))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"elements": elements,
+		"paging":   paging{Start: start, Count: count, Total: len(actors)},
+	})
+}
@@ -0,0 +1,9 @@
+package model
+
+// Batch is the result of fetching many entities in one call. Results and
+// Errors are keyed by the keys as requested; every key is in exactly one
+// of them.
+type Batch[K comparable, T any] struct {
+	Results map[K]T
+	Errors  map[K]error
+}
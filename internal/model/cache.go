@@ -0,0 +1,21 @@
+package model
+
+// CacheStats summarizes the on-disk response cache.
+type CacheStats struct {
+	Dir       string               `json:"dir"`
+	Entries   int                  `json:"entries"`
+	Fresh     int                  `json:"fresh"`
+	Bytes     int64                `json:"bytes"`
+	Resources []CacheResourceStats `json:"resources"`
+}
+
+// CacheResourceStats summarizes the cached responses of one API resource,
+// such as posts. Fresh entries are served without asking the API; stale
+// ones are revalidated. TTL is how long an entry stays fresh.
+type CacheResourceStats struct {
+	Resource string `json:"resource"`
+	TTL      string `json:"ttl"`
+	Entries  int    `json:"entries"`
+	Fresh    int    `json:"fresh"`
+	Bytes    int64  `json:"bytes"`
+}
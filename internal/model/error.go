@@ -1,8 +1,11 @@
 package model
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Sentinel errors for common LinkedIn API failure modes.
@@ -19,20 +22,104 @@ var (
 	ErrServer = errors.New("server error")
 )
 
-// APIError represents a structured error returned by the LinkedIn API.
+// APIError is returned for every failed LinkedIn API call. The JSON fields
+// mirror LinkedIn's Rest.li error envelope; the rest describe the request
+// and response it came from. Body holds the raw response body.
 type APIError struct {
-	StatusCode int    `json:"status"`
-	Message    string `json:"message"`
-	Code       string `json:"serviceErrorCode"`
-	TraceID    string `json:"traceId"`
+	StatusCode       int           `json:"status"`
+	Code             string        `json:"code"`
+	ServiceErrorCode int           `json:"serviceErrorCode"`
+	Message          string        `json:"message"`
+	ErrorDetailType  string        `json:"errorDetailType,omitempty"`
+	ErrorDetails     *ErrorDetails `json:"errorDetails,omitempty"`
+
+	RequestID string     `json:"requestId,omitempty"`
+	Method    string     `json:"method,omitempty"`
+	Path      string     `json:"path,omitempty"`
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	Body      string     `json:"-"`
+}
+
+// ErrorDetails holds the field-level validation failures of a Rest.li error.
+type ErrorDetails struct {
+	InputErrors []InputError `json:"inputErrors"`
+}
+
+// InputError is a validation failure of one request field. FieldPath is
+// flattened from LinkedIn's input.inputPath.fieldPath.
+type InputError struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	FieldPath   string `json:"fieldPath,omitempty"`
+}
+
+// UnmarshalJSON decodes LinkedIn's nested input error representation.
+func (e *InputError) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+		FieldPath   string `json:"fieldPath"`
+		Input       struct {
+			InputPath struct {
+				FieldPath string `json:"fieldPath"`
+			} `json:"inputPath"`
+		} `json:"input"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = InputError{Code: raw.Code, Description: raw.Description, FieldPath: raw.FieldPath}
+	if e.FieldPath == "" {
+		e.FieldPath = raw.Input.InputPath.FieldPath
+	}
+	return nil
+}
+
+// RateLimit holds the rate limit state reported by LinkedIn's response
+// headers. RetryAfter is how long the server asked the client to wait.
+// HasRemaining tells a reported Remaining of zero from an absent one.
+type RateLimit struct {
+	Limit        int           `json:"limit,omitempty"`
+	Remaining    int           `json:"remaining"`
+	HasRemaining bool          `json:"-"`
+	Reset        time.Time     `json:"reset,omitzero"`
+	RetryAfter   time.Duration `json:"retryAfter,omitempty"`
 }
 
 // Error returns a human-readable representation of the API error.
 func (e *APIError) Error() string {
-	if e.Code != "" {
-		return fmt.Sprintf("linkedin api %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	var b strings.Builder
+	fmt.Fprintf(&b, "linkedin api %d", e.StatusCode)
+	switch {
+	case e.Code != "":
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	case e.ServiceErrorCode != 0:
+		fmt.Fprintf(&b, " (%d)", e.ServiceErrorCode)
+	}
+	b.WriteString(": " + e.Message)
+	for _, in := range e.InputErrors() {
+		desc := in.Description
+		if desc == "" {
+			desc = in.Code
+		}
+		if in.FieldPath != "" {
+			fmt.Fprintf(&b, "; %s: %s", in.FieldPath, desc)
+		} else {
+			b.WriteString("; " + desc)
+		}
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// InputErrors returns the field-level validation failures, if any.
+func (e *APIError) InputErrors() []InputError {
+	if e.ErrorDetails == nil {
+		return nil
 	}
-	return fmt.Sprintf("linkedin api %d: %s", e.StatusCode, e.Message)
+	return e.ErrorDetails.InputErrors
 }
 
 // Unwrap returns the corresponding sentinel error for the status code,
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 )
@@ -69,3 +70,42 @@ func TestAPIErrorUnwrapNilForUnknownStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestAPIErrorEnvelope(t *testing.T) {
+	body := `{
+		"status": 422,
+		"serviceErrorCode": 100,
+		"code": "UNPROCESSABLE_ENTITY",
+		"message": "Validation failed",
+		"errorDetailType": "com.linkedin.common.error.BadRequest",
+		"errorDetails": {"inputErrors": [{
+			"description": "Invalid value",
+			"input": {"inputPath": {"fieldPath": "visibility"}},
+			"code": "INVALID_VALUE"
+		}]}
+	}`
+	var e APIError
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if e.ServiceErrorCode != 100 || e.Code != "UNPROCESSABLE_ENTITY" {
+		t.Errorf("codes = %d, %q", e.ServiceErrorCode, e.Code)
+	}
+	inputs := e.InputErrors()
+	if len(inputs) != 1 || inputs[0].FieldPath != "visibility" || inputs[0].Code != "INVALID_VALUE" {
+		t.Fatalf("InputErrors = %+v", inputs)
+	}
+
+	e.RequestID = "abc"
+	want := "linkedin api 422 (UNPROCESSABLE_ENTITY): Validation failed; visibility: Invalid value (request ID abc)"
+	if got := e.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestAPIErrorServiceErrorCodeOnly(t *testing.T) {
+	e := &APIError{StatusCode: 401, ServiceErrorCode: 65601, Message: "expired"}
+	if got, want := e.Error(), "linkedin api 401 (65601): expired"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -11,6 +11,10 @@ type Post struct {
 	Visibility     string    `json:"visibility"`
 	CreatedAt      time.Time `json:"createdAt"`
 	LifecycleState string    `json:"lifecycleState"`
+	// ReshareParent is the post this post reshares, and ReshareRoot the
+	// original post at the start of the chain of reshares.
+	ReshareParent string `json:"reshareParent,omitempty"`
+	ReshareRoot   string `json:"reshareRoot,omitempty"`
 }
 
 // CreatePostRequest contains the fields needed to create a new post.
@@ -20,6 +24,19 @@ type CreatePostRequest struct {
 	MediaURN   string `json:"mediaUrn,omitempty"`
 	MediaTitle string `json:"mediaTitle,omitempty"`
 	AuthorURN  string `json:"authorUrn,omitempty"`
+	// ReshareParent, if set, makes the post a reshare of that post, with
+	// Text as the optional commentary.
+	ReshareParent string `json:"reshareParent,omitempty"`
+}
+
+// UpdatePostRequest lists the fields of an existing post to change. Nil
+// fields are left as they are; an empty call-to-action label or landing
+// page removes it.
+type UpdatePostRequest struct {
+	Text              *string `json:"text,omitempty"`
+	CallToActionLabel *string `json:"callToActionLabel,omitempty"`
+	LandingPage       *string `json:"landingPage,omitempty"`
+	LifecycleState    *string `json:"lifecycleState,omitempty"`
 }
 
 // PostList is a paginated list of posts.
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// RateLimitFamilies are the endpoint families API calls are classified
+// into for rate limiting, in display order.
+var RateLimitFamilies = []string{"posts", "socialActions", "reactions", "statistics", "other"}
+
+// Quota is the rate limit state of one API endpoint family. Budget and
+// Available describe lcli's client-side limit and the calls it currently
+// allows; Limit, Remaining and Reset are the X-RateLimit-* values LinkedIn
+// last reported, at Observed. Unset parts are nil or zero.
+type Quota struct {
+	Family    string    `json:"family"`
+	Budget    string    `json:"budget,omitempty"`
+	Available *int      `json:"available,omitempty"`
+	Limit     int       `json:"limit,omitempty"`
+	Remaining *int      `json:"remaining,omitempty"`
+	Reset     time.Time `json:"reset,omitzero"`
+	Observed  time.Time `json:"observed,omitzero"`
+}
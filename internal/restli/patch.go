@@ -0,0 +1,39 @@
+// patch.go builds the bodies of Rest.li PARTIAL_UPDATE requests.
+package restli
+
+import "encoding/json"
+
+// MethodHeader names the Rest.li method of a request whose HTTP method
+// does not imply it, such as a PARTIAL_UPDATE sent as a POST.
+const MethodHeader = "X-RestLi-Method"
+
+// Patch is the body of a PARTIAL_UPDATE request. Set maps fields to their
+// new values and Delete lists fields to remove; fields in neither are left
+// as they are. It encodes as {"patch":{"$set":{...},"$delete":[...]}}.
+type Patch struct {
+	Set    map[string]any
+	Delete []string
+}
+
+// RestliMethod returns PARTIAL_UPDATE, the method to send in MethodHeader
+// with a Patch body.
+func (Patch) RestliMethod() string {
+	return "PARTIAL_UPDATE"
+}
+
+// Empty reports whether the patch changes nothing.
+func (p Patch) Empty() bool {
+	return len(p.Set) == 0 && len(p.Delete) == 0
+}
+
+// MarshalJSON encodes the patch in the Rest.li patch format.
+func (p Patch) MarshalJSON() ([]byte, error) {
+	ops := make(map[string]any, 2)
+	if len(p.Set) > 0 {
+		ops["$set"] = p.Set
+	}
+	if len(p.Delete) > 0 {
+		ops["$delete"] = p.Delete
+	}
+	return json.Marshal(map[string]any{"patch": ops})
+}
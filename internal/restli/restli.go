@@ -0,0 +1,286 @@
+// Package restli encodes and decodes the Rest.li 2.0 URL syntax LinkedIn's
+// REST API expects with X-Restli-Protocol-Version 2.0.0: entity keys,
+// complex keys and records, lists, finders, batch keys and field
+// projections.
+//
+// Values are encoded for use anywhere in a URL. Strings, such as URNs, have
+// every character outside the URL unreserved set percent-encoded, so the
+// characters Rest.li uses for structure, ( ) , and :, only ever appear as
+// structure.
+package restli
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// List is a Rest.li list, encoded as List(a,b,...).
+type List []any
+
+// Record is a Rest.li record, such as a complex key, encoded as
+// (name:value,...) with its fields in name order.
+type Record map[string]any
+
+// Encode encodes v as a Rest.li 2.0 value. v may be a string, bool, integer,
+// float, List, []string or Record; anything else is encoded as the string
+// fmt.Sprint returns for it.
+func Encode(v any) string {
+	var b strings.Builder
+	encode(&b, v)
+	return b.String()
+}
+
+func encode(b *strings.Builder, v any) {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			b.WriteString("''")
+			return
+		}
+		b.WriteString(Escape(v))
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case int64:
+		b.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case List:
+		b.WriteString("List(")
+		for i, e := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			encode(b, e)
+		}
+		b.WriteByte(')')
+	case []string:
+		l := make(List, len(v))
+		for i, s := range v {
+			l[i] = s
+		}
+		encode(b, l)
+	case Record:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		b.WriteByte('(')
+		for i, name := range names {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(Escape(name))
+			b.WriteByte(':')
+			encode(b, v[name])
+		}
+		b.WriteByte(')')
+	default:
+		b.WriteString(Escape(fmt.Sprint(v)))
+	}
+}
+
+// Escape percent-encodes every byte of s outside the URL unreserved set
+// (letters, digits, - . _ ~).
+func Escape(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0xF])
+	}
+	return b.String()
+}
+
+// Decode parses a Rest.li 2.0 value as it appears in a URL, still
+// percent-encoded. Strings are returned unescaped, lists as List and records
+// as Record. Rest.li does not type scalars in URLs, so numbers and booleans
+// are returned as strings.
+func Decode(s string) (any, error) {
+	p := &parser{s: s}
+	v, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if p.i != len(s) {
+		return nil, p.errorf("unexpected %q", s[p.i])
+	}
+	return v, nil
+}
+
+// parser is a recursive descent parser over an encoded value.
+type parser struct {
+	s string
+	i int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("restli: invalid value %q at offset %d: %s", p.s, p.i, fmt.Sprintf(format, args...))
+}
+
+// value parses a list, record or string.
+func (p *parser) value() (any, error) {
+	rest := p.s[p.i:]
+	switch {
+	case strings.HasPrefix(rest, "List("):
+		p.i += len("List(")
+		list := List{}
+		if p.consume(')') {
+			return list, nil
+		}
+		for {
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+			if p.consume(')') {
+				return list, nil
+			}
+			if !p.consume(',') {
+				return nil, p.errorf("want , or ) in list")
+			}
+		}
+	case strings.HasPrefix(rest, "("):
+		p.i++
+		rec := Record{}
+		if p.consume(')') {
+			return rec, nil
+		}
+		for {
+			name, err := p.scalar(":")
+			if err != nil {
+				return nil, err
+			}
+			if name == "" || !p.consume(':') {
+				return nil, p.errorf("want name: in record")
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			rec[name] = v
+			if p.consume(')') {
+				return rec, nil
+			}
+			if !p.consume(',') {
+				return nil, p.errorf("want , or ) in record")
+			}
+		}
+	default:
+		return p.scalar("")
+	}
+}
+
+// scalar parses a string up to the next structural character, or also up
+// to one of stop.
+func (p *parser) scalar(stop string) (string, error) {
+	start := p.i
+	for p.i < len(p.s) && !strings.ContainsRune(",()"+stop, rune(p.s[p.i])) {
+		if stop == "" && p.s[p.i] == ':' {
+			return "", p.errorf("unescaped : in string")
+		}
+		p.i++
+	}
+	raw := p.s[start:p.i]
+	if raw == "''" {
+		return "", nil
+	}
+	s, err := url.PathUnescape(raw)
+	if err != nil {
+		return "", p.errorf("%v", err)
+	}
+	return s, nil
+}
+
+// consume advances past c if it is next.
+func (p *parser) consume(c byte) bool {
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+// Path returns a resource path built from segments, each encoded with
+// Encode: Path("posts", urn) is "/posts/urn%3Ali%3Ashare%3A1" and
+// Path("reactions", Record{"actor": a, "entity": e}) addresses a reaction by
+// its complex key.
+func Path(segments ...any) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteByte('/')
+		encode(&b, s)
+	}
+	return b.String()
+}
+
+// URL returns path with the query q appended, if it has any parameters.
+func URL(path string, q *Query) string {
+	if q == nil || len(q.params) == 0 {
+		return path
+	}
+	return path + "?" + q.Encode()
+}
+
+// Query is a Rest.li 2.0 query string. Parameters are encoded in the order
+// they are added. The zero value is an empty query.
+type Query struct {
+	params []string
+}
+
+// NewQuery returns an empty query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Finder returns a query calling the finder name (q=name).
+func Finder(name string) *Query {
+	return NewQuery().Set("q", name)
+}
+
+// Action returns a query calling the action name (action=name).
+func Action(name string) *Query {
+	return NewQuery().Set("action", name)
+}
+
+// BatchGet returns a query for the entities with the given keys
+// (ids=List(...)).
+func BatchGet(keys ...any) *Query {
+	return NewQuery().Set("ids", List(keys))
+}
+
+// Set adds the parameter name with the value v, encoded with Encode.
+func (q *Query) Set(name string, v any) *Query {
+	q.params = append(q.params, Escape(name)+"="+Encode(v))
+	return q
+}
+
+// Page adds the start and count paging parameters.
+func (q *Query) Page(start, count int) *Query {
+	return q.Set("start", start).Set("count", count)
+}
+
+// Fields adds a projection that limits the response to the given fields.
+// A field may select subfields, as in "content:(media:(id))".
+func (q *Query) Fields(fields ...string) *Query {
+	q.params = append(q.params, "fields="+strings.Join(fields, ","))
+	return q
+}
+
+// Encode returns the query string without the leading "?".
+func (q *Query) Encode() string {
+	return strings.Join(q.params, "&")
+}
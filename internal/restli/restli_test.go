@@ -0,0 +1,137 @@
+package restli
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{"urn:li:share:1", "urn%3Ali%3Ashare%3A1"},
+		{"a b,c(d)'e", "a%20b%2Cc%28d%29%27e"},
+		{"", "''"},
+		{int64(12345), "12345"},
+		{true, "true"},
+		{List{"urn:li:share:1", "urn:li:share:2"}, "List(urn%3Ali%3Ashare%3A1,urn%3Ali%3Ashare%3A2)"},
+		{[]string{}, "List()"},
+		{Record{"entity": "urn:li:share:2", "actor": "urn:li:person:1"},
+			"(actor:urn%3Ali%3Aperson%3A1,entity:urn%3Ali%3Ashare%3A2)"},
+		{Record{"timeRange": Record{"start": 1, "end": 2}, "ids": List{1, 2}},
+			"(ids:List(1,2),timeRange:(end:2,start:1))"},
+		{Record{}, "()"},
+	}
+	for _, tt := range tests {
+		if got := Encode(tt.v); got != tt.want {
+			t.Errorf("Encode(%#v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	values := []any{
+		"urn:li:comment:(urn:li:activity:1,2)",
+		"text with spaces, commas: and 'quotes' (ü)",
+		"",
+		List{},
+		List{"urn:li:share:1", List{"nested", ""}, Record{"k": "v"}},
+		Record{},
+		Record{"actor": "urn:li:person:1", "entity": "urn:li:comment:(urn:li:share:2,3)"},
+		Record{"outer": Record{"inner": List{"a", "b"}}},
+	}
+	for _, v := range values {
+		encoded := Encode(v)
+		got, err := Decode(encoded)
+		if err != nil {
+			t.Errorf("Decode(%q): %v", encoded, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, v) {
+			t.Errorf("Decode(Encode(%#v)) = %#v", v, got)
+		}
+		if again := Encode(got); again != encoded {
+			t.Errorf("Encode(Decode(%q)) = %q", encoded, again)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, bad := range []string{
+		"List(a,b",
+		"(a:1",
+		"(a)",
+		"(:1)",
+		"urn:li:share:1",
+		"a)b",
+		"List(a)x",
+		"%zz",
+	} {
+		if v, err := Decode(bad); err == nil {
+			t.Errorf("Decode(%q) = %#v, want error", bad, v)
+		}
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{Path("posts"), "/posts"},
+		{Path("posts", "urn:li:share:1"), "/posts/urn%3Ali%3Ashare%3A1"},
+		{Path("organizations", int64(42)), "/organizations/42"},
+		{Path("socialActions", "urn:li:share:1", "comments", "7"), "/socialActions/urn%3Ali%3Ashare%3A1/comments/7"},
+		{Path("reactions", Record{"actor": "me", "entity": "urn:li:share:1"}), "/reactions/(actor:me,entity:urn%3Ali%3Ashare%3A1)"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Path = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{URL("/posts", nil), "/posts"},
+		{URL("/posts", NewQuery()), "/posts"},
+		{URL("/posts", Finder("author").Set("author", "urn:li:person:1").Page(0, 10)),
+			"/posts?q=author&author=urn%3Ali%3Aperson%3A1&start=0&count=10"},
+		{URL("/organizations", BatchGet(int64(1), int64(2))), "/organizations?ids=List(1,2)"},
+		{URL("/posts", BatchGet("urn:li:share:1").Fields("id", "author", "content:(media:(id))")),
+			"/posts?ids=List(urn%3Ali%3Ashare%3A1)&fields=id,author,content:(media:(id))"},
+		{URL("/images", Action("initializeUpload")), "/images?action=initializeUpload"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("URL = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		p    Patch
+		want string
+	}{
+		{Patch{Set: map[string]any{"commentary": "Fixed", "lifecycleState": "PUBLISHED"}},
+			`{"patch":{"$set":{"commentary":"Fixed","lifecycleState":"PUBLISHED"}}}`},
+		{Patch{Delete: []string{"contentLandingPage"}}, `{"patch":{"$delete":["contentLandingPage"]}}`},
+		{Patch{}, `{"patch":{}}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("Marshal = %s, want %s", data, tt.want)
+		}
+	}
+	if (Patch{}).RestliMethod() != "PARTIAL_UPDATE" || !(Patch{}).Empty() {
+		t.Error("zero Patch")
+	}
+}
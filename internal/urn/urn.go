@@ -0,0 +1,260 @@
+// Package urn parses, validates and normalizes LinkedIn URNs, and resolves
+// the linkedin.com post, profile and company URLs users copy from their
+// browser.
+package urn
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Type is the entity type of a URN, such as share in urn:li:share:123.
+type Type string
+
+// The URN types lcli works with.
+const (
+	Person       Type = "person"
+	Organization Type = "organization"
+	Share        Type = "share"
+	UGCPost      Type = "ugcPost"
+	Activity     Type = "activity"
+	Comment      Type = "comment"
+	Image        Type = "image"
+	Video        Type = "video"
+	Document     Type = "document"
+)
+
+// Posts are the types that name a post. Comments and reactions also accept
+// activities; the posts API only shares and ugcPosts.
+var Posts = []Type{Share, UGCPost}
+
+// Entities are the types that can be commented on or reacted to.
+var Entities = []Type{Share, UGCPost, Activity, Comment}
+
+// Actors are the types that can author posts, comments and reactions.
+var Actors = []Type{Person, Organization}
+
+const prefix = "urn:li:"
+
+var (
+	numericID = regexp.MustCompile(`^[0-9]+$`)
+	opaqueID  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// idPatterns validates the ID of each type. Member and media IDs are
+// opaque strings; the others are numbers.
+var idPatterns = map[Type]*regexp.Regexp{
+	Person:       opaqueID,
+	Organization: numericID,
+	Share:        numericID,
+	UGCPost:      numericID,
+	Activity:     numericID,
+	Comment:      numericID,
+	Image:        opaqueID,
+	Video:        opaqueID,
+	Document:     opaqueID,
+}
+
+// URN is a parsed LinkedIn URN.
+type URN struct {
+	Type Type
+	ID   string
+	// Parent is the entity a comment is on, from the composite comment URN
+	// urn:li:comment:(PARENT,ID). It is nil for other types.
+	Parent *URN
+}
+
+// New returns the URN of type t with the given ID.
+func New(t Type, id string) URN {
+	return URN{Type: t, ID: id}
+}
+
+// String formats u as a URN.
+func (u URN) String() string {
+	if u.Type == Comment && u.Parent != nil {
+		return fmt.Sprintf("%scomment:(%s,%s)", prefix, u.Parent, u.ID)
+	}
+	return prefix + string(u.Type) + ":" + u.ID
+}
+
+// Is reports whether u has one of the given types.
+func (u URN) Is(types ...Type) bool {
+	for _, t := range types {
+		if u.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses and validates a URN such as urn:li:share:123 or
+// urn:li:comment:(urn:li:activity:123,456). The comment form with an
+// abbreviated parent, urn:li:comment:(activity:123,456), which LinkedIn's
+// web pages use, is accepted too.
+func Parse(s string) (URN, error) {
+	rest, ok := strings.CutPrefix(s, prefix)
+	if !ok {
+		return URN{}, fmt.Errorf("invalid URN %q: want urn:li:TYPE:ID", s)
+	}
+	name, id, ok := strings.Cut(rest, ":")
+	t := Type(name)
+	pattern, known := idPatterns[t]
+	switch {
+	case !ok || name == "":
+		return URN{}, fmt.Errorf("invalid URN %q: want urn:li:TYPE:ID", s)
+	case !known:
+		return URN{}, fmt.Errorf("unsupported URN type %q in %q", name, s)
+	case t == Comment && strings.HasPrefix(id, "("):
+		return parseComment(s, id)
+	case !pattern.MatchString(id):
+		return URN{}, fmt.Errorf("invalid %s ID %q in %q", t, id, s)
+	}
+	return URN{Type: t, ID: id}, nil
+}
+
+// parseComment parses the (PARENT,ID) part of a composite comment URN. The
+// parent may itself be a comment, so the ID follows the last comma.
+func parseComment(s, tuple string) (URN, error) {
+	inner, ok := strings.CutPrefix(tuple, "(")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, ")")
+	}
+	i := strings.LastIndexByte(inner, ',')
+	if !ok || i < 0 {
+		return URN{}, fmt.Errorf("invalid comment URN %q: want urn:li:comment:(PARENT,ID)", s)
+	}
+	parentText, id := inner[:i], inner[i+1:]
+	if !strings.HasPrefix(parentText, prefix) {
+		parentText = prefix + parentText
+	}
+	parent, err := Parse(parentText)
+	if err != nil {
+		return URN{}, fmt.Errorf("invalid comment URN %q: %w", s, err)
+	}
+	if !parent.Is(Entities...) {
+		return URN{}, fmt.Errorf("invalid comment URN %q: a %s cannot be commented on", s, parent.Type)
+	}
+	if !numericID.MatchString(id) {
+		return URN{}, fmt.Errorf("invalid comment ID %q in %q", id, s)
+	}
+	return URN{Type: Comment, ID: id, Parent: &parent}, nil
+}
+
+// VanityError reports a profile or company URL that names its entity by
+// vanity name, which only the API can resolve to a URN.
+type VanityError struct {
+	// Type is Person for /in/ URLs and Organization for /company/ URLs.
+	Type Type
+	Name string
+}
+
+func (e *VanityError) Error() string {
+	if e.Type == Organization {
+		return fmt.Sprintf("the company URL names %q by vanity name (look it up with \"lcli org info --vanity %s\")", e.Name, e.Name)
+	}
+	return fmt.Sprintf("the profile URL names %q by vanity name, which LinkedIn's API cannot resolve (use the member's person URN)", e.Name)
+}
+
+// postURLActivity matches the entity in the slug of a linkedin.com/posts/
+// URL, e.g. jane-doe_launch-activity-7100000000000000000-AbCd.
+var postURLActivity = regexp.MustCompile(`-(activity|share|ugcPost)-([0-9]+)(-|$)`)
+
+// FromURL returns the URN a linkedin.com URL points at. It understands post
+// URLs (/feed/update/URN, /embed/feed/update/URN and /posts/SLUG), comment
+// links (a post URL with commentUrn), /company/ID and /in/NAME. Vanity
+// profile and company URLs return a *VanityError.
+func FromURL(raw string) (URN, error) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return URN{}, fmt.Errorf("invalid URL %q", raw)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return URN{}, fmt.Errorf("%q is not a linkedin.com URL", raw)
+	}
+
+	if c := u.Query().Get("commentUrn"); c != "" {
+		return Parse(c)
+	}
+
+	segs := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(segs) >= 3 && segs[0] == "feed" && segs[1] == "update":
+		return Parse(segs[2])
+	case len(segs) >= 4 && segs[0] == "embed" && segs[1] == "feed" && segs[2] == "update":
+		return Parse(segs[3])
+	case len(segs) >= 2 && segs[0] == "posts":
+		if m := postURLActivity.FindStringSubmatch(segs[1]); m != nil {
+			return URN{Type: Type(m[1]), ID: m[2]}, nil
+		}
+	case len(segs) >= 2 && segs[0] == "company":
+		if numericID.MatchString(segs[1]) {
+			return URN{Type: Organization, ID: segs[1]}, nil
+		}
+		return URN{}, &VanityError{Type: Organization, Name: segs[1]}
+	case len(segs) >= 2 && segs[0] == "in":
+		return URN{}, &VanityError{Type: Person, Name: segs[1]}
+	}
+	return URN{}, fmt.Errorf("cannot find a post, comment, profile or company in %q", raw)
+}
+
+// Normalize resolves user input to a URN of one of the wanted types. The
+// input may be a URN, a linkedin.com URL (see FromURL) or a bare ID, which
+// is taken to be of the first wanted type.
+func Normalize(input string, want ...Type) (URN, error) {
+	input = strings.TrimSpace(input)
+	var u URN
+	var err error
+	switch {
+	case input == "":
+		return URN{}, errors.New("empty URN")
+	case strings.HasPrefix(input, prefix):
+		u, err = Parse(input)
+	case strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://"):
+		u, err = FromURL(input)
+	case len(want) > 0 && idPatterns[want[0]].MatchString(input):
+		u = URN{Type: want[0], ID: input}
+	default:
+		return URN{}, fmt.Errorf("%q is not a URN, ID or linkedin.com URL of type %s", input, typeList(want))
+	}
+	if err != nil {
+		return URN{}, err
+	}
+	if len(want) > 0 && !u.Is(want...) {
+		return URN{}, wrongType(input, u, want)
+	}
+	return u, nil
+}
+
+// wrongType explains that u is not one of the wanted types.
+func wrongType(input string, u URN, want []Type) error {
+	name := u.String()
+	if input != name {
+		name = fmt.Sprintf("%s (from %q)", name, input)
+	}
+	msg := fmt.Sprintf("%s has type %s, but a URN of type %s is needed", name, u.Type, typeList(want))
+	if u.Type == Activity {
+		// Feed URLs show activity URNs, which the posts API does not accept.
+		msg += "; use the share or ugcPost URN that post create and post list print"
+	}
+	return errors.New(msg)
+}
+
+// typeList formats types as "share, ugcPost or activity".
+func typeList(types []Type) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	switch len(names) {
+	case 0:
+		return "LinkedIn"
+	case 1:
+		return names[0]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+	}
+}
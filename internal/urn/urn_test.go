@@ -0,0 +1,160 @@
+package urn
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		typ  Type
+	}{
+		{"urn:li:share:7000", "urn:li:share:7000", Share},
+		{"urn:li:ugcPost:7001", "urn:li:ugcPost:7001", UGCPost},
+		{"urn:li:person:abc-DEF_1", "urn:li:person:abc-DEF_1", Person},
+		{"urn:li:organization:1001", "urn:li:organization:1001", Organization},
+		{"urn:li:comment:(urn:li:activity:123,456)", "urn:li:comment:(urn:li:activity:123,456)", Comment},
+		{"urn:li:comment:(activity:123,456)", "urn:li:comment:(urn:li:activity:123,456)", Comment},
+	}
+	for _, tt := range tests {
+		u, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if u.Type != tt.typ || u.String() != tt.want {
+			t.Errorf("Parse(%q) = %s (%s), want %s (%s)", tt.in, u, u.Type, tt.want, tt.typ)
+		}
+	}
+
+	c, _ := Parse("urn:li:comment:(urn:li:share:1,2)")
+	if c.ID != "2" || c.Parent == nil || c.Parent.String() != "urn:li:share:1" {
+		t.Errorf("comment = %+v", c)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"share:1",
+		"urn:li:share",
+		"urn:li:share:",
+		"urn:li:share:abc",
+		"urn:li:widget:1",
+		"urn:li:organization:-1",
+		"urn:li:comment:(urn:li:share:1)",
+		"urn:li:comment:(urn:li:share:1,x)",
+		"urn:li:comment:(urn:li:person:a,1)",
+	} {
+		if u, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %s, want error", in, u)
+		}
+	}
+}
+
+func TestFromURL(t *testing.T) {
+	tests := map[string]string{
+		"https://www.linkedin.com/feed/update/urn:li:activity:7100/":                                                 "urn:li:activity:7100",
+		"https://www.linkedin.com/feed/update/urn:li:share:7000?utm_source=share":                                    "urn:li:share:7000",
+		"https://www.linkedin.com/embed/feed/update/urn:li:ugcPost:7001":                                             "urn:li:ugcPost:7001",
+		"https://www.linkedin.com/posts/jane-doe_launch-activity-7100000000000000000-AbCd":                           "urn:li:activity:7100000000000000000",
+		"https://linkedin.com/posts/jane-doe_launch-ugcPost-7001-x":                                                  "urn:li:ugcPost:7001",
+		"https://www.linkedin.com/feed/update/urn:li:activity:1/?commentUrn=urn%3Ali%3Acomment%3A(activity%3A1%2C2)": "urn:li:comment:(urn:li:activity:1,2)",
+		"https://www.linkedin.com/company/1001/":                                                                     "urn:li:organization:1001",
+	}
+	for in, want := range tests {
+		u, err := FromURL(in)
+		if err != nil {
+			t.Errorf("FromURL(%q): %v", in, err)
+			continue
+		}
+		if u.String() != want {
+			t.Errorf("FromURL(%q) = %s, want %s", in, u, want)
+		}
+	}
+
+	for _, in := range []string{
+		"https://example.com/feed/update/urn:li:share:1",
+		"https://evil-linkedin.com/feed/update/urn:li:share:1",
+		"ftp://www.linkedin.com/feed/update/urn:li:share:1",
+		"https://www.linkedin.com/jobs/view/123",
+	} {
+		if u, err := FromURL(in); err == nil {
+			t.Errorf("FromURL(%q) = %s, want error", in, u)
+		}
+	}
+}
+
+func TestFromURLVanity(t *testing.T) {
+	tests := []struct {
+		in   string
+		typ  Type
+		name string
+	}{
+		{"https://www.linkedin.com/company/acme/", Organization, "acme"},
+		{"https://www.linkedin.com/in/jane-doe", Person, "jane-doe"},
+	}
+	for _, tt := range tests {
+		_, err := FromURL(tt.in)
+		var ve *VanityError
+		if !errors.As(err, &ve) || ve.Type != tt.typ || ve.Name != tt.name {
+			t.Errorf("FromURL(%q) = %v, want VanityError{%s, %s}", tt.in, err, tt.typ, tt.name)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Type
+		out  string
+	}{
+		{"urn:li:share:7000", Posts, "urn:li:share:7000"},
+		{" 7000 ", Posts, "urn:li:share:7000"},
+		{"1001", []Type{Organization}, "urn:li:organization:1001"},
+		{"https://www.linkedin.com/feed/update/urn:li:activity:7100", Entities, "urn:li:activity:7100"},
+		{"abc123", []Type{Person}, "urn:li:person:abc123"},
+		{"urn:li:organization:1", Actors, "urn:li:organization:1"},
+		{"urn:li:image:C4D", nil, "urn:li:image:C4D"},
+	}
+	for _, tt := range tests {
+		u, err := Normalize(tt.in, tt.want...)
+		if err != nil {
+			t.Errorf("Normalize(%q): %v", tt.in, err)
+			continue
+		}
+		if u.String() != tt.out {
+			t.Errorf("Normalize(%q) = %s, want %s", tt.in, u, tt.out)
+		}
+	}
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Type
+		msg  string
+	}{
+		{"", Posts, "empty URN"},
+		{"urn", Posts, "of type share or ugcPost"},
+		{"abc", []Type{Organization}, "of type organization"},
+		{"urn:li:person:abc", Posts, "has type person, but a URN of type share or ugcPost is needed"},
+		{"https://www.linkedin.com/feed/update/urn:li:activity:1", Posts, "use the share or ugcPost URN"},
+		{"https://www.linkedin.com/company/acme", []Type{Organization}, "org info --vanity acme"},
+	}
+	for _, tt := range tests {
+		_, err := Normalize(tt.in, tt.want...)
+		if err == nil || !strings.Contains(err.Error(), tt.msg) {
+			t.Errorf("Normalize(%q) = %v, want error containing %q", tt.in, err, tt.msg)
+		}
+	}
+}
+
+func TestTypeList(t *testing.T) {
+	if got := typeList(Entities); got != "share, ugcPost, activity or comment" {
+		t.Errorf("typeList = %q", got)
+	}
+}